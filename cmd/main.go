@@ -14,6 +14,7 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/event_handler"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	"github.com/keptn-contrib/dynatrace-service/internal/onboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
 
 	api "github.com/keptn/go-utils/pkg/api/utils"
 	eventsource "github.com/keptn/go-utils/pkg/sdk/connector/eventsource/nats"
@@ -66,6 +67,9 @@ func _main() int {
 	replyCtx, stopReplyPeriod := context2.NewTriggeredTimeoutContext(ctx, workCtx, env.GetReplyGracePeriod(), "replyCtx")
 	defer stopReplyPeriod()
 
+	// dashboardCache is shared by all events so that the tiles of unmodified dashboards are not parsed again, although the dashboards are still downloaded to detect modifications
+	dashboardCache := dashboard.NewCache()
+
 	workerWaitGroup := &sync.WaitGroup{}
 	if env.IsServiceSyncEnabled() {
 		workerWaitGroup.Add(1)
@@ -102,7 +106,7 @@ func _main() int {
 		workerWaitGroup.Add(1)
 		go func() {
 			defer workerWaitGroup.Done()
-			gotEvent(workCtx, replyCtx, eventSenderClient, event)
+			gotEvent(workCtx, replyCtx, eventSenderClient, event, dashboardCache)
 		}()
 	}})
 	if err != nil {
@@ -167,6 +171,7 @@ func (d dynatraceService) RegistrationData() controlplane.RegistrationData {
 			createEventSubscription("sh.keptn.event.action.started"),
 			createEventSubscription("sh.keptn.event.action.finished"),
 			createEventSubscription("sh.keptn.event.get-sli.triggered"),
+			createEventSubscription("sh.keptn.event.lint-dashboard.triggered"),
 			createEventSubscription("sh.keptn.event.deployment.finished"),
 			createEventSubscription("sh.keptn.event.test.triggered"),
 			createEventSubscription("sh.keptn.event.test.finished"),
@@ -176,14 +181,14 @@ func (d dynatraceService) RegistrationData() controlplane.RegistrationData {
 	}
 }
 
-func gotEvent(workCtx context.Context, replyCtx context.Context, eventSender *keptn.EventSenderClient, event cloudevents.Event) {
+func gotEvent(workCtx context.Context, replyCtx context.Context, eventSender *keptn.EventSenderClient, event cloudevents.Event, dashboardCache *dashboard.Cache) {
 	clientFactory, err := keptn.NewClientFactory()
 	if err != nil {
 		log.WithError(err).Error("Could not create a Keptn client factory")
 		return
 	}
 
-	handler, err := event_handler.NewEventHandler(workCtx, clientFactory, eventSender, event)
+	handler, err := event_handler.NewEventHandler(workCtx, clientFactory, eventSender, event, dashboardCache)
 	if err != nil {
 		log.WithError(err).Error("NewEventHandler() returned an error")
 		return
@@ -0,0 +1,86 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/audit"
+)
+
+// AuditLogsPath is the base endpoint for Audit logs API v2
+const AuditLogsPath = "/api/v2/auditlogs"
+
+// AuditLogsRequiredDelay is delay required between the end of a timeframe and an Audit logs v2 API request using it.
+const AuditLogsRequiredDelay = 2 * time.Minute
+
+// AuditLogsMaximumWait is maximum acceptable wait time between the end of a timeframe and an Audit logs v2 API request using it.
+const AuditLogsMaximumWait = 4 * time.Minute
+
+const filterKey = "filter"
+
+// AuditLogsClientQueryRequest encapsulates the request for the AuditLogsClient's GetTotalCountByQuery method.
+type AuditLogsClientQueryRequest struct {
+	query     audit.Query
+	timeframe common.Timeframe
+}
+
+// NewAuditLogsClientQueryRequest creates new AuditLogsClientQueryRequest.
+func NewAuditLogsClientQueryRequest(query audit.Query, timeframe common.Timeframe) AuditLogsClientQueryRequest {
+	return AuditLogsClientQueryRequest{
+		query:     query,
+		timeframe: timeframe,
+	}
+}
+
+// RequestString encodes AuditLogsClientQueryRequest into a request string.
+func (q *AuditLogsClientQueryRequest) RequestString() string {
+	queryParameters := newQueryParameters()
+	if q.query.GetFilter() != "" {
+		queryParameters.add(filterKey, q.query.GetFilter())
+	}
+	queryParameters.add(fromKey, common.TimestampToUnixMillisecondsString(q.timeframe.Start()))
+	queryParameters.add(toKey, common.TimestampToUnixMillisecondsString(q.timeframe.End()))
+
+	return AuditLogsPath + "?" + queryParameters.encode()
+}
+
+// auditLogsQueryResult represents the result of a query to /api/v2/auditlogs.
+// Here only totalCount is considered as that is the only field that is used.
+type auditLogsQueryResult struct {
+	TotalCount int `json:"totalCount"`
+}
+
+// AuditLogsClient is a client for interacting with the Dynatrace audit logs endpoints.
+type AuditLogsClient struct {
+	client ClientInterface
+}
+
+// NewAuditLogsClient creates a new AuditLogsClient.
+func NewAuditLogsClient(client ClientInterface) *AuditLogsClient {
+	return &AuditLogsClient{
+		client: client,
+	}
+}
+
+// GetTotalCountByQuery calls the Dynatrace API to retrieve the total count of audit log entries for the given query and timeframe.
+func (ac *AuditLogsClient) GetTotalCountByQuery(ctx context.Context, request AuditLogsClientQueryRequest) (int, error) {
+	err := NewTimeframeDelay(request.timeframe, AuditLogsRequiredDelay, AuditLogsMaximumWait).Wait(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := ac.client.Get(ctx, request.RequestString())
+	if err != nil {
+		return 0, err
+	}
+
+	var result auditLogsQueryResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return 0, common.NewUnmarshalJSONError("audit logs", err)
+	}
+
+	return result.TotalCount, nil
+}
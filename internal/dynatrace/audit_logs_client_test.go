@@ -0,0 +1,28 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/audit"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogsClient_GetTotalCountByQuery(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/v2/auditlogs?filter=category%28%22CONFIG%22%29%2CeventType%28%22UPDATE%22%29&from=1654693200000&to=1654696800000", "./testdata/test_auditlogsclient_gettotalcountbyquery.json")
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframeParser("2022-06-08T13:00:00Z", "2022-06-08T14:00:00Z").Parse()
+	assert.NoError(t, err)
+
+	auditQuery := audit.NewQuery("category(\"CONFIG\"),eventType(\"UPDATE\")")
+	totalCount, err := NewAuditLogsClient(dtClient).GetTotalCountByQuery(context.TODO(), NewAuditLogsClientQueryRequest(auditQuery, *timeframe))
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, totalCount)
+}
@@ -7,15 +7,36 @@ const (
 	// DataExplorerTileType is the tile type for data explorer dashboard tiles
 	DataExplorerTileType = "DATA_EXPLORER"
 
+	// DQLTileType is the tile type for DQL query sections of Dynatrace Notebooks
+	DQLTileType = "DQL"
+
+	// HeaderTileType is the tile type for header dashboard tiles
+	HeaderTileType = "HEADER"
+
+	// HostsTileType is the tile type for host health dashboard tiles
+	HostsTileType = "HOSTS"
+
 	// MarkdownTileType is the tile type for markdown dashboard tiles
 	MarkdownTileType = "MARKDOWN"
 
 	// OpenProblemsTileType is the tile type for open problems dashboard tiles
 	OpenProblemsTileType = "OPEN_PROBLEMS"
 
+	// ServicesTileType is the tile type for service health dashboard tiles
+	ServicesTileType = "SERVICES"
+
 	// SLOTileType is the tile type for SLO dashboard tiles
 	SLOTileType = "SLO"
 
+	// SyntheticTestsTileType is the tile type for synthetic monitors dashboard tiles
+	SyntheticTestsTileType = "SYNTHETIC_TESTS"
+
+	// SyntheticSingleWebCheckTileType is the tile type for single browser monitor dashboard tiles
+	SyntheticSingleWebCheckTileType = "SYNTHETIC_SINGLE_WEBCHECK"
+
+	// SyntheticHTTPMonitorTileType is the tile type for single HTTP monitor dashboard tiles
+	SyntheticHTTPMonitorTileType = "SYNTHETIC_HTTP_MONITOR"
+
 	// USQLTileType is the tile type for USQL dashboard tiles
 	USQLTileType = "DTAQL"
 )
@@ -56,6 +77,9 @@ type DashboardMetadata struct {
 	SharingDetails  SharingDetails   `json:"sharingDetails"`
 	DashboardFilter *DashboardFilter `json:"dashboardFilter,omitempty"`
 	Tags            []string         `json:"tags,omitempty"`
+
+	// LastModified is the time the dashboard was last modified in milliseconds since the epoch, or 0 if unknown.
+	LastModified int64 `json:"lastModified,omitempty"`
 }
 
 type SharingDetails struct {
@@ -66,6 +90,7 @@ type SharingDetails struct {
 type DashboardFilter struct {
 	Timeframe      string               `json:"timeframe,omitempty"`
 	ManagementZone *ManagementZoneEntry `json:"managementZone,omitempty"`
+	Tags           []string             `json:"tags,omitempty"`
 }
 
 type ManagementZoneEntry struct {
@@ -90,6 +115,7 @@ type Tile struct {
 	FilterConfig              *FilterConfig               `json:"filterConfig,omitempty"`
 	VisualConfig              *VisualizationConfiguration `json:"visualConfig,omitempty"`
 	MetricExpressions         []string                    `json:"metricExpressions,omitempty"`
+	TimeFrameShift            string                      `json:"timeFrameShift,omitempty"`
 }
 
 // VisualizationConfiguration is the visual configuration for a dashboard tile.
@@ -130,12 +156,16 @@ type Bounds struct {
 type TileFilter struct {
 	Timeframe      string               `json:"timeframe,omitempty"`
 	ManagementZone *ManagementZoneEntry `json:"managementZone,omitempty"`
+	Entities       []string             `json:"entities,omitempty"`
 }
 
-// DataExplorerQuery Query Definition for DATA_EXPLORER dashboard tile
+// DataExplorerQuery is a query of a Data Explorer tile.
+// Queries defined using the Code tab as well as expressions over other queries, e.g. (A)/(B), specify a metric selector.
+// Queries that are not enabled are hidden in the tile.
 type DataExplorerQuery struct {
-	ID      string `json:"id"`
-	Enabled bool   `json:"enabled"`
+	ID             string `json:"id"`
+	MetricSelector string `json:"metricSelector,omitempty"`
+	Enabled        bool   `json:"enabled"`
 }
 
 type FilterConfig struct {
@@ -0,0 +1,115 @@
+package dynatrace
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// KeptnProjectDashboardTagKey is the key of the dashboard tag specifying the Keptn project, e.g. keptn_project:sockshop.
+	KeptnProjectDashboardTagKey = "keptn_project"
+
+	// KeptnStageDashboardTagKey is the key of the dashboard tag specifying the Keptn stage, e.g. keptn_stage:staging.
+	KeptnStageDashboardTagKey = "keptn_stage"
+
+	// KeptnServiceDashboardTagKey is the key of the dashboard tag specifying the Keptn service, e.g. keptn_service:carts.
+	KeptnServiceDashboardTagKey = "keptn_service"
+
+	// dashboardTagWildcardValue may be used as a tag value to match any stage or service.
+	dashboardTagWildcardValue = "*"
+)
+
+// NewKeptnProjectDashboardTag creates a dashboard tag specifying the Keptn project.
+func NewKeptnProjectDashboardTag(project string) string {
+	return KeptnProjectDashboardTagKey + ":" + project
+}
+
+// tagMatch describes how a dashboard's tags match a single Keptn dimension, i.e. stage or service.
+type tagMatch int
+
+const (
+	tagMismatch tagMatch = iota
+	tagWildcardMatch
+	tagExactMatch
+)
+
+// matchDashboardTag determines how the tags match the specified key and value.
+// A tag with exactly the value is an exact match. A wildcard tag, or no tag for the key at all, is a wildcard match. Otherwise the tags do not match.
+func matchDashboardTag(tags []string, key string, value string) tagMatch {
+	keyFound := false
+	wildcardFound := false
+	for _, tag := range tags {
+		tagKey, tagValue, _ := strings.Cut(tag, ":")
+		if !strings.EqualFold(tagKey, key) {
+			continue
+		}
+
+		keyFound = true
+		if strings.EqualFold(tagValue, value) {
+			return tagExactMatch
+		}
+
+		if tagValue == dashboardTagWildcardValue {
+			wildcardFound = true
+		}
+	}
+
+	if !keyFound || wildcardFound {
+		return tagWildcardMatch
+	}
+
+	return tagMismatch
+}
+
+// getDashboardTagPrecedence returns the precedence of a dashboard based on its tags, or -1 if the dashboard does not match.
+// Service-specific dashboards take precedence over stage-specific ones, which in turn take precedence over project-level dashboards.
+func getDashboardTagPrecedence(tags []string, project string, stage string, service string) int {
+	if matchDashboardTag(tags, KeptnProjectDashboardTagKey, project) != tagExactMatch {
+		return -1
+	}
+
+	serviceMatch := matchDashboardTag(tags, KeptnServiceDashboardTagKey, service)
+	stageMatch := matchDashboardTag(tags, KeptnStageDashboardTagKey, stage)
+	if serviceMatch == tagMismatch || stageMatch == tagMismatch {
+		return -1
+	}
+
+	precedence := 0
+	if serviceMatch == tagExactMatch {
+		precedence += 2
+	}
+	if stageMatch == tagExactMatch {
+		precedence++
+	}
+	return precedence
+}
+
+// SearchForDashboardMatchingTags searches for the dashboard whose tags best match "keptn_project:PROJECT", "keptn_stage:STAGE" and "keptn_service:SERVICE".
+// A dashboard without a stage or service tag, or with the wildcard value "*", matches any stage or service. The project tag must always match.
+// Dashboards for project, stage and service take precedence over those for project and service, followed by those for project and stage and finally project-level dashboards.
+// It returns the dashboard if exactly one dashboard matches with the highest precedence or an error otherwise.
+func SearchForDashboardMatchingTags(dashboards []*Dashboard, project string, stage string, service string) (*Dashboard, error) {
+	bestPrecedence := -1
+	var bestDashboards []*Dashboard
+	for _, dashboard := range dashboards {
+		precedence := getDashboardTagPrecedence(dashboard.DashboardMetadata.Tags, project, stage, service)
+		if precedence < 0 || precedence < bestPrecedence {
+			continue
+		}
+
+		if precedence > bestPrecedence {
+			bestPrecedence = precedence
+			bestDashboards = nil
+		}
+		bestDashboards = append(bestDashboards, dashboard)
+	}
+
+	switch len(bestDashboards) {
+	case 0:
+		return nil, fmt.Errorf("no dashboard tags match the criteria '%s:%s', '%s:%s', '%s:%s'", KeptnProjectDashboardTagKey, project, KeptnStageDashboardTagKey, stage, KeptnServiceDashboardTagKey, service)
+	case 1:
+		return bestDashboards[0], nil
+	default:
+		return nil, fmt.Errorf("%d dashboards match the tag criteria '%s:%s', '%s:%s', '%s:%s' with the same precedence", len(bestDashboards), KeptnProjectDashboardTagKey, project, KeptnStageDashboardTagKey, stage, KeptnServiceDashboardTagKey, service)
+	}
+}
@@ -0,0 +1,115 @@
+package dynatrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchForDashboardMatchingTags(t *testing.T) {
+
+	const project = "sockshop"
+	const stage = "staging"
+	const service = "carts"
+
+	projectDashboard := createDashboardWithTags("project", "keptn_project:sockshop")
+	projectWildcardDashboard := createDashboardWithTags("project-wildcard", "keptn_project:sockshop", "keptn_stage:*", "keptn_service:*")
+	stageDashboard := createDashboardWithTags("stage", "keptn_project:sockshop", "keptn_stage:staging")
+	serviceDashboard := createDashboardWithTags("service", "keptn_project:sockshop", "keptn_service:carts")
+	fullDashboard := createDashboardWithTags("full", "keptn_project:sockshop", "keptn_stage:staging", "keptn_service:carts", "team:carts")
+
+	tests := []struct {
+		name                string
+		dashboards          []*Dashboard
+		expectedDashboardID string
+		expectError         bool
+		partialErrorMessage string
+	}{
+		{
+			name:                "full match, single dashboard",
+			dashboards:          []*Dashboard{fullDashboard},
+			expectedDashboardID: "full",
+		},
+		{
+			name:                "full match takes precedence over service, stage and project dashboards",
+			dashboards:          []*Dashboard{projectDashboard, stageDashboard, fullDashboard, serviceDashboard},
+			expectedDashboardID: "full",
+		},
+		{
+			name:                "service dashboard takes precedence over stage dashboard",
+			dashboards:          []*Dashboard{stageDashboard, serviceDashboard, projectDashboard},
+			expectedDashboardID: "service",
+		},
+		{
+			name:                "stage dashboard takes precedence over project dashboard",
+			dashboards:          []*Dashboard{projectDashboard, stageDashboard},
+			expectedDashboardID: "stage",
+		},
+		{
+			name:                "project dashboard is used as fallback",
+			dashboards:          []*Dashboard{projectDashboard, createDashboardWithTags("other-service", "keptn_project:sockshop", "keptn_service:orders")},
+			expectedDashboardID: "project",
+		},
+		{
+			name:                "wildcard tags match any stage and service",
+			dashboards:          []*Dashboard{projectWildcardDashboard, createDashboardWithTags("other-stage", "keptn_project:sockshop", "keptn_stage:production", "keptn_service:carts")},
+			expectedDashboardID: "project-wildcard",
+		},
+		{
+			name:                "tags are matched case-insensitively",
+			dashboards:          []*Dashboard{createDashboardWithTags("upper-case", "KEPTN_PROJECT:Sockshop", "keptn_service:Carts")},
+			expectedDashboardID: "upper-case",
+		},
+		{
+			name:                "one of several service tags matches",
+			dashboards:          []*Dashboard{createDashboardWithTags("several-services", "keptn_project:sockshop", "keptn_service:orders", "keptn_service:carts")},
+			expectedDashboardID: "several-services",
+		},
+		{
+			name: "no match, because project, stage or service differ",
+			dashboards: []*Dashboard{
+				createDashboardWithTags("other-project", "keptn_project:sockshop-v2"),
+				createDashboardWithTags("other-stage", "keptn_project:sockshop", "keptn_stage:production"),
+				createDashboardWithTags("other-service", "keptn_project:sockshop", "keptn_service:orders"),
+				createDashboardWithTags("untagged")},
+			expectError:         true,
+			partialErrorMessage: "no dashboard tags match the criteria",
+		},
+		{
+			name:                "no match, because there are no dashboards",
+			dashboards:          []*Dashboard{},
+			expectError:         true,
+			partialErrorMessage: "no dashboard tags match the criteria",
+		},
+		{
+			name:                "multiple dashboards match with the same precedence",
+			dashboards:          []*Dashboard{projectDashboard, serviceDashboard, createDashboardWithTags("service-2", "keptn_project:sockshop", "keptn_stage:*", "keptn_service:carts")},
+			expectError:         true,
+			partialErrorMessage: "2 dashboards match the tag criteria",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashboard, err := SearchForDashboardMatchingTags(tt.dashboards, project, stage, service)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.partialErrorMessage)
+				assert.Nil(t, dashboard)
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, dashboard) {
+					assert.EqualValues(t, tt.expectedDashboardID, dashboard.ID)
+				}
+			}
+		})
+	}
+}
+
+func createDashboardWithTags(dashboardID string, tags ...string) *Dashboard {
+	return &Dashboard{
+		ID: dashboardID,
+		DashboardMetadata: DashboardMetadata{
+			Tags: tags,
+		},
+	}
+}
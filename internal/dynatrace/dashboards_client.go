@@ -25,7 +25,18 @@ func NewDashboardsClient(client ClientInterface) *DashboardsClient {
 
 // GetAll gets a list of DashboardStubs detailling all accessible dashboards or returns an error.
 func (dc *DashboardsClient) GetAll(ctx context.Context) (*DashboardList, error) {
-	res, err := dc.client.Get(ctx, DashboardsPath)
+	return dc.getList(ctx, DashboardsPath)
+}
+
+// GetByTag gets a list of DashboardStubs detailling all accessible dashboards with the specified tag or returns an error.
+func (dc *DashboardsClient) GetByTag(ctx context.Context, tag string) (*DashboardList, error) {
+	queryParameters := newQueryParameters()
+	queryParameters.add("tags", tag)
+	return dc.getList(ctx, DashboardsPath+"?"+queryParameters.encode())
+}
+
+func (dc *DashboardsClient) getList(ctx context.Context, path string) (*DashboardList, error) {
+	res, err := dc.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -47,9 +58,39 @@ func (dc *DashboardsClient) GetByID(ctx context.Context, dashboardID string) (*D
 		return nil, err
 	}
 
-	// parse json
+	return unmarshalDashboard(body)
+}
+
+// GetByIDIfModified gets a dashboard by ID unless it was last modified at the specified time, in which case its tiles are not parsed and nil is returned instead.
+// A lastModified of 0 always returns the dashboard, as do dashboards without a last-modified time.
+func (dc *DashboardsClient) GetByIDIfModified(ctx context.Context, dashboardID string, lastModified int64) (*Dashboard, error) {
+	body, err := dc.client.Get(ctx, DashboardsPath+"/"+url.PathEscape(dashboardID))
+	if err != nil {
+		return nil, err
+	}
+
+	if lastModified != 0 {
+		header := struct {
+			DashboardMetadata struct {
+				LastModified int64 `json:"lastModified"`
+			} `json:"dashboardMetadata"`
+		}{}
+		err = json.Unmarshal(body, &header)
+		if err != nil {
+			return nil, common.NewUnmarshalJSONError("Dynatrace dashboard", err)
+		}
+
+		if header.DashboardMetadata.LastModified == lastModified {
+			return nil, nil
+		}
+	}
+
+	return unmarshalDashboard(body)
+}
+
+func unmarshalDashboard(body []byte) (*Dashboard, error) {
 	dynatraceDashboard := &Dashboard{}
-	err = json.Unmarshal(body, &dynatraceDashboard)
+	err := json.Unmarshal(body, &dynatraceDashboard)
 	if err != nil {
 		return nil, common.NewUnmarshalJSONError("Dynatrace dashboard", err)
 	}
@@ -0,0 +1,58 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+func TestDashboardsClient_GetByIDIfModified(t *testing.T) {
+	const dashboardID = "12345678-1111-4444-8888-123456789012"
+	const lastModified = 1664625600000
+
+	tests := []struct {
+		name            string
+		lastModified    int64
+		expectDashboard bool
+	}{
+		{
+			name:            "unknown last-modified time returns dashboard",
+			lastModified:    0,
+			expectDashboard: true,
+		},
+		{
+			name:            "changed last-modified time returns dashboard",
+			lastModified:    lastModified - 1000,
+			expectDashboard: true,
+		},
+		{
+			name:            "unchanged last-modified time returns nil",
+			lastModified:    lastModified,
+			expectDashboard: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(DashboardsPath+"/"+dashboardID, "./testdata/test_dashboardsclient_getbyid.json")
+
+			dtClient, _, teardown := createDynatraceClient(t, handler)
+			defer teardown()
+
+			dashboard, err := NewDashboardsClient(dtClient).GetByIDIfModified(context.TODO(), dashboardID, tt.lastModified)
+			assert.NoError(t, err)
+			if !tt.expectDashboard {
+				assert.Nil(t, dashboard)
+				return
+			}
+
+			if assert.NotNil(t, dashboard) {
+				assert.EqualValues(t, lastModified, dashboard.DashboardMetadata.LastModified)
+				assert.EqualValues(t, 1, len(dashboard.Tiles))
+			}
+		})
+	}
+}
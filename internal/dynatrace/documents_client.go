@@ -0,0 +1,40 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+)
+
+// DocumentsPath is the base endpoint for the Dynatrace platform Document API.
+const DocumentsPath = "/platform/document/v1/documents"
+
+// DocumentsClient is a client for interacting with the Dynatrace platform Document API.
+type DocumentsClient struct {
+	client ClientInterface
+}
+
+// NewDocumentsClient creates a new DocumentsClient.
+func NewDocumentsClient(client ClientInterface) *DocumentsClient {
+	return &DocumentsClient{
+		client: client,
+	}
+}
+
+// GetNotebookByID gets the content of the notebook document with the specified ID or returns an error.
+func (dc *DocumentsClient) GetNotebookByID(ctx context.Context, notebookID string) (*Notebook, error) {
+	body, err := dc.client.Get(ctx, DocumentsPath+"/"+url.PathEscape(notebookID)+"/content")
+	if err != nil {
+		return nil, err
+	}
+
+	notebook := &Notebook{}
+	err = json.Unmarshal(body, notebook)
+	if err != nil {
+		return nil, common.NewUnmarshalJSONError("Dynatrace notebook", err)
+	}
+
+	return notebook, nil
+}
@@ -0,0 +1,210 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dql"
+)
+
+// DQLExecutePath is the endpoint for submitting DQL queries to Grail.
+const DQLExecutePath = "/platform/storage/query/v1/query:execute"
+
+// DQLPollPath is the endpoint for polling the state of previously submitted DQL queries.
+const DQLPollPath = "/platform/storage/query/v1/query:poll"
+
+// DQLRequiredDelay is delay required between the end of a timeframe and an DQL API request using it.
+const DQLRequiredDelay = 2 * time.Minute
+
+// DQLMaximumWait is maximum acceptable wait time between the end of a timeframe and an DQL API request using it.
+const DQLMaximumWait = 4 * time.Minute
+
+// dqlPollRequestTimeout is the time the Grail API may hold a poll request open before returning the current query state.
+const dqlPollRequestTimeout = 10 * time.Second
+
+// dqlTimestampFormat is the ISO 8601 format used for the default timeframe of DQL queries.
+const dqlTimestampFormat = "2006-01-02T15:04:05.000Z"
+
+const (
+	defaultTimeframeStartKey = "defaultTimeframeStart"
+	defaultTimeframeEndKey   = "defaultTimeframeEnd"
+	requestTokenKey          = "request-token"
+	requestTimeoutMillisKey  = "request-timeout-milliseconds"
+)
+
+const (
+	dqlQueryStateNotStarted = "NOT_STARTED"
+	dqlQueryStateRunning    = "RUNNING"
+	dqlQueryStateSucceeded  = "SUCCEEDED"
+)
+
+// DQLClientQueryRequest encapsulates the request for the DQLClient's GetByQuery method.
+type DQLClientQueryRequest struct {
+	query     dql.Query
+	timeframe common.Timeframe
+}
+
+// NewDQLClientQueryRequest creates new DQLClientQueryRequest.
+func NewDQLClientQueryRequest(query dql.Query, timeframe common.Timeframe) DQLClientQueryRequest {
+	return DQLClientQueryRequest{
+		query:     query,
+		timeframe: timeframe,
+	}
+}
+
+// RequestString encodes DQLClientQueryRequest into a request string.
+// As DQL queries are submitted using a POST request, the string only documents the request and its parameters.
+func (q *DQLClientQueryRequest) RequestString() string {
+	queryParameters := newQueryParameters()
+	queryParameters.add(queryKey, q.query.GetQuery())
+	queryParameters.add(defaultTimeframeStartKey, formatDQLTimestamp(q.timeframe.Start()))
+	queryParameters.add(defaultTimeframeEndKey, formatDQLTimestamp(q.timeframe.End()))
+
+	return DQLExecutePath + "?" + queryParameters.encode()
+}
+
+func (q *DQLClientQueryRequest) body() ([]byte, error) {
+	return json.Marshal(dqlExecuteRequest{
+		Query:                 q.query.GetQuery(),
+		DefaultTimeframeStart: formatDQLTimestamp(q.timeframe.Start()),
+		DefaultTimeframeEnd:   formatDQLTimestamp(q.timeframe.End()),
+	})
+}
+
+func formatDQLTimestamp(t time.Time) string {
+	return t.UTC().Format(dqlTimestampFormat)
+}
+
+type dqlExecuteRequest struct {
+	Query                 string `json:"query"`
+	DefaultTimeframeStart string `json:"defaultTimeframeStart"`
+	DefaultTimeframeEnd   string `json:"defaultTimeframeEnd"`
+}
+
+// dqlQueryState is returned by both the execute and poll endpoints.
+type dqlQueryState struct {
+	State        string     `json:"state"`
+	RequestToken string     `json:"requestToken"`
+	Result       *DQLResult `json:"result"`
+}
+
+// DQLResult is the result of a DQL query.
+type DQLResult struct {
+	Records []DQLRecord `json:"records"`
+}
+
+// DQLRecord is a single record of a DQL result, i.e. a map of field names to values.
+type DQLRecord map[string]interface{}
+
+// GetNumericFieldValue returns the value of the specified field as a float64.
+// Grail returns long values as strings to avoid loss of precision, so these are parsed as well.
+func (r DQLRecord) GetNumericFieldValue(field string) (float64, error) {
+	fieldValue, ok := r[field]
+	if !ok {
+		return 0, fmt.Errorf("could not find field '%s' in DQL record", field)
+	}
+
+	switch v := fieldValue.(type) {
+	case float64:
+		return v, nil
+	case string:
+		value, err := strconv.ParseFloat(v, 64)
+		if err == nil {
+			return value, nil
+		}
+	}
+
+	return 0, errors.New("DQL record field value should be a number")
+}
+
+// DQLClient is a client for executing DQL queries using the Dynatrace Grail query endpoints.
+type DQLClient struct {
+	client ClientInterface
+}
+
+// NewDQLClient creates a new DQLClient
+func NewDQLClient(client ClientInterface) *DQLClient {
+	return &DQLClient{
+		client: client,
+	}
+}
+
+// GetByQuery submits the DQL query, polls until the query has finished and returns the result.
+func (dc *DQLClient) GetByQuery(ctx context.Context, request DQLClientQueryRequest) (*DQLResult, error) {
+	err := NewTimeframeDelay(request.timeframe, DQLRequiredDelay, DQLMaximumWait).Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := request.body()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := dc.client.Post(ctx, DQLExecutePath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := unmarshalDQLQueryState(response)
+	if err != nil {
+		return nil, err
+	}
+
+	requestToken := state.RequestToken
+	for {
+		switch state.State {
+		case dqlQueryStateSucceeded:
+			if state.Result == nil {
+				return nil, errors.New("DQL query succeeded but returned no result")
+			}
+			return state.Result, nil
+
+		case dqlQueryStateNotStarted, dqlQueryStateRunning:
+			if requestToken == "" {
+				return nil, errors.New("DQL query is still running but no request token was returned")
+			}
+
+			if ctx.Err() != nil {
+				return nil, errors.New("polling DQL query interrupted")
+			}
+
+			state, err = dc.poll(ctx, requestToken)
+			if err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("DQL query finished with state %s", state.State)
+		}
+	}
+}
+
+// poll gets the current state of the DQL query with the specified request token.
+func (dc *DQLClient) poll(ctx context.Context, requestToken string) (*dqlQueryState, error) {
+	queryParameters := newQueryParameters()
+	queryParameters.add(requestTokenKey, requestToken)
+	queryParameters.add(requestTimeoutMillisKey, strconv.FormatInt(dqlPollRequestTimeout.Milliseconds(), 10))
+
+	response, err := dc.client.Get(ctx, DQLPollPath+"?"+queryParameters.encode())
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalDQLQueryState(response)
+}
+
+func unmarshalDQLQueryState(body []byte) (*dqlQueryState, error) {
+	var state dqlQueryState
+	err := json.Unmarshal(body, &state)
+	if err != nil {
+		return nil, common.NewUnmarshalJSONError("DQL query state", err)
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,35 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dql"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDQLClient_GetByQueryPollsUntilQuerySucceeded(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(DQLExecutePath, "./testdata/test_dqlclient_execute_running.json")
+	handler.AddExact(DQLPollPath+"?request-timeout-milliseconds=10000&request-token=rZRQ6rT8QuqDfz8Q9ZJn4A%3D%3D", "./testdata/test_dqlclient_poll_succeeded.json")
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframeParser("2019-10-21T09:11:24Z", "2019-10-21T09:11:25Z").Parse()
+	assert.NoError(t, err)
+
+	query, err := dql.NewQuery("fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()")
+	assert.NoError(t, err)
+
+	request := NewDQLClientQueryRequest(*query, *timeframe)
+	result, err := NewDQLClient(dtClient).GetByQuery(context.TODO(), request)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, result) && assert.EqualValues(t, 1, len(result.Records)) {
+		assert.EqualValues(t, "42", result.Records[0]["error_count"])
+	}
+	assert.EqualValues(t, DQLExecutePath+"?defaultTimeframeEnd=2019-10-21T09%3A11%3A25.000Z&defaultTimeframeStart=2019-10-21T09%3A11%3A24.000Z&query=fetch+logs+%7C+filter+loglevel+%3D%3D+%22ERROR%22+%7C+summarize+error_count+%3D+count%28%29", request.RequestString())
+}
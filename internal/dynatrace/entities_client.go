@@ -8,9 +8,53 @@ import (
 	"time"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/entities"
 )
 
-const entitiesPath = "/api/v2/entities"
+// EntitiesPath is the base endpoint for Monitored entities API v2
+const EntitiesPath = "/api/v2/entities"
+
+// entitiesTotalCountPageSize is the page size used when only the total count of entities is required, as this is included in the first page.
+const entitiesTotalCountPageSize = 1
+
+// entitiesListPageSize is the page size used when all entities matching an entity selector are required.
+const entitiesListPageSize = 500
+
+const (
+	pageSizeKey    = "pageSize"
+	nextPageKeyKey = "nextPageKey"
+)
+
+// EntitiesClientQueryRequest encapsulates the request for the EntitiesClient's GetTotalCountByQuery method.
+type EntitiesClientQueryRequest struct {
+	query     entities.Query
+	timeframe common.Timeframe
+}
+
+// NewEntitiesClientQueryRequest creates new EntitiesClientQueryRequest.
+func NewEntitiesClientQueryRequest(query entities.Query, timeframe common.Timeframe) EntitiesClientQueryRequest {
+	return EntitiesClientQueryRequest{
+		query:     query,
+		timeframe: timeframe,
+	}
+}
+
+// RequestString encodes EntitiesClientQueryRequest into a request string.
+// If the query does not specify the start of the timeframe, the start of the request's timeframe is used.
+func (q *EntitiesClientQueryRequest) RequestString() string {
+	from := q.query.GetFrom()
+	if from == "" {
+		from = common.TimestampToUnixMillisecondsString(q.timeframe.Start())
+	}
+
+	queryParameters := newQueryParameters()
+	queryParameters.add(entitySelectorKey, q.query.GetEntitySelector())
+	queryParameters.add(fromKey, from)
+	queryParameters.add(toKey, common.TimestampToUnixMillisecondsString(q.timeframe.End()))
+	queryParameters.add(pageSizeKey, strconv.Itoa(entitiesTotalCountPageSize))
+
+	return EntitiesPath + "?" + queryParameters.encode()
+}
 
 // EntitiesResponse represents the response from Dynatrace entities endpoints
 type EntitiesResponse struct {
@@ -47,6 +91,58 @@ func NewEntitiesClient(client ClientInterface) *EntitiesClient {
 	}
 }
 
+// GetTotalCountByQuery calls the Dynatrace API to retrieve the total count of entities matching the query.
+// Only the first page is requested, as it already includes the total count.
+func (ec *EntitiesClient) GetTotalCountByQuery(ctx context.Context, request EntitiesClientQueryRequest) (int, error) {
+	body, err := ec.Client.Get(ctx, request.RequestString())
+	if err != nil {
+		return 0, err
+	}
+
+	var response EntitiesResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return 0, common.NewUnmarshalJSONError("monitored entities", err)
+	}
+
+	return response.TotalCount, nil
+}
+
+// GetIDsBySelector returns the IDs of all entities matching the specified entity selector in the timeframe, following the pages of the response.
+func (ec *EntitiesClient) GetIDsBySelector(ctx context.Context, entitySelector string, timeframe common.Timeframe) ([]string, error) {
+	queryParameters := newQueryParameters()
+	queryParameters.add(entitySelectorKey, entitySelector)
+	queryParameters.add(fromKey, common.TimestampToUnixMillisecondsString(timeframe.Start()))
+	queryParameters.add(toKey, common.TimestampToUnixMillisecondsString(timeframe.End()))
+	queryParameters.add(pageSizeKey, strconv.Itoa(entitiesListPageSize))
+
+	var entityIDs []string
+	for {
+		body, err := ec.Client.Get(ctx, EntitiesPath+"?"+queryParameters.encode())
+		if err != nil {
+			return nil, err
+		}
+
+		var response EntitiesResponse
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			return nil, common.NewUnmarshalJSONError("monitored entities", err)
+		}
+
+		for _, entity := range response.Entities {
+			entityIDs = append(entityIDs, entity.EntityID)
+		}
+
+		if response.NextPageKey == "" {
+			return entityIDs, nil
+		}
+
+		// subsequent pages must only be requested using the next page key
+		queryParameters = newQueryParameters()
+		queryParameters.add(nextPageKeyKey, response.NextPageKey)
+	}
+}
+
 // GetKeptnManagedServices gets all service entities with a keptn_managed and keptn_service tag.
 func (ec *EntitiesClient) GetKeptnManagedServices(ctx context.Context) ([]Entity, error) {
 	entities := []Entity{}
@@ -59,9 +155,9 @@ func (ec *EntitiesClient) GetKeptnManagedServices(ctx context.Context) ([]Entity
 		var err error
 
 		if nextPageKey == "" {
-			response, err = ec.Client.Get(ctx, entitiesPath+"?"+buildKeptnManagedServicesQueryParams(pageSize))
+			response, err = ec.Client.Get(ctx, EntitiesPath+"?"+buildKeptnManagedServicesQueryParams(pageSize))
 		} else {
-			response, err = ec.Client.Get(ctx, entitiesPath+"?nextPageKey="+nextPageKey)
+			response, err = ec.Client.Get(ctx, EntitiesPath+"?nextPageKey="+nextPageKey)
 		}
 		if err != nil {
 			return nil, err
@@ -110,7 +206,7 @@ func (ec *EntitiesClient) GetAllPGIsForKeptnServices(ctx context.Context, cfg PG
 	query.add("from", common.TimestampToUnixMillisecondsString(cfg.From))
 	query.add("to", common.TimestampToUnixMillisecondsString(cfg.To))
 
-	response, err := ec.Client.Get(ctx, entitiesPath+"?"+query.encode())
+	response, err := ec.Client.Get(ctx, EntitiesPath+"?"+query.encode())
 	if err != nil {
 		return nil, err
 	}
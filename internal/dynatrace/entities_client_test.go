@@ -12,6 +12,8 @@ import (
 	"github.com/go-test/deep"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/entities"
 	"github.com/keptn-contrib/dynatrace-service/internal/test"
 )
 
@@ -179,6 +181,40 @@ func TestEntitiesClient_GetAllPGIsForKeptnServices(t *testing.T) {
 	}
 }
 
+// TestEntitiesClient_GetTotalCountByQuery tests that the total count is read from the first page without following the next page key.
+func TestEntitiesClient_GetTotalCountByQuery(t *testing.T) {
+	const testdataFolder = "./testdata/entities_client/"
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(EntitiesPath+"?entitySelector=type%28HOST%29%2Ctag%28%22keptn_stage%3Astaging%22%29&from=1654000200000&pageSize=1&to=1654000320000", filepath.Join(testdataFolder, "entities_first_page.json"))
+
+	client, teardown := createEventsClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframe(time.Date(2022, 5, 31, 12, 30, 0, 0, time.UTC), time.Date(2022, 5, 31, 12, 32, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	query, err := entities.NewQuery("type(HOST),tag(\"keptn_stage:staging\")", "")
+	assert.NoError(t, err)
+
+	count, err := client.GetTotalCountByQuery(context.Background(), NewEntitiesClientQueryRequest(*query, *timeframe))
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 3, count)
+	}
+}
+
+// TestEntitiesClientQueryRequest_RequestString tests that the start of the query overrides the start of the timeframe.
+func TestEntitiesClientQueryRequest_RequestString(t *testing.T) {
+	timeframe, err := common.NewTimeframe(time.Date(2022, 5, 31, 12, 30, 0, 0, time.UTC), time.Date(2022, 5, 31, 12, 32, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	query, err := entities.NewQuery("type(HOST)", "now-2h")
+	assert.NoError(t, err)
+
+	request := NewEntitiesClientQueryRequest(*query, *timeframe)
+	assert.EqualValues(t, EntitiesPath+"?entitySelector=type%28HOST%29&from=now-2h&pageSize=1&to=1654000320000", request.RequestString())
+}
+
 func createEventsClient(t *testing.T, handler http.Handler) (*EntitiesClient, func()) {
 	dynatraceClient, _, teardown := createDynatraceClient(t, handler)
 
@@ -186,3 +222,22 @@ func createEventsClient(t *testing.T, handler http.Handler) (*EntitiesClient, fu
 
 	return ec, teardown
 }
+
+func TestEntitiesClient_GetIDsBySelectorFollowsNextPageKey(t *testing.T) {
+	const testdataFolder = "./testdata/entities_client/"
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(EntitiesPath+"?entitySelector=type%28%22SYNTHETIC_TEST%22%29&from=1654000200000&pageSize=500&to=1654000320000", filepath.Join(testdataFolder, "synthetic_monitors_page1.json"))
+	handler.AddExact(EntitiesPath+"?nextPageKey=AQAAABQBAAAABQ%3D%3D", filepath.Join(testdataFolder, "synthetic_monitors_page2.json"))
+
+	client, teardown := createEventsClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframe(time.Date(2022, 5, 31, 12, 30, 0, 0, time.UTC), time.Date(2022, 5, 31, 12, 32, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	entityIDs, err := client.GetIDsBySelector(context.Background(), "type(\"SYNTHETIC_TEST\")", *timeframe)
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, []string{"SYNTHETIC_TEST-7A3B9E0F1C2D4E5F", "SYNTHETIC_TEST-0123456789ABCDEF"}, entityIDs)
+	}
+}
@@ -0,0 +1,69 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/jsonapi"
+)
+
+// JSONAPIClientGetRequest encapsulates the request for the JSONAPIClient's Get method.
+type JSONAPIClientGetRequest struct {
+	query     jsonapi.Query
+	timeframe common.Timeframe
+}
+
+// NewJSONAPIClientGetRequest creates new JSONAPIClientGetRequest.
+func NewJSONAPIClientGetRequest(query jsonapi.Query, timeframe common.Timeframe) JSONAPIClientGetRequest {
+	return JSONAPIClientGetRequest{
+		query:     query,
+		timeframe: timeframe,
+	}
+}
+
+// RequestString encodes JSONAPIClientGetRequest into a request string.
+// The start and end of the timeframe are added as from and to parameters unless the path of the query already includes them.
+func (q *JSONAPIClientGetRequest) RequestString() string {
+	queryParameters := newQueryParameters()
+	for _, parameter := range q.query.GetPathParameters() {
+		queryParameters.add(parameter.Key, parameter.Value)
+	}
+
+	if !queryParameters.has(fromKey) {
+		queryParameters.add(fromKey, common.TimestampToUnixMillisecondsString(q.timeframe.Start()))
+	}
+	if !queryParameters.has(toKey) {
+		queryParameters.add(toKey, common.TimestampToUnixMillisecondsString(q.timeframe.End()))
+	}
+
+	return q.query.GetBasePath() + "?" + queryParameters.encode()
+}
+
+// JSONAPIClient is a client for retrieving arbitrary JSON documents from Dynatrace API endpoints.
+type JSONAPIClient struct {
+	client ClientInterface
+}
+
+// NewJSONAPIClient creates a new JSONAPIClient
+func NewJSONAPIClient(client ClientInterface) *JSONAPIClient {
+	return &JSONAPIClient{
+		client: client,
+	}
+}
+
+// Get calls the Dynatrace API to retrieve the JSON document at the path of the query and returns it decoded into interface{} values.
+func (c *JSONAPIClient) Get(ctx context.Context, request JSONAPIClientGetRequest) (interface{}, error) {
+	body, err := c.client.Get(ctx, request.RequestString())
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	err = json.Unmarshal(body, &document)
+	if err != nil {
+		return nil, common.NewUnmarshalJSONError("JSON API response", err)
+	}
+
+	return document, nil
+}
@@ -0,0 +1,76 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/jsonapi"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestJSONAPIClientGetRequest_RequestString tests that parameters in the path are encoded and the timeframe is added.
+func TestJSONAPIClientGetRequest_RequestString(t *testing.T) {
+	timeframe, err := common.NewTimeframeParser("2019-10-21T09:11:24Z", "2019-10-21T09:11:25Z").Parse()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name                  string
+		path                  string
+		expectedRequestString string
+	}{
+		{
+			name:                  "path without parameters",
+			path:                  "/api/v2/releases",
+			expectedRequestString: "/api/v2/releases?from=1571649084000&to=1571649085000",
+		},
+		{
+			name:                  "path with parameter",
+			path:                  "/api/v2/releases?releasesSelector=stage(\"staging\")",
+			expectedRequestString: "/api/v2/releases?from=1571649084000&releasesSelector=stage%28%22staging%22%29&to=1571649085000",
+		},
+		{
+			name:                  "path with multiple parameters",
+			path:                  "/api/v2/entities?entitySelector=type(HOST);fields=+tags",
+			expectedRequestString: "/api/v2/entities?entitySelector=type%28HOST%29&fields=%2Btags&from=1571649084000&to=1571649085000",
+		},
+		{
+			name:                  "path with encoded parameter",
+			path:                  "/api/v2/entities?entitySelector=type(HOST)%2Ctag(%22a%3Bb%22)",
+			expectedRequestString: "/api/v2/entities?entitySelector=type%28HOST%29%2Ctag%28%22a%3Bb%22%29&from=1571649084000&to=1571649085000",
+		},
+		{
+			name:                  "path with from parameter",
+			path:                  "/api/v2/entities?from=now-2h",
+			expectedRequestString: "/api/v2/entities?from=now-2h&to=1571649085000",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := jsonapi.NewQuery(tc.path, "$.totalCount")
+			assert.NoError(t, err)
+
+			request := NewJSONAPIClientGetRequest(*query, *timeframe)
+			assert.EqualValues(t, tc.expectedRequestString, request.RequestString())
+		})
+	}
+}
+
+func TestJSONAPIClient_Get(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/v2/releases?from=1571649084000&to=1571649085000", "./testdata/test_jsonapiclient_get.json")
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframeParser("2019-10-21T09:11:24Z", "2019-10-21T09:11:25Z").Parse()
+	assert.NoError(t, err)
+
+	query, err := jsonapi.NewQuery("/api/v2/releases", "$.totalCount")
+	assert.NoError(t, err)
+
+	document, err := NewJSONAPIClient(dtClient).Get(context.TODO(), NewJSONAPIClientGetRequest(*query, *timeframe))
+	assert.NoError(t, err)
+	assert.EqualValues(t, []interface{}{2.0}, query.GetSelector().Select(document))
+}
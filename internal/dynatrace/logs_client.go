@@ -0,0 +1,126 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/logs"
+)
+
+// LogsSearchPath is the search endpoint for Logs API v2
+const LogsSearchPath = "/api/v2/logs/search"
+
+// LogsRequiredDelay is delay required between the end of a timeframe and an Logs v2 API request using it.
+const LogsRequiredDelay = 2 * time.Minute
+
+// LogsMaximumWait is maximum acceptable wait time between the end of a timeframe and an Logs v2 API request using it.
+const LogsMaximumWait = 4 * time.Minute
+
+// logsSearchLimit is the maximum number of log records the Logs API v2 returns for a search.
+const logsSearchLimit = 10000
+
+const (
+	limitKey        = "limit"
+	nextSliceKeyKey = "nextSliceKey"
+)
+
+// LogsClientQueryRequest encapsulates the request for the LogsClient's GetCountByQuery method.
+type LogsClientQueryRequest struct {
+	query     logs.Query
+	timeframe common.Timeframe
+}
+
+// NewLogsClientQueryRequest creates new LogsClientQueryRequest.
+func NewLogsClientQueryRequest(query logs.Query, timeframe common.Timeframe) LogsClientQueryRequest {
+	return LogsClientQueryRequest{
+		query:     query,
+		timeframe: timeframe,
+	}
+}
+
+// RequestString encodes LogsClientQueryRequest into a request string.
+func (q *LogsClientQueryRequest) RequestString() string {
+	queryParameters := newQueryParameters()
+	queryParameters.add(queryKey, q.query.GetQuery())
+	queryParameters.add(fromKey, common.TimestampToUnixMillisecondsString(q.timeframe.Start()))
+	queryParameters.add(toKey, common.TimestampToUnixMillisecondsString(q.timeframe.End()))
+	queryParameters.add(limitKey, strconv.Itoa(logsSearchLimit))
+
+	return LogsSearchPath + "?" + queryParameters.encode()
+}
+
+// logsSearchResult is a single slice of the result of a query to /api/v2/logs/search.
+// Here the individual records are not considered, as only their number is used.
+type logsSearchResult struct {
+	Results      []json.RawMessage `json:"results"`
+	NextSliceKey string            `json:"nextSliceKey"`
+}
+
+// LogsSearchLimitReachedError indicates that a search matched at least as many log records as the Logs API v2 returns, so that their count may be incomplete.
+type LogsSearchLimitReachedError struct {
+	Limit int
+}
+
+// Error returns a string representation of this error.
+func (e *LogsSearchLimitReachedError) Error() string {
+	return fmt.Sprintf("Logs API v2 returned the maximum of %d log records, so the count may be incomplete", e.Limit)
+}
+
+// LogsClient is a client for interacting with the Dynatrace logs endpoints.
+type LogsClient struct {
+	client ClientInterface
+}
+
+// NewLogsClient creates a new LogsClient
+func NewLogsClient(client ClientInterface) *LogsClient {
+	return &LogsClient{
+		client: client,
+	}
+}
+
+// GetCountByQuery calls the Dynatrace API to retrieve all slices of log records matching the query in the timeframe and returns their count.
+// As the Logs API v2 returns at most logsSearchLimit log records for a search, a LogsSearchLimitReachedError is returned if this limit is reached rather than a count that may be incomplete.
+func (lc *LogsClient) GetCountByQuery(ctx context.Context, request LogsClientQueryRequest) (int, error) {
+	err := NewTimeframeDelay(request.timeframe, LogsRequiredDelay, LogsMaximumWait).Wait(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	nextSliceKey := ""
+	for {
+		var body []byte
+		if nextSliceKey == "" {
+			body, err = lc.client.Get(ctx, request.RequestString())
+		} else {
+			queryParameters := newQueryParameters()
+			queryParameters.add(nextSliceKeyKey, nextSliceKey)
+			body, err = lc.client.Get(ctx, LogsSearchPath+"?"+queryParameters.encode())
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		var result logsSearchResult
+		err = json.Unmarshal(body, &result)
+		if err != nil {
+			return 0, common.NewUnmarshalJSONError("log search result", err)
+		}
+
+		count += len(result.Results)
+		if result.NextSliceKey == "" {
+			break
+		}
+		nextSliceKey = result.NextSliceKey
+	}
+
+	if count >= logsSearchLimit {
+		return 0, &LogsSearchLimitReachedError{Limit: logsSearchLimit}
+	}
+
+	return count, nil
+}
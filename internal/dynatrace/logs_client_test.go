@@ -0,0 +1,58 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/logs"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogsClient_GetCountByQueryFollowsNextSliceKey(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(LogsSearchPath+"?from=1571649084000&limit=10000&query=loglevel%3D%22ERROR%22&to=1571649085000", "./testdata/test_logsclient_getcountbyquery_slice1.json")
+	handler.AddExact(LogsSearchPath+"?nextSliceKey=___beZuA_____AAAAAQ", "./testdata/test_logsclient_getcountbyquery_slice2.json")
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframeParser("2019-10-21T09:11:24Z", "2019-10-21T09:11:25Z").Parse()
+	assert.NoError(t, err)
+
+	logsQuery, err := logs.NewQuery("loglevel=\"ERROR\"")
+	assert.NoError(t, err)
+
+	count, err := NewLogsClient(dtClient).GetCountByQuery(context.TODO(), NewLogsClientQueryRequest(*logsQuery, *timeframe))
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+}
+
+func TestLogsClient_GetCountByQueryFailsIfSearchLimitIsReached(t *testing.T) {
+	records := make([]json.RawMessage, logsSearchLimit)
+	for i := range records {
+		records[i] = json.RawMessage(`{"content":"error"}`)
+	}
+	body, err := json.Marshal(logsSearchResult{Results: records})
+	assert.NoError(t, err)
+
+	handler := test.NewPayloadBasedURLHandler(t)
+	handler.AddExact(LogsSearchPath+"?from=1571649084000&limit=10000&query=loglevel%3D%22ERROR%22&to=1571649085000", body)
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframeParser("2019-10-21T09:11:24Z", "2019-10-21T09:11:25Z").Parse()
+	assert.NoError(t, err)
+
+	logsQuery, err := logs.NewQuery("loglevel=\"ERROR\"")
+	assert.NoError(t, err)
+
+	_, err = NewLogsClient(dtClient).GetCountByQuery(context.TODO(), NewLogsClientQueryRequest(*logsQuery, *timeframe))
+
+	var limitReachedError *LogsSearchLimitReachedError
+	assert.ErrorAs(t, err, &limitReachedError)
+}
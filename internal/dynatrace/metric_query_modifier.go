@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 )
 
 const (
@@ -18,6 +19,9 @@ const (
 	millionUnitID  = "Million"
 	billionUnitID  = "Billion"
 	trillionUnitID = "Trillion"
+
+	resolutionInfRetry = "resolution=Inf"
+	foldRetry          = "fold"
 )
 
 // unitlessConversions lists all support conversions from Count or Unspecified to target via the map's key.
@@ -53,6 +57,7 @@ type metricsQueryModifier struct {
 	unitsConversionMetricSelectorSuffix string
 	setResolutionToInf                  bool
 	metricDefinition                    *MetricDefinition
+	targetUnitID                        string
 }
 
 func newMetricsQueryModifier(metricsClient MetricsClientInterface, query metrics.Query) *metricsQueryModifier {
@@ -73,6 +78,19 @@ func (u *metricsQueryModifier) applyUnitConversion(ctx context.Context, targetUn
 		return nil, err
 	}
 
+	// units of the catalog may also be specified by symbol or in lowercase, but the Metrics API v2 requires their ID
+	targetUnit, isKnownTargetUnit := unit.Lookup(targetUnitID)
+	if isKnownTargetUnit {
+		targetUnitID = targetUnit.ID()
+	}
+
+	// the Metrics API v2 silently ignores incompatible conversions, so these are rejected instead
+	if sourceUnit, isKnownSourceUnit := unit.Lookup(metricDefinition.Unit); isKnownSourceUnit && isKnownTargetUnit && !sourceUnit.IsConvertibleTo(targetUnit) {
+		return nil, unit.NewIncompatibleUnitsError(sourceUnit.ID(), targetUnit.ID())
+	}
+
+	u.targetUnitID = targetUnitID
+
 	if metricDefinition.Unit == targetUnitID {
 		return u.getModifiedQuery()
 	}
@@ -86,6 +104,20 @@ func (u *metricsQueryModifier) applyUnitConversion(ctx context.Context, targetUn
 	return u.getModifiedQuery()
 }
 
+// getResultUnitID gets the unit of the values returned by the modified query, i.e. the target unit if a unit conversion was applied or otherwise the unit of the metric if its definition was retrieved.
+// It returns an empty string if the unit is not known.
+func (u *metricsQueryModifier) getResultUnitID() string {
+	if u.targetUnitID != "" {
+		return u.targetUnitID
+	}
+
+	if u.metricDefinition != nil {
+		return u.metricDefinition.Unit
+	}
+
+	return ""
+}
+
 // doesTargetUnitRequireConversion checks if the target unit ID requires conversion or not. Currently, "Auto" (default empty value and explicit `auto` value) and "None" require no conversion.
 func doesTargetUnitRequireConversion(targetUnitID string) bool {
 	switch targetUnitID {
@@ -116,6 +148,19 @@ func (u *metricsQueryModifier) applyFoldOrResolutionInf(ctx context.Context) (*m
 	return u.getModifiedQuery()
 }
 
+// getRetries gets the modifications applied by applyFoldOrResolutionInf.
+func (u *metricsQueryModifier) getRetries() []string {
+	var retries []string
+	if u.setResolutionToInf {
+		retries = append(retries, resolutionInfRetry)
+	}
+
+	if u.includeFold {
+		retries = append(retries, foldRetry)
+	}
+	return retries
+}
+
 // getModifiedQuery gets the modified query with any resolution change, or fold or units conversion.
 func (u *metricsQueryModifier) getModifiedQuery() (*metrics.Query, error) {
 	return metrics.NewQuery(u.getModifiedMetricSelector(), u.query.GetEntitySelector(), u.getModifiedResolution(), u.query.GetMZSelector())
@@ -45,6 +45,16 @@ func NewMetricsClientQueryRequest(query metrics.Query, timeframe common.Timefram
 	}
 }
 
+// EntitySelector gets the entity selector of the MetricsClientQueryRequest or an empty string if none is set.
+func (q *MetricsClientQueryRequest) EntitySelector() string {
+	return q.query.GetEntitySelector()
+}
+
+// Timeframe gets the timeframe of the MetricsClientQueryRequest.
+func (q *MetricsClientQueryRequest) Timeframe() common.Timeframe {
+	return q.timeframe
+}
+
 // RequestString encodes MetricsClientQueryRequest into a request string.
 func (q *MetricsClientQueryRequest) RequestString() string {
 	queryParameters := newQueryParameters()
@@ -0,0 +1,70 @@
+package dynatrace
+
+import (
+	"errors"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+// NewMetricsDiagnostics creates Diagnostics for the specified metrics processing results.
+func NewMetricsDiagnostics(results *MetricsProcessingResults) result.Diagnostics {
+	request := results.Request()
+	timeframe := request.Timeframe()
+	return result.Diagnostics{
+		Warnings:       results.Warnings(),
+		EntitySelector: request.EntitySelector(),
+		Timeframe:      &timeframe,
+		Retries:        results.Retries(),
+	}
+}
+
+// NewMetricsDiagnosticsFromError creates Diagnostics for a metrics request that failed with the specified error.
+func NewMetricsDiagnosticsFromError(request MetricsClientQueryRequest, err error) result.Diagnostics {
+	diagnostics := NewDiagnosticsFromMetricsError(err)
+	timeframe := request.Timeframe()
+	diagnostics.EntitySelector = request.EntitySelector()
+	diagnostics.Timeframe = &timeframe
+	return diagnostics
+}
+
+// NewDiagnosticsFromMetricsError creates Diagnostics classifying the specified error returned while retrieving or processing metrics.
+func NewDiagnosticsFromMetricsError(err error) result.Diagnostics {
+	return result.Diagnostics{
+		ErrorCode: GetErrorCodeFromMetricsError(err),
+		Warnings:  GetMetricsQueryWarnings(err),
+		Retries:   GetMetricsQueryRetries(err),
+	}
+}
+
+// GetErrorCodeFromMetricsError returns the error code classifying the specified error returned while retrieving or processing metrics.
+// Errors that are not specific to metrics, e.g. API or network errors, are classified as failed queries.
+func GetErrorCodeFromMetricsError(err error) result.ErrorCode {
+	var zeroMetricSeriesError *MetricsQueryReturnedZeroMetricSeriesError
+	var zeroValuesError *MetricsQueryReturnedZeroValuesError
+	var nullValueError *MetricsQueryReturnedNullValueError
+	if errors.As(err, &zeroMetricSeriesError) || errors.As(err, &zeroValuesError) || errors.As(err, &nullValueError) {
+		return result.ErrorCodeNoData
+	}
+
+	var multipleMetricSeriesError *MetricsQueryReturnedMultipleMetricSeriesError
+	var multipleValuesError *MetricsQueryReturnedMultipleValuesError
+	var unableToApplyFoldError *UnableToApplyFoldToValueDefaultAggregationError
+	if errors.As(err, &multipleMetricSeriesError) || errors.As(err, &multipleValuesError) || errors.As(err, &unableToApplyFoldError) {
+		return result.ErrorCodeMultipleResults
+	}
+
+	var unknownUnitlessConversionError *UnknownUnitlessConversionError
+	var unknownUnitError *unit.UnknownUnitError
+	var incompatibleUnitsError *unit.IncompatibleUnitsError
+	if errors.As(err, &unknownUnitlessConversionError) || errors.As(err, &unknownUnitError) || errors.As(err, &incompatibleUnitsError) {
+		return result.ErrorCodeUnitConversion
+	}
+
+	var wrongNumberOfCollectionsError *MetricsQueryReturnedWrongNumberOfMetricSeriesCollectionsError
+	if errors.As(err, &wrongNumberOfCollectionsError) {
+		return result.ErrorCodeUnexpectedResult
+	}
+
+	return result.ErrorCodeQueryFailed
+}
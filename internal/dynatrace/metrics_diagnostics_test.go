@@ -0,0 +1,80 @@
+package dynatrace
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+// TestNewDiagnosticsFromMetricsError tests that errors returned while retrieving or processing metrics are classified and any warnings are extracted.
+func TestNewDiagnosticsFromMetricsError(t *testing.T) {
+	warnings := []string{"The dimension key `dt.entity.services` has been referenced, but the metric has no such key."}
+
+	tests := []struct {
+		name              string
+		err               error
+		expectedErrorCode result.ErrorCode
+		expectedWarnings  []string
+	}{
+		{
+			name:              "zero metric series",
+			err:               &MetricsQueryReturnedZeroMetricSeriesError{Warnings: warnings},
+			expectedErrorCode: result.ErrorCodeNoData,
+			expectedWarnings:  warnings,
+		},
+		{
+			name:              "wrapped zero values",
+			err:               fmt.Errorf("could not process: %w", &MetricsQueryReturnedZeroValuesError{Warnings: warnings}),
+			expectedErrorCode: result.ErrorCodeNoData,
+			expectedWarnings:  warnings,
+		},
+		{
+			name:              "null value",
+			err:               &MetricsQueryReturnedNullValueError{},
+			expectedErrorCode: result.ErrorCodeNoData,
+		},
+		{
+			name:              "multiple metric series",
+			err:               &MetricsQueryReturnedMultipleMetricSeriesError{SeriesCount: 3, Warnings: warnings},
+			expectedErrorCode: result.ErrorCodeMultipleResults,
+			expectedWarnings:  warnings,
+		},
+		{
+			name:              "multiple values",
+			err:               &MetricsQueryReturnedMultipleValuesError{ValueCount: 2},
+			expectedErrorCode: result.ErrorCodeMultipleResults,
+		},
+		{
+			name:              "fold cannot be applied",
+			err:               &UnableToApplyFoldToValueDefaultAggregationError{},
+			expectedErrorCode: result.ErrorCodeMultipleResults,
+		},
+		{
+			name:              "incompatible units",
+			err:               unit.NewIncompatibleUnitsError("MilliSecond", "Byte"),
+			expectedErrorCode: result.ErrorCodeUnitConversion,
+		},
+		{
+			name:              "API error",
+			err:               &APIError{code: 500, message: "internal server error"},
+			expectedErrorCode: result.ErrorCodeQueryFailed,
+		},
+		{
+			name:              "other error",
+			err:               errors.New("connection refused"),
+			expectedErrorCode: result.ErrorCodeQueryFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := NewDiagnosticsFromMetricsError(tt.err)
+			assert.EqualValues(t, tt.expectedErrorCode, diagnostics.ErrorCode)
+			assert.EqualValues(t, tt.expectedWarnings, diagnostics.Warnings)
+		})
+	}
+}
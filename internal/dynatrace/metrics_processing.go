@@ -106,6 +106,63 @@ func (e *MetricsQueryReturnedNullValueError) Error() string {
 	return appendOptionalWarningsToMessage("Metrics API v2 returned 'null' as value", e.Warnings)
 }
 
+// MetricsQueryRetriedError represents an error that occurred after a metrics query was modified and retried.
+// Its message is that of the cause so that retrying a query does not change the messages reported.
+type MetricsQueryRetriedError struct {
+	Retries []string
+	cause   error
+}
+
+// Error returns a string representation of the MetricsQueryRetriedError.
+func (e *MetricsQueryRetriedError) Error() string {
+	return e.cause.Error()
+}
+
+// Unwrap returns the cause of the MetricsQueryRetriedError.
+func (e *MetricsQueryRetriedError) Unwrap() error {
+	return e.cause
+}
+
+// GetMetricsQueryWarnings gets any Metrics API v2 warnings associated with the specified error.
+func GetMetricsQueryWarnings(err error) []string {
+	var zeroMetricSeriesError *MetricsQueryReturnedZeroMetricSeriesError
+	if errors.As(err, &zeroMetricSeriesError) {
+		return zeroMetricSeriesError.Warnings
+	}
+
+	var multipleMetricSeriesError *MetricsQueryReturnedMultipleMetricSeriesError
+	if errors.As(err, &multipleMetricSeriesError) {
+		return multipleMetricSeriesError.Warnings
+	}
+
+	var zeroValuesError *MetricsQueryReturnedZeroValuesError
+	if errors.As(err, &zeroValuesError) {
+		return zeroValuesError.Warnings
+	}
+
+	var multipleValuesError *MetricsQueryReturnedMultipleValuesError
+	if errors.As(err, &multipleValuesError) {
+		return multipleValuesError.Warnings
+	}
+
+	var nullValueError *MetricsQueryReturnedNullValueError
+	if errors.As(err, &nullValueError) {
+		return nullValueError.Warnings
+	}
+
+	return nil
+}
+
+// GetMetricsQueryRetries gets any retries of the metrics query associated with the specified error.
+func GetMetricsQueryRetries(err error) []string {
+	var retriedError *MetricsQueryRetriedError
+	if errors.As(err, &retriedError) {
+		return retriedError.Retries
+	}
+
+	return nil
+}
+
 func appendOptionalWarningsToMessage(message string, warnings []string) string {
 	if len(warnings) > 0 {
 		return fmt.Sprintf("%s. Warnings: %s", message, strings.Join(warnings, ", "))
@@ -118,6 +175,8 @@ type MetricsProcessingResults struct {
 	request  MetricsClientQueryRequest
 	results  []MetricsProcessingResult
 	warnings []string
+	unitID   string
+	retries  []string
 }
 
 func newMetricsProcessingResults(request MetricsClientQueryRequest, results []MetricsProcessingResult, warnings []string) *MetricsProcessingResults {
@@ -152,6 +211,16 @@ func (r *MetricsProcessingResults) Warnings() []string {
 	return r.warnings
 }
 
+// UnitID gets the unit of the values of the MetricsProcessingResults or an empty string if it is not known.
+func (r *MetricsProcessingResults) UnitID() string {
+	return r.unitID
+}
+
+// Retries gets any modifications that were applied to the query before retrying it, e.g. "resolution=Inf" or "fold".
+func (r *MetricsProcessingResults) Retries() []string {
+	return r.retries
+}
+
 // MetricsProcessingResult associates a value with a name derived from a specific set of dimension values.
 type MetricsProcessingResult struct {
 	name  string
@@ -333,6 +402,7 @@ func (p *MetricsProcessingDecorator) ProcessRequest(ctx context.Context, request
 
 	results, err := p.metricsProcessing.ProcessRequest(ctx, NewMetricsClientQueryRequest(*modifiedQuery, request.timeframe))
 	if err == nil {
+		results.unitID = metricsQueryModifier.getResultUnitID()
 		return results, nil
 	}
 
@@ -346,5 +416,13 @@ func (p *MetricsProcessingDecorator) ProcessRequest(ctx context.Context, request
 		return nil, err
 	}
 
-	return p.metricsProcessing.ProcessRequest(ctx, NewMetricsClientQueryRequest(*modifiedQuery, request.timeframe))
+	retries := metricsQueryModifier.getRetries()
+	results, err = p.metricsProcessing.ProcessRequest(ctx, NewMetricsClientQueryRequest(*modifiedQuery, request.timeframe))
+	if err != nil {
+		return nil, &MetricsQueryRetriedError{Retries: retries, cause: err}
+	}
+
+	results.unitID = metricsQueryModifier.getResultUnitID()
+	results.retries = retries
+	return results, nil
 }
@@ -0,0 +1,43 @@
+package dynatrace
+
+const (
+	// DQLNotebookSectionType is the section type for DQL query sections of Dynatrace Notebooks
+	DQLNotebookSectionType = "dql"
+
+	// MarkdownNotebookSectionType is the section type for markdown sections of Dynatrace Notebooks
+	MarkdownNotebookSectionType = "markdown"
+)
+
+// Notebook is the content of a Dynatrace Notebook document.
+type Notebook struct {
+	Version  string            `json:"version"`
+	Sections []NotebookSection `json:"sections"`
+}
+
+// NotebookSection is a single section of a Dynatrace Notebook.
+type NotebookSection struct {
+	ID       string                `json:"id"`
+	Type     string                `json:"type"`
+	Title    string                `json:"title,omitempty"`
+	Markdown string                `json:"markdown,omitempty"`
+	State    *NotebookSectionState `json:"state,omitempty"`
+}
+
+// NotebookSectionState is the state of a Dynatrace Notebook section, including its input.
+type NotebookSectionState struct {
+	Input NotebookSectionInput `json:"input"`
+}
+
+// NotebookSectionInput is the input of a Dynatrace Notebook section, e.g. a DQL query.
+type NotebookSectionInput struct {
+	Value string `json:"value"`
+}
+
+// GetQuery returns the DQL query of the section or an empty string if none is set.
+func (s *NotebookSection) GetQuery() string {
+	if s.State == nil {
+		return ""
+	}
+
+	return s.State.Input.Value
+}
@@ -22,7 +22,7 @@ const (
 	problemSelectorKey = "problemSelector"
 )
 
-// ProblemsV2ClientQueryRequest encapsulates the request for the ProblemsV2Client's GetTotalCountByQuery method.
+// ProblemsV2ClientQueryRequest encapsulates the request for the ProblemsV2Client's GetTotalCountByQuery and GetByQuery methods.
 type ProblemsV2ClientQueryRequest struct {
 	query     problems.Query
 	timeframe common.Timeframe
@@ -58,6 +58,59 @@ type problemQueryResult struct {
 	TotalCount int `json:"totalCount"`
 }
 
+// problemsV2Response represents a page of problems returned by /api/v2/problems.
+type problemsV2Response struct {
+	TotalCount  int         `json:"totalCount"`
+	NextPageKey string      `json:"nextPageKey"`
+	Problems    []ProblemV2 `json:"problems"`
+}
+
+// ProblemV2 represents a problem returned by /api/v2/problems.
+// Here only the fields used for aggregation are considered.
+type ProblemV2 struct {
+	ProblemID        string            `json:"problemId"`
+	DisplayID        string            `json:"displayId"`
+	Title            string            `json:"title"`
+	Status           string            `json:"status"`
+	StartTime        int64             `json:"startTime"`
+	EndTime          int64             `json:"endTime"`
+	ImpactedEntities []ProblemV2Entity `json:"impactedEntities"`
+}
+
+// ProblemV2Entity represents an entity affected or impacted by a problem.
+type ProblemV2Entity struct {
+	EntityID ProblemV2EntityID `json:"entityId"`
+	Name     string            `json:"name"`
+}
+
+// ProblemV2EntityID represents the ID of an entity affected or impacted by a problem.
+type ProblemV2EntityID struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// GetDurationWithin returns the duration of the problem clipped to the specified timeframe.
+// Problems that are still open, i.e. have an end time of -1, are considered to last until the end of the timeframe.
+func (p ProblemV2) GetDurationWithin(timeframe common.Timeframe) time.Duration {
+	start := time.UnixMilli(p.StartTime).UTC()
+	if start.Before(timeframe.Start()) {
+		start = timeframe.Start()
+	}
+
+	end := timeframe.End()
+	if p.EndTime >= 0 {
+		problemEnd := time.UnixMilli(p.EndTime).UTC()
+		if problemEnd.Before(end) {
+			end = problemEnd
+		}
+	}
+
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
 // Problem problem details returned by /api/v2/problems/{PROBLEM-ID}
 // Here only status is considered as that is the only field that is used
 type problem struct {
@@ -96,3 +149,41 @@ func (pc *ProblemsV2Client) GetTotalCountByQuery(ctx context.Context, request Pr
 
 	return result.TotalCount, nil
 }
+
+// GetByQuery calls the Dynatrace V2 API to retrieve all pages of problems for a given query and timeframe.
+func (pc *ProblemsV2Client) GetByQuery(ctx context.Context, request ProblemsV2ClientQueryRequest) ([]ProblemV2, error) {
+	err := NewTimeframeDelay(request.timeframe, ProblemsV2RequiredDelay, ProblemsV2MaximumWait).Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingProblems []ProblemV2
+	nextPageKey := ""
+	for {
+		var body []byte
+		if nextPageKey == "" {
+			body, err = pc.client.Get(ctx, request.RequestString())
+		} else {
+			queryParameters := newQueryParameters()
+			queryParameters.add(nextPageKeyKey, nextPageKey)
+			body, err = pc.client.Get(ctx, ProblemsV2Path+"?"+queryParameters.encode())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var response problemsV2Response
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			return nil, common.NewUnmarshalJSONError("problems", err)
+		}
+
+		matchingProblems = append(matchingProblems, response.Problems...)
+		if response.NextPageKey == "" {
+			break
+		}
+		nextPageKey = response.NextPageKey
+	}
+
+	return matchingProblems, nil
+}
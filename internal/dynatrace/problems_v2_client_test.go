@@ -2,7 +2,9 @@ package dynatrace
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/problems"
@@ -26,3 +28,77 @@ func TestProblemsV2Client_GetTotalCountByQuery(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, 1, totalProblemCount)
 }
+
+func TestProblemsV2Client_GetByQueryFollowsNextPageKey(t *testing.T) {
+	const testdataFolder = "./testdata/problems_v2_client/"
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(ProblemsV2Path+"?from=1571648400000&problemSelector=status%28%22open%22%29&to=1571652000000", filepath.Join(testdataFolder, "paged_problems_page1.json"))
+	handler.AddExact(ProblemsV2Path+"?nextPageKey=AQAAABQBAAAABQ%3D%3D", filepath.Join(testdataFolder, "paged_problems_page2.json"))
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframe(time.Date(2019, 10, 21, 9, 0, 0, 0, time.UTC), time.Date(2019, 10, 21, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	problemQuery := problems.NewQuery("status(\"open\")", "")
+	matchingProblems, err := NewProblemsV2Client(dtClient).GetByQuery(context.TODO(), NewProblemsV2ClientQueryRequest(problemQuery, *timeframe))
+	if assert.NoError(t, err) && assert.Len(t, matchingProblems, 3) {
+		assert.EqualValues(t, "P-191001", matchingProblems[0].DisplayID)
+		assert.EqualValues(t, -1, matchingProblems[1].EndTime)
+		assert.EqualValues(t, "HOST-A7A3BEBFB2E0A2FC", matchingProblems[2].ImpactedEntities[0].EntityID.ID)
+	}
+}
+
+// TestProblemV2_GetDurationWithin tests that problem durations are clipped to the timeframe.
+func TestProblemV2_GetDurationWithin(t *testing.T) {
+	timeframe, err := common.NewTimeframe(time.Date(2019, 10, 21, 9, 0, 0, 0, time.UTC), time.Date(2019, 10, 21, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	toMilliseconds := func(hour int, minute int) int64 {
+		return time.Date(2019, 10, 21, hour, minute, 0, 0, time.UTC).UnixMilli()
+	}
+
+	tests := []struct {
+		name             string
+		problem          ProblemV2
+		expectedDuration time.Duration
+	}{
+		{
+			name:             "within timeframe",
+			problem:          ProblemV2{StartTime: toMilliseconds(9, 10), EndTime: toMilliseconds(9, 25)},
+			expectedDuration: 15 * time.Minute,
+		},
+		{
+			name:             "starts before timeframe",
+			problem:          ProblemV2{StartTime: toMilliseconds(8, 30), EndTime: toMilliseconds(9, 5)},
+			expectedDuration: 5 * time.Minute,
+		},
+		{
+			name:             "ends after timeframe",
+			problem:          ProblemV2{StartTime: toMilliseconds(9, 50), EndTime: toMilliseconds(10, 30)},
+			expectedDuration: 10 * time.Minute,
+		},
+		{
+			name:             "still open",
+			problem:          ProblemV2{StartTime: toMilliseconds(9, 40), EndTime: -1},
+			expectedDuration: 20 * time.Minute,
+		},
+		{
+			name:             "spans timeframe",
+			problem:          ProblemV2{StartTime: toMilliseconds(8, 0), EndTime: -1},
+			expectedDuration: time.Hour,
+		},
+		{
+			name:             "ends before timeframe",
+			problem:          ProblemV2{StartTime: toMilliseconds(8, 0), EndTime: toMilliseconds(8, 30)},
+			expectedDuration: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDuration, tc.problem.GetDurationWithin(*timeframe))
+		})
+	}
+}
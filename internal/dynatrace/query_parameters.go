@@ -18,6 +18,12 @@ func (q *queryParameters) add(key string, value string) {
 	q.values.Add(key, value)
 }
 
+// has returns true if a value has been added for the key
+func (q *queryParameters) has(key string) bool {
+	_, ok := q.values[key]
+	return ok
+}
+
 // encode URL encodes the values
 func (q *queryParameters) encode() string {
 	return q.values.Encode()
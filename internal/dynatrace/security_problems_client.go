@@ -20,9 +20,13 @@ const SecurityProblemsV2MaximumWait = 4 * time.Minute
 
 const (
 	securityProblemSelectorKey = "securityProblemSelector"
+	fieldsKey                  = "fields"
+
+	// riskAssessmentField is the optional field of security problems required for aggregations by risk score or risk level.
+	riskAssessmentField = "+riskAssessment"
 )
 
-// SecurityProblemsV2ClientQueryRequest encapsulates the request for the SecurityProblemsClient's GetTotalCountByQuery method.
+// SecurityProblemsV2ClientQueryRequest encapsulates the request for the SecurityProblemsClient's GetTotalCountByQuery and GetByQuery methods.
 type SecurityProblemsV2ClientQueryRequest struct {
 	query     secpv2.Query
 	timeframe common.Timeframe
@@ -36,22 +40,55 @@ func NewSecurityProblemsClientQueryRequest(query secpv2.Query, timeframe common.
 	}
 }
 
-// RequestString encodes SecurityProblemsV2ClientQueryRequest into a request string.
+// RequestString encodes SecurityProblemsV2ClientQueryRequest into a request string for retrieving the total count of security problems.
 func (q *SecurityProblemsV2ClientQueryRequest) RequestString() string {
+	return SecurityProblemsPath + "?" + q.newQueryParameters().encode()
+}
+
+// ListRequestString encodes SecurityProblemsV2ClientQueryRequest into a request string for retrieving the security problems including their risk assessments.
+func (q *SecurityProblemsV2ClientQueryRequest) ListRequestString() string {
+	queryParameters := q.newQueryParameters()
+	queryParameters.add(fieldsKey, riskAssessmentField)
+	return SecurityProblemsPath + "?" + queryParameters.encode()
+}
+
+func (q *SecurityProblemsV2ClientQueryRequest) newQueryParameters() *queryParameters {
 	queryParameters := newQueryParameters()
 	if q.query.GetSecurityProblemSelector() != "" {
 		queryParameters.add(securityProblemSelectorKey, q.query.GetSecurityProblemSelector())
 	}
 	queryParameters.add(fromKey, common.TimestampToUnixMillisecondsString(q.timeframe.Start()))
 	queryParameters.add(toKey, common.TimestampToUnixMillisecondsString(q.timeframe.End()))
-
-	return SecurityProblemsPath + "?" + queryParameters.encode()
+	return queryParameters
 }
 
 type securityProblemQueryResult struct {
 	TotalCount int `json:"totalCount"`
 }
 
+// securityProblemsResponse represents a page of security problems returned by /api/v2/securityProblems.
+type securityProblemsResponse struct {
+	TotalCount       int               `json:"totalCount"`
+	NextPageKey      string            `json:"nextPageKey"`
+	SecurityProblems []SecurityProblem `json:"securityProblems"`
+}
+
+// SecurityProblem represents a security problem returned by /api/v2/securityProblems.
+// Here only the fields used for aggregation are considered.
+type SecurityProblem struct {
+	SecurityProblemID string                        `json:"securityProblemId"`
+	DisplayID         string                        `json:"displayId"`
+	Status            string                        `json:"status"`
+	Title             string                        `json:"title"`
+	RiskAssessment    SecurityProblemRiskAssessment `json:"riskAssessment"`
+}
+
+// SecurityProblemRiskAssessment represents the Davis risk assessment of a security problem.
+type SecurityProblemRiskAssessment struct {
+	RiskLevel string  `json:"riskLevel"`
+	RiskScore float64 `json:"riskScore"`
+}
+
 // SecurityProblemsClient is a client for interacting with the Dynatrace security problems endpoints
 type SecurityProblemsClient struct {
 	client ClientInterface
@@ -84,3 +121,41 @@ func (sc *SecurityProblemsClient) GetTotalCountByQuery(ctx context.Context, requ
 
 	return result.TotalCount, nil
 }
+
+// GetByQuery calls the Dynatrace API to retrieve all pages of security problems including their risk assessments for the given query and timeframe.
+func (sc *SecurityProblemsClient) GetByQuery(ctx context.Context, request SecurityProblemsV2ClientQueryRequest) ([]SecurityProblem, error) {
+	err := NewTimeframeDelay(request.timeframe, SecurityProblemsV2RequiredDelay, SecurityProblemsV2MaximumWait).Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var securityProblems []SecurityProblem
+	nextPageKey := ""
+	for {
+		var body []byte
+		if nextPageKey == "" {
+			body, err = sc.client.Get(ctx, request.ListRequestString())
+		} else {
+			queryParameters := newQueryParameters()
+			queryParameters.add(nextPageKeyKey, nextPageKey)
+			body, err = sc.client.Get(ctx, SecurityProblemsPath+"?"+queryParameters.encode())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var response securityProblemsResponse
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			return nil, common.NewUnmarshalJSONError("security problems", err)
+		}
+
+		securityProblems = append(securityProblems, response.SecurityProblems...)
+		if response.NextPageKey == "" {
+			break
+		}
+		nextPageKey = response.NextPageKey
+	}
+
+	return securityProblems, nil
+}
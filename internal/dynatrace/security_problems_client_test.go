@@ -2,6 +2,7 @@ package dynatrace
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
@@ -45,3 +46,36 @@ func TestSecurityProblemsClient_GetTotalCountByQuery_Some(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, 177, totalSecurityProblemCount)
 }
+
+func TestSecurityProblemsClient_GetByQueryFollowsNextPageKey(t *testing.T) {
+	const testdataFolder = "./testdata/security_problems_client/"
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(SecurityProblemsPath+"?fields=%2BriskAssessment&from=1638255600000&securityProblemSelector=status%28OPEN%29&to=1638259200000", filepath.Join(testdataFolder, "paged_security_problems_page1.json"))
+	handler.AddExact(SecurityProblemsPath+"?nextPageKey=AQAAABQBAAAABQ%3D%3D", filepath.Join(testdataFolder, "paged_security_problems_page2.json"))
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	timeframe, err := common.NewTimeframeParser("2021-11-30T07:00:00Z", "2021-11-30T08:00:00Z").Parse()
+	assert.NoError(t, err)
+
+	securityProblemQuery := secpv2.NewQuery("status(OPEN)")
+
+	securityProblems, err := NewSecurityProblemsClient(dtClient).GetByQuery(context.TODO(), NewSecurityProblemsClientQueryRequest(securityProblemQuery, *timeframe))
+	if assert.NoError(t, err) && assert.Len(t, securityProblems, 3) {
+		assert.EqualValues(t, "CRITICAL", securityProblems[0].RiskAssessment.RiskLevel)
+		assert.EqualValues(t, 5.3, securityProblems[1].RiskAssessment.RiskScore)
+		assert.EqualValues(t, "S-1003", securityProblems[2].DisplayID)
+	}
+}
+
+// TestSecurityProblemsV2ClientQueryRequest_RequestStrings tests that only the request for listing security problems includes their risk assessments.
+func TestSecurityProblemsV2ClientQueryRequest_RequestStrings(t *testing.T) {
+	timeframe, err := common.NewTimeframeParser("2021-11-30T07:00:00Z", "2021-11-30T08:00:00Z").Parse()
+	assert.NoError(t, err)
+
+	request := NewSecurityProblemsClientQueryRequest(secpv2.NewQuery("status(OPEN)"), *timeframe)
+	assert.EqualValues(t, "/api/v2/securityProblems?from=1638255600000&securityProblemSelector=status%28OPEN%29&to=1638259200000", request.RequestString())
+	assert.EqualValues(t, "/api/v2/securityProblems?fields=%2BriskAssessment&from=1638255600000&securityProblemSelector=status%28OPEN%29&to=1638259200000", request.ListRequestString())
+}
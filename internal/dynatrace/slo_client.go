@@ -3,11 +3,14 @@ package dynatrace
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/slo"
 )
 
 const SLOPath = "/api/v2/slo"
@@ -19,7 +22,9 @@ const SLORequiredDelay = 2 * time.Minute
 const SLOMaximumWait = 4 * time.Minute
 
 const (
-	timeFrameKey = "timeFrame"
+	timeFrameKey   = "timeFrame"
+	sloSelectorKey = "sloSelector"
+	evaluateKey    = "evaluate"
 )
 
 // SLOClientGetRequest encapsulates the request for the SLOClient's Get method.
@@ -46,12 +51,95 @@ func (q *SLOClientGetRequest) RequestString() string {
 	return SLOPath + "/" + url.PathEscape(q.sloID) + "?" + queryParameters.encode()
 }
 
+// SLOClientFindRequest encapsulates the request for the SLOClient's GetIDBySelector method.
+type SLOClientFindRequest struct {
+	sloSelector string
+}
+
+// NewSLOClientFindRequest creates new SLOClientFindRequest.
+func NewSLOClientFindRequest(sloSelector string) SLOClientFindRequest {
+	return SLOClientFindRequest{
+		sloSelector: sloSelector,
+	}
+}
+
+// RequestString encodes SLOClientFindRequest into a request string.
+func (q *SLOClientFindRequest) RequestString() string {
+	queryParameters := newQueryParameters()
+	queryParameters.add(sloSelectorKey, q.sloSelector)
+	queryParameters.add(evaluateKey, "false")
+
+	return SLOPath + "?" + queryParameters.encode()
+}
+
+// SLOSelectorMatchError indicates that an SLO selector did not match exactly one SLO.
+type SLOSelectorMatchError struct {
+	sloSelector string
+	names       []string
+	totalCount  int
+}
+
+// Error returns a string representation of this error.
+func (e *SLOSelectorMatchError) Error() string {
+	if e.totalCount == 0 {
+		return fmt.Sprintf("no SLO matches selector '%s'", e.sloSelector)
+	}
+	return fmt.Sprintf("SLO selector '%s' should match exactly one SLO but matches %d: %s", e.sloSelector, e.totalCount, strings.Join(e.names, ", "))
+}
+
+// TotalCount returns the number of SLOs matching the selector.
+func (e *SLOSelectorMatchError) TotalCount() int {
+	return e.totalCount
+}
+
+// sloListResult represents the result of a query to /api/v2/slo.
+// Here only totalCount as well as the IDs and names of the SLOs are considered.
+type sloListResult struct {
+	TotalCount int                 `json:"totalCount"`
+	SLOs       []sloListResultItem `json:"slo"`
+}
+
+type sloListResultItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 type SLOResult struct {
-	Name                string  `json:"name"`
-	EvaluatedPercentage float64 `json:"evaluatedPercentage"`
-	Error               string  `json:"error"`
-	Target              float64 `json:"target"`
-	Warning             float64 `json:"warning"`
+	Name                string                  `json:"name"`
+	EvaluatedPercentage float64                 `json:"evaluatedPercentage"`
+	ErrorBudget         float64                 `json:"errorBudget"`
+	ErrorBudgetBurnRate *SLOErrorBudgetBurnRate `json:"errorBudgetBurnRate,omitempty"`
+	Error               string                  `json:"error"`
+	Target              float64                 `json:"target"`
+	Warning             float64                 `json:"warning"`
+}
+
+// SLOErrorBudgetBurnRate represents the error budget burn rate of an SLO.
+type SLOErrorBudgetBurnRate struct {
+	BurnRateVisualizationEnabled bool    `json:"burnRateVisualizationEnabled"`
+	BurnRateValue                float64 `json:"burnRateValue"`
+	BurnRateType                 string  `json:"burnRateType,omitempty"`
+}
+
+// GetFieldValue returns the value of the specified field of the SLOResult or an error if the field is unknown or not available.
+func (r *SLOResult) GetFieldValue(field string) (float64, error) {
+	switch field {
+	case slo.EvaluatedPercentageField:
+		return r.EvaluatedPercentage, nil
+	case slo.ErrorBudgetField:
+		return r.ErrorBudget, nil
+	case slo.ErrorBudgetBurnRateField:
+		if r.ErrorBudgetBurnRate == nil || !r.ErrorBudgetBurnRate.BurnRateVisualizationEnabled {
+			return 0, errors.New("error budget burn rate is not available, please enable burn rate visualization for the SLO")
+		}
+		return r.ErrorBudgetBurnRate.BurnRateValue, nil
+	case slo.TargetField:
+		return r.Target, nil
+	case slo.WarningField:
+		return r.Warning, nil
+	default:
+		return 0, fmt.Errorf("unknown SLO field: %s", field)
+	}
 }
 
 type SLOClient struct {
@@ -91,3 +179,28 @@ func (c *SLOClient) Get(ctx context.Context, request SLOClientGetRequest) (*SLOR
 
 	return &result, nil
 }
+
+// GetIDBySelector calls the Dynatrace API to find the SLO matching the SLO selector and returns its ID.
+// It returns a SLOSelectorMatchError if the selector does not match exactly one SLO.
+func (c *SLOClient) GetIDBySelector(ctx context.Context, request SLOClientFindRequest) (string, error) {
+	body, err := c.client.Get(ctx, request.RequestString())
+	if err != nil {
+		return "", err
+	}
+
+	var result sloListResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return "", common.NewUnmarshalJSONError("SLOs", err)
+	}
+
+	if result.TotalCount != 1 || len(result.SLOs) != 1 {
+		names := make([]string, 0, len(result.SLOs))
+		for _, slo := range result.SLOs {
+			names = append(names, slo.Name)
+		}
+		return "", &SLOSelectorMatchError{sloSelector: request.sloSelector, names: names, totalCount: result.TotalCount}
+	}
+
+	return result.SLOs[0].ID, nil
+}
@@ -2,9 +2,11 @@ package dynatrace
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/slo"
 	"github.com/keptn-contrib/dynatrace-service/internal/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,4 +26,131 @@ func TestExecuteGetDynatraceSLO(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, sloResult, "No SLO Result returned for "+sloID)
 	assert.EqualValues(t, 95.66405076939219, sloResult.EvaluatedPercentage, "Not returning expected value for SLO")
+	assert.EqualValues(t, -4.3159492306078135, sloResult.ErrorBudget)
+}
+
+// TestSLOClient_GetIDBySelector tests resolving SLO IDs using SLO selectors.
+func TestSLOClient_GetIDBySelector(t *testing.T) {
+	const testdataFolder = "./testdata/slo_client/"
+
+	tests := []struct {
+		name                 string
+		dataFile             string
+		expectedID           string
+		expectedErrorMessage string
+	}{
+		{
+			name:       "one match",
+			dataFile:   "slos_one_match.json",
+			expectedID: "524ca177-849b-3e8c-8175-42b93fbc33c5",
+		},
+		{
+			name:                 "no match",
+			dataFile:             "slos_no_match.json",
+			expectedErrorMessage: "no SLO matches selector 'text(\"carts\")'",
+		},
+		{
+			name:                 "two matches",
+			dataFile:             "slos_two_matches.json",
+			expectedErrorMessage: "SLO selector 'text(\"carts\")' should match exactly one SLO but matches 2: carts availability, carts availability (staging)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(SLOPath+"?evaluate=false&sloSelector=text%28%22carts%22%29", filepath.Join(testdataFolder, tc.dataFile))
+			dtClient, _, teardown := createDynatraceClient(t, handler)
+			defer teardown()
+
+			id, err := NewSLOClient(dtClient).GetIDBySelector(context.TODO(), NewSLOClientFindRequest("text(\"carts\")"))
+			if tc.expectedErrorMessage != "" {
+				var matchError *SLOSelectorMatchError
+				if assert.ErrorAs(t, err, &matchError) {
+					assert.EqualError(t, err, tc.expectedErrorMessage)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectedID, id)
+		})
+	}
+}
+
+// TestSLOResult_GetFieldValue tests that the values of the supported fields can be retrieved from an SLOResult.
+func TestSLOResult_GetFieldValue(t *testing.T) {
+	sloResult := SLOResult{
+		EvaluatedPercentage: 95.5,
+		ErrorBudget:         -4.5,
+		ErrorBudgetBurnRate: &SLOErrorBudgetBurnRate{
+			BurnRateVisualizationEnabled: true,
+			BurnRateValue:                2.5,
+			BurnRateType:                 "FAST",
+		},
+		Target:  99.9,
+		Warning: 99.95,
+	}
+
+	tests := []struct {
+		name                 string
+		sloResult            SLOResult
+		field                string
+		expectedValue        float64
+		expectedErrorMessage string
+	}{
+		{
+			name:          "evaluated percentage",
+			sloResult:     sloResult,
+			field:         slo.EvaluatedPercentageField,
+			expectedValue: 95.5,
+		},
+		{
+			name:          "error budget",
+			sloResult:     sloResult,
+			field:         slo.ErrorBudgetField,
+			expectedValue: -4.5,
+		},
+		{
+			name:          "error budget burn rate",
+			sloResult:     sloResult,
+			field:         slo.ErrorBudgetBurnRateField,
+			expectedValue: 2.5,
+		},
+		{
+			name:          "target",
+			sloResult:     sloResult,
+			field:         slo.TargetField,
+			expectedValue: 99.9,
+		},
+		{
+			name:          "warning",
+			sloResult:     sloResult,
+			field:         slo.WarningField,
+			expectedValue: 99.95,
+		},
+		{
+			name:                 "error budget burn rate not available",
+			sloResult:            SLOResult{ErrorBudgetBurnRate: &SLOErrorBudgetBurnRate{BurnRateVisualizationEnabled: false}},
+			field:                slo.ErrorBudgetBurnRateField,
+			expectedErrorMessage: "error budget burn rate is not available",
+		},
+		{
+			name:                 "unknown field",
+			sloResult:            sloResult,
+			field:                "status",
+			expectedErrorMessage: "unknown SLO field: status",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := tc.sloResult.GetFieldValue(tc.field)
+			if tc.expectedErrorMessage != "" {
+				assert.ErrorContains(t, err, tc.expectedErrorMessage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectedValue, value)
+		})
+	}
 }
@@ -0,0 +1,109 @@
+package dynatrace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/synthetic"
+)
+
+const (
+	browserMonitorAvailabilityMetricID = "builtin:synthetic.browser.availability.location.total"
+	browserMonitorDurationMetricID     = "builtin:synthetic.browser.totalDuration"
+	httpMonitorAvailabilityMetricID    = "builtin:synthetic.http.availability.location.total"
+	httpMonitorDurationMetricID        = "builtin:synthetic.http.duration.geo"
+)
+
+// SyntheticClientQueryRequest encapsulates the request for the SyntheticClient's GetByQuery method.
+type SyntheticClientQueryRequest struct {
+	query     synthetic.Query
+	timeframe common.Timeframe
+}
+
+// NewSyntheticClientQueryRequest creates new SyntheticClientQueryRequest.
+func NewSyntheticClientQueryRequest(query synthetic.Query, timeframe common.Timeframe) SyntheticClientQueryRequest {
+	return SyntheticClientQueryRequest{
+		query:     query,
+		timeframe: timeframe,
+	}
+}
+
+// RequestString encodes SyntheticClientQueryRequest into a request string.
+func (q *SyntheticClientQueryRequest) RequestString() string {
+	request, err := q.metricsRequest()
+	if err != nil {
+		return ""
+	}
+	return request.RequestString()
+}
+
+// metricsRequest creates the Metrics API v2 request that aggregates the execution results of the monitor over all locations and the whole timeframe.
+func (q *SyntheticClientQueryRequest) metricsRequest() (*MetricsClientQueryRequest, error) {
+	metricID, err := getSyntheticMetricID(q.query)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := metrics.NewQuery(metricID+":splitBy():avg", fmt.Sprintf("entityId(%q)", q.query.GetMonitorID()), metrics.ResolutionInf, "")
+	if err != nil {
+		return nil, err
+	}
+
+	request := NewMetricsClientQueryRequest(*query, q.timeframe)
+	return &request, nil
+}
+
+func getSyntheticMetricID(query synthetic.Query) (string, error) {
+	browserMonitor := synthetic.IsBrowserMonitor(query.GetMonitorID())
+	switch query.GetMetric() {
+	case synthetic.AvailabilityMetric:
+		if browserMonitor {
+			return browserMonitorAvailabilityMetricID, nil
+		}
+		return httpMonitorAvailabilityMetricID, nil
+
+	case synthetic.DurationMetric:
+		if browserMonitor {
+			return browserMonitorDurationMetricID, nil
+		}
+		return httpMonitorDurationMetricID, nil
+
+	default:
+		return "", fmt.Errorf("unknown synthetic metric: %s", query.GetMetric())
+	}
+}
+
+// SyntheticClient is a client for retrieving the execution results of Dynatrace synthetic monitors.
+type SyntheticClient struct {
+	client ClientInterface
+}
+
+// NewSyntheticClient creates a new SyntheticClient
+func NewSyntheticClient(client ClientInterface) *SyntheticClient {
+	return &SyntheticClient{
+		client: client,
+	}
+}
+
+// GetByQuery retrieves the execution results of the synthetic monitor in the timeframe and returns the requested metric aggregated over all executions.
+// Errors returned are those of the underlying metrics processing.
+func (sc *SyntheticClient) GetByQuery(ctx context.Context, request SyntheticClientQueryRequest) (float64, error) {
+	metricsRequest, err := request.metricsRequest()
+	if err != nil {
+		return 0, err
+	}
+
+	results, err := NewMetricsProcessingThatAllowsOnlyOneResult(NewMetricsClient(sc.client)).ProcessRequest(ctx, *metricsRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := results.FirstResultOrError()
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Value(), nil
+}
@@ -0,0 +1,81 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/synthetic"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+// TestSyntheticClientQueryRequest_RequestString tests that the metric queried depends on the type of the monitor.
+func TestSyntheticClientQueryRequest_RequestString(t *testing.T) {
+	tests := []struct {
+		name            string
+		monitorID       string
+		metric          string
+		expectedRequest string
+	}{
+		{
+			name:            "browser monitor availability",
+			monitorID:       "SYNTHETIC_TEST-7A3B9E0F1C2D4E5F",
+			metric:          synthetic.AvailabilityMetric,
+			expectedRequest: MetricsPath + "/query?entitySelector=entityId%28%22SYNTHETIC_TEST-7A3B9E0F1C2D4E5F%22%29&from=1571649084000&metricSelector=builtin%3Asynthetic.browser.availability.location.total%3AsplitBy%28%29%3Aavg&resolution=Inf&to=1571649085000",
+		},
+		{
+			name:            "browser monitor duration",
+			monitorID:       "SYNTHETIC_TEST-7A3B9E0F1C2D4E5F",
+			metric:          synthetic.DurationMetric,
+			expectedRequest: MetricsPath + "/query?entitySelector=entityId%28%22SYNTHETIC_TEST-7A3B9E0F1C2D4E5F%22%29&from=1571649084000&metricSelector=builtin%3Asynthetic.browser.totalDuration%3AsplitBy%28%29%3Aavg&resolution=Inf&to=1571649085000",
+		},
+		{
+			name:            "HTTP monitor availability",
+			monitorID:       "HTTP_CHECK-1C2D3E4F5A6B7C8D",
+			metric:          synthetic.AvailabilityMetric,
+			expectedRequest: MetricsPath + "/query?entitySelector=entityId%28%22HTTP_CHECK-1C2D3E4F5A6B7C8D%22%29&from=1571649084000&metricSelector=builtin%3Asynthetic.http.availability.location.total%3AsplitBy%28%29%3Aavg&resolution=Inf&to=1571649085000",
+		},
+		{
+			name:            "HTTP monitor duration",
+			monitorID:       "HTTP_CHECK-1C2D3E4F5A6B7C8D",
+			metric:          synthetic.DurationMetric,
+			expectedRequest: MetricsPath + "/query?entitySelector=entityId%28%22HTTP_CHECK-1C2D3E4F5A6B7C8D%22%29&from=1571649084000&metricSelector=builtin%3Asynthetic.http.duration.geo%3AsplitBy%28%29%3Aavg&resolution=Inf&to=1571649085000",
+		},
+	}
+
+	timeframe, err := common.NewTimeframeParser("2019-10-21T09:11:24Z", "2019-10-21T09:11:25Z").Parse()
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := synthetic.NewQuery(tt.monitorID, tt.metric)
+			assert.NoError(t, err)
+
+			request := NewSyntheticClientQueryRequest(*query, *timeframe)
+			assert.EqualValues(t, tt.expectedRequest, request.RequestString())
+		})
+	}
+}
+
+func TestSyntheticClient_GetByQuery(t *testing.T) {
+	timeframe, err := common.NewTimeframeParser("2019-10-21T09:11:24Z", "2019-10-21T09:11:25Z").Parse()
+	assert.NoError(t, err)
+
+	query, err := synthetic.NewQuery("HTTP_CHECK-1C2D3E4F5A6B7C8D", synthetic.AvailabilityMetric)
+	assert.NoError(t, err)
+
+	request := NewSyntheticClientQueryRequest(*query, *timeframe)
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(request.RequestString(), "./testdata/test_syntheticclient_getbyquery.json")
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	value, err := NewSyntheticClient(dtClient).GetByQuery(context.TODO(), request)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 98.5, value)
+}
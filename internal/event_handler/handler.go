@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	keptnevents "github.com/keptn/go-utils/pkg/lib"
@@ -17,9 +16,12 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/lint"
 	"github.com/keptn-contrib/dynatrace-service/internal/monitoring"
 	"github.com/keptn-contrib/dynatrace-service/internal/problem"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
 )
 
 // DynatraceEventHandler is the common interface for all event handlers.
@@ -30,8 +32,9 @@ type DynatraceEventHandler interface {
 }
 
 // NewEventHandler creates a new DynatraceEventHandler for the specified event.
-func NewEventHandler(ctx context.Context, clientFactory keptn.ClientFactoryInterface, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event) (DynatraceEventHandler, error) {
-	eventHandler, err := getEventHandler(ctx, eventSenderClient, event, clientFactory)
+// The specified dashboard cache is shared by all sh.keptn.event.get-sli.triggered event handlers.
+func NewEventHandler(ctx context.Context, clientFactory keptn.ClientFactoryInterface, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event, dashboardCache *dashboard.Cache) (DynatraceEventHandler, error) {
+	eventHandler, err := getEventHandler(ctx, eventSenderClient, event, clientFactory, dashboardCache)
 	if err != nil {
 		log.WithError(err).Error("Cannot handle event")
 		return NewErrorHandler(fmt.Errorf("cannot handle event: %w", err), event, eventSenderClient, clientFactory.CreateUniformClient()), nil
@@ -40,7 +43,7 @@ func NewEventHandler(ctx context.Context, clientFactory keptn.ClientFactoryInter
 	return eventHandler, nil
 }
 
-func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event, clientFactory keptn.ClientFactoryInterface) (DynatraceEventHandler, error) {
+func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderClientInterface, event cloudevents.Event, clientFactory keptn.ClientFactoryInterface, dashboardCache *dashboard.Cache) (DynatraceEventHandler, error) {
 	log.WithField("eventType", event.Type()).Debug("Received event")
 
 	keptnEvent, err := getEventAdapter(event)
@@ -111,7 +114,9 @@ func getEventHandler(ctx context.Context, eventSenderClient keptn.EventSenderCli
 	case *action.ActionFinishedAdapter:
 		return action.NewActionFinishedEventHandler(keptnEvent.(*action.ActionFinishedAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
 	case *sli.GetSLITriggeredAdapter:
-		return sli.NewGetSLITriggeredHandler(keptnEvent.(*sli.GetSLITriggeredAdapter), dtClient, eventSenderClient, keptn.NewConfigClient(clientFactory.CreateResourceClient()), dynatraceConfig.DtCreds, dynatraceConfig.Dashboard, ff.LoadGetSLIFeatureFlags()), nil
+		return sli.NewGetSLITriggeredHandler(keptnEvent.(*sli.GetSLITriggeredAdapter), dtClient, eventSenderClient, keptn.NewConfigClient(clientFactory.CreateResourceClient()), dynatraceConfig.DtCreds, dynatraceConfig.Dashboard, ff.LoadGetSLIFeatureFlags(), dashboardCache), nil
+	case *lint.LintDashboardTriggeredAdapter:
+		return lint.NewLintDashboardTriggeredHandler(keptnEvent.(*lint.LintDashboardTriggeredAdapter), dtClient, eventSenderClient, keptn.NewConfigClient(clientFactory.CreateResourceClient()), dynatraceConfig.Dashboard, ff.LoadGetSLIFeatureFlags()), nil
 	case *action.DeploymentFinishedAdapter:
 		return action.NewDeploymentFinishedEventHandler(keptnEvent.(*action.DeploymentFinishedAdapter), dtClient, clientFactory.CreateEventClient(), keptn.NewBridgeURLCreator(keptnCredentialsProvider), dynatraceConfig.AttachRules), nil
 	case *action.TestTriggeredAdapter:
@@ -148,6 +153,8 @@ func getEventAdapter(e cloudevents.Event) (adapter.EventContentAdapter, error) {
 			return nil, nil
 		}
 		return a, nil
+	case keptnv2.GetTriggeredEventType(lint.LintDashboardTaskName):
+		return lint.NewLintDashboardTriggeredAdapterFromEvent(e)
 	case keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName):
 		return action.NewDeploymentFinishedAdapterFromEvent(e)
 	case keptnv2.GetTriggeredEventType(keptnv2.TestTaskName):
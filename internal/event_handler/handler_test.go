@@ -8,7 +8,9 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/lint"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/stretchr/testify/assert"
 )
@@ -61,7 +63,7 @@ func TestEventHandlerIgnoresGetSLITriggeredNotForDynatrace(t *testing.T) {
 		t: t,
 	}
 
-	handler, err := NewEventHandler(context.Background(), clientFactory, eventSenderClient, getSLITriggeredEvent)
+	handler, err := NewEventHandler(context.Background(), clientFactory, eventSenderClient, getSLITriggeredEvent, dashboard.NewCache())
 	if !assert.NoError(t, err) {
 		return
 	}
@@ -70,6 +72,27 @@ func TestEventHandlerIgnoresGetSLITriggeredNotForDynatrace(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// Test_getEventAdapterForLintDashboardTriggered tests that getEventAdapter returns a lint.LintDashboardTriggeredAdapter and no error for an "sh.keptn.event.lint-dashboard.triggered" event.
+func Test_getEventAdapterForLintDashboardTriggered(t *testing.T) {
+	lintDashboardTriggeredEvent, err := createTestCloudEvent("sh.keptn.event.lint-dashboard.triggered", keptnv2.EventData{
+		Project: "my-project",
+		Stage:   "quality-gate",
+		Service: "test",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	adapter, err := getEventAdapter(lintDashboardTriggeredEvent)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	lintDashboardTriggeredAdapter, ok := adapter.(*lint.LintDashboardTriggeredAdapter)
+	assert.True(t, ok)
+	assert.NotNil(t, lintDashboardTriggeredAdapter)
+}
+
 func createTestGetSLITriggeredCloudEvent(sliProvider string) (cloudevents.Event, error) {
 	return createTestCloudEvent("sh.keptn.event.get-sli.triggered", keptnv2.GetSLITriggeredEventData{
 		EventData: keptnv2.EventData{
@@ -116,6 +116,11 @@ func (rc *ConfigClient) GetDynatraceConfig(ctx context.Context, project string,
 	return rc.client.GetResource(ctx, project, stage, service, configFilename)
 }
 
+// GetDashboard gets the dashboard resource with the specified URI for the specified project, stage and service, checking first on the service, then stage and then project level.
+func (rc *ConfigClient) GetDashboard(ctx context.Context, project string, stage string, service string, resourceURI string) (string, error) {
+	return rc.client.GetResource(ctx, project, stage, service, resourceURI)
+}
+
 // GetShipyard returns the shipyard definition of a project.
 func (rc *ConfigClient) GetShipyard(ctx context.Context, project string) (*keptnv2.Shipyard, error) {
 	shipyardResource, err := rc.client.GetProjectResource(ctx, project, shipyardFilename)
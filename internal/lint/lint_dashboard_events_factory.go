@@ -0,0 +1,89 @@
+package lint
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// LintDashboardStartedEventFactory is a factory for lint-dashboard.started cloud events.
+type LintDashboardStartedEventFactory struct {
+	event LintDashboardTriggeredAdapterInterface
+}
+
+// NewLintDashboardStartedEventFactory creates a new LintDashboardStartedEventFactory.
+func NewLintDashboardStartedEventFactory(event LintDashboardTriggeredAdapterInterface) *LintDashboardStartedEventFactory {
+	return &LintDashboardStartedEventFactory{
+		event: event,
+	}
+}
+
+// CreateCloudEvent creates a cloud event based on the factory or returns an error if this can't be done.
+func (f *LintDashboardStartedEventFactory) CreateCloudEvent() (*cloudevents.Event, error) {
+	lintDashboardStartedEvent := keptnv2.EventData{
+		Project: f.event.GetProject(),
+		Stage:   f.event.GetStage(),
+		Service: f.event.GetService(),
+		Labels:  f.event.GetLabels(),
+		Status:  keptnv2.StatusSucceeded,
+		Result:  keptnv2.ResultPass,
+	}
+	return adapter.NewCloudEventFactory(f.event, keptnv2.GetStartedEventType(LintDashboardTaskName), lintDashboardStartedEvent).CreateCloudEvent()
+}
+
+// LintDashboardFinishedEventFactory is a factory for lint-dashboard.finished cloud events.
+type LintDashboardFinishedEventFactory struct {
+	incomingEvent LintDashboardTriggeredAdapterInterface
+	eventData     *lintDashboardFinishedEventData
+}
+
+// NewErroredLintDashboardFinishedEventFactory creates a new LintDashboardFinishedEventFactory for a dashboard that could not be linted, e.g. because it could not be retrieved.
+func NewErroredLintDashboardFinishedEventFactory(incomingEvent LintDashboardTriggeredAdapterInterface, err error) *LintDashboardFinishedEventFactory {
+	return &LintDashboardFinishedEventFactory{
+		incomingEvent: incomingEvent,
+		eventData:     newLintDashboardFinishedEventData(incomingEvent, keptnv2.StatusErrored, keptnv2.ResultFailed, err.Error(), &dashboard.LintReport{Problems: []dashboard.LintProblem{}}),
+	}
+}
+
+// NewSuccessfulLintDashboardFinishedEventFactoryFromReport creates a new LintDashboardFinishedEventFactory for the specified lint report.
+// The result is pass if no problems were found and fail otherwise.
+func NewSuccessfulLintDashboardFinishedEventFactoryFromReport(incomingEvent LintDashboardTriggeredAdapterInterface, report *dashboard.LintReport) *LintDashboardFinishedEventFactory {
+	result := keptnv2.ResultPass
+	message := "Dashboard contains no problems"
+	if report.HasProblems() {
+		result = keptnv2.ResultFailed
+		message = "Dashboard contains problems"
+	}
+
+	return &LintDashboardFinishedEventFactory{
+		incomingEvent: incomingEvent,
+		eventData:     newLintDashboardFinishedEventData(incomingEvent, keptnv2.StatusSucceeded, result, message, report),
+	}
+}
+
+func newLintDashboardFinishedEventData(incomingEvent LintDashboardTriggeredAdapterInterface, status keptnv2.StatusType, result keptnv2.ResultType, message string, report *dashboard.LintReport) *lintDashboardFinishedEventData {
+	return &lintDashboardFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: incomingEvent.GetProject(),
+			Stage:   incomingEvent.GetStage(),
+			Service: incomingEvent.GetService(),
+			Labels:  incomingEvent.GetLabels(),
+			Status:  status,
+			Result:  result,
+			Message: message,
+		},
+		LintDashboard: *report,
+	}
+}
+
+// lintDashboardFinishedEventData is the data of a sh.keptn.event.lint-dashboard.finished event.
+type lintDashboardFinishedEventData struct {
+	keptnv2.EventData
+	LintDashboard dashboard.LintReport `json:"lint-dashboard"`
+}
+
+// CreateCloudEvent creates a cloud event based on the factory or returns an error if this can't be done.
+func (f *LintDashboardFinishedEventFactory) CreateCloudEvent() (*cloudevents.Event, error) {
+	return adapter.NewCloudEventFactory(f.incomingEvent, keptnv2.GetFinishedEventType(LintDashboardTaskName), f.eventData).CreateCloudEvent()
+}
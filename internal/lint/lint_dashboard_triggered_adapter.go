@@ -0,0 +1,111 @@
+package lint
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// LintDashboardTaskName is the name of the Keptn task for linting the dashboard used for SLIs and SLOs.
+const LintDashboardTaskName = "lint-dashboard"
+
+type LintDashboardTriggeredAdapterInterface interface {
+	adapter.EventContentAdapter
+	adapter.TriggeredCloudEventContentAdapter
+
+	GetDashboard() string
+}
+
+// lintDashboardTriggeredEventData is the data of a sh.keptn.event.lint-dashboard.triggered event.
+type lintDashboardTriggeredEventData struct {
+	keptnv2.EventData
+	LintDashboard lintDashboardTriggered `json:"lint-dashboard"`
+}
+
+// lintDashboardTriggered contains the task specific data of a sh.keptn.event.lint-dashboard.triggered event.
+type lintDashboardTriggered struct {
+	// Dashboard optionally overrides the dashboard property of the dynatrace.conf.yaml, e.g. to lint a dashboard before it is used for evaluations.
+	Dashboard string `json:"dashboard,omitempty"`
+}
+
+// LintDashboardTriggeredAdapter is a content adaptor for events of type sh.keptn.event.lint-dashboard.triggered
+type LintDashboardTriggeredAdapter struct {
+	event      lintDashboardTriggeredEventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewLintDashboardTriggeredAdapterFromEvent creates a new LintDashboardTriggeredAdapter from a cloudevents Event
+func NewLintDashboardTriggeredAdapterFromEvent(e cloudevents.Event) (*LintDashboardTriggeredAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	ldData := &lintDashboardTriggeredEventData{}
+	err := ceAdapter.PayloadAs(ldData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LintDashboardTriggeredAdapter{
+		event:      *ldData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a LintDashboardTriggeredAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a LintDashboardTriggeredAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a LintDashboardTriggeredAdapter) GetEvent() string {
+	return ""
+}
+
+// GetProject returns the project
+func (a LintDashboardTriggeredAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a LintDashboardTriggeredAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a LintDashboardTriggeredAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a LintDashboardTriggeredAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a LintDashboardTriggeredAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a LintDashboardTriggeredAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a LintDashboardTriggeredAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
+
+// GetEventID returns the ID of the event
+func (a LintDashboardTriggeredAdapter) GetEventID() string {
+	return a.cloudEvent.GetEventID()
+}
+
+// GetDashboard returns the dashboard property specified in the event, which may be empty
+func (a LintDashboardTriggeredAdapter) GetDashboard() string {
+	return a.event.LintDashboard.Dashboard
+}
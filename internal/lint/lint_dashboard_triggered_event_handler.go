@@ -0,0 +1,97 @@
+package lint
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+)
+
+// LintDashboardEventHandler handles sh.keptn.event.lint-dashboard.triggered events by linting the dashboard that would be used for SLIs and SLOs.
+type LintDashboardEventHandler struct {
+	event             LintDashboardTriggeredAdapterInterface
+	dtClient          dynatrace.ClientInterface
+	eventSenderClient keptn.EventSenderClientInterface
+	dashboardReader   dashboardReaderInterface
+	dashboardProperty string
+	featureFlags      ff.GetSLIFeatureFlags
+}
+
+// dashboardReaderInterface is a subset of a keptn.ConfigClientInterface for processing sh.keptn.event.lint-dashboard.triggered events.
+// It can read dashboards stored as resources.
+type dashboardReaderInterface interface {
+
+	// GetDashboard gets the dashboard resource with the specified URI for the specified project, stage and service, checking first on the service, then stage and then project level.
+	GetDashboard(ctx context.Context, project string, stage string, service string, resourceURI string) (string, error)
+}
+
+// NewLintDashboardTriggeredHandler creates a new LintDashboardEventHandler.
+// The dashboard property specified in the event, if any, takes precedence over the specified dashboard property from the dynatrace.conf.yaml.
+func NewLintDashboardTriggeredHandler(event LintDashboardTriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eventSenderClient keptn.EventSenderClientInterface, dashboardReader dashboardReaderInterface, dashboardProperty string, flags ff.GetSLIFeatureFlags) LintDashboardEventHandler {
+	if event.GetDashboard() != "" {
+		dashboardProperty = event.GetDashboard()
+	}
+
+	return LintDashboardEventHandler{
+		event:             event,
+		dtClient:          dtClient,
+		eventSenderClient: eventSenderClient,
+		dashboardReader:   dashboardReader,
+		dashboardProperty: dashboardProperty,
+		featureFlags:      flags,
+	}
+}
+
+// HandleEvent handles a lint-dashboard triggered event.
+func (eh LintDashboardEventHandler) HandleEvent(workCtx context.Context, _ context.Context) error {
+	if err := eh.sendEvent(NewLintDashboardStartedEventFactory(eh.event)); err != nil {
+		return err
+	}
+
+	lintDashboardFinishedEventFactory := eh.processEvent(workCtx)
+
+	log.Info("Finished linting dashboard, sending sh.keptn.event.lint-dashboard.finished event now...")
+	return eh.sendEvent(lintDashboardFinishedEventFactory)
+}
+
+func (eh *LintDashboardEventHandler) processEvent(ctx context.Context) *LintDashboardFinishedEventFactory {
+	log.WithFields(
+		log.Fields{
+			"project":   eh.event.GetProject(),
+			"stage":     eh.event.GetStage(),
+			"service":   eh.event.GetService(),
+			"dashboard": eh.dashboardProperty,
+		}).Info("Processing sh.keptn.event.lint-dashboard.triggered")
+
+	if eh.dashboardProperty == "" {
+		return NewErroredLintDashboardFinishedEventFactory(eh.event, errors.New("no dashboard specified, either set the 'dashboard' property in dynatrace.conf.yaml or in the event"))
+	}
+
+	report, err := dashboard.NewLinting(eh.dtClient, eh.event, eh.dashboardReader, eh.featureFlags).Lint(ctx, eh.dashboardProperty)
+	if err != nil {
+		log.WithError(err).Error("error linting dashboard")
+		return NewErroredLintDashboardFinishedEventFactory(eh.event, err)
+	}
+
+	for _, p := range report.Problems {
+		log.WithField("problem", p).Warn("Found problem in dashboard")
+	}
+
+	return NewSuccessfulLintDashboardFinishedEventFactoryFromReport(eh.event, report)
+}
+
+func (eh *LintDashboardEventHandler) sendEvent(factory adapter.CloudEventFactoryInterface) error {
+	err := eh.eventSenderClient.SendCloudEvent(factory)
+	if err != nil {
+		log.WithError(err).Error("Could not send lint-dashboard cloud event")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+)
+
+const testDashboardResourceURI = "dynatrace/kqg-dashboard.json"
+
+func TestLintDashboardEventHandler_HandleEvent(t *testing.T) {
+	tests := []struct {
+		name                   string
+		configuredDashboard    string
+		eventDashboard         string
+		dashboardFile          string
+		dashboardReaderErr     error
+		expectedStatus         keptnv2.StatusType
+		expectedResult         keptnv2.ResultType
+		expectedMessage        string
+		expectedProblemKinds   []dashboard.LintProblemKind
+		expectedDashboardIDSet bool
+	}{
+		{
+			name:                   "dashboard with problems fails",
+			configuredDashboard:    "resource:" + testDashboardResourceURI,
+			dashboardFile:          "./testdata/dashboard_with_problems.json",
+			expectedStatus:         keptnv2.StatusSucceeded,
+			expectedResult:         keptnv2.ResultFailed,
+			expectedMessage:        "Dashboard contains problems",
+			expectedProblemKinds:   []dashboard.LintProblemKind{dashboard.TileConfigurationLintProblemKind, dashboard.UnsupportedTileTypeLintProblemKind},
+			expectedDashboardIDSet: true,
+		},
+		{
+			name:                   "dashboard without problems passes",
+			configuredDashboard:    "resource:" + testDashboardResourceURI,
+			dashboardFile:          "./testdata/dashboard_without_problems.json",
+			expectedStatus:         keptnv2.StatusSucceeded,
+			expectedResult:         keptnv2.ResultPass,
+			expectedMessage:        "Dashboard contains no problems",
+			expectedProblemKinds:   []dashboard.LintProblemKind{},
+			expectedDashboardIDSet: true,
+		},
+		{
+			name:                   "dashboard specified in event takes precedence",
+			configuredDashboard:    "query",
+			eventDashboard:         "resource:" + testDashboardResourceURI,
+			dashboardFile:          "./testdata/dashboard_without_problems.json",
+			expectedStatus:         keptnv2.StatusSucceeded,
+			expectedResult:         keptnv2.ResultPass,
+			expectedMessage:        "Dashboard contains no problems",
+			expectedProblemKinds:   []dashboard.LintProblemKind{},
+			expectedDashboardIDSet: true,
+		},
+		{
+			name:                 "no dashboard specified errors",
+			expectedStatus:       keptnv2.StatusErrored,
+			expectedResult:       keptnv2.ResultFailed,
+			expectedMessage:      "no dashboard specified",
+			expectedProblemKinds: []dashboard.LintProblemKind{},
+		},
+		{
+			name:                 "dashboard that cannot be retrieved errors",
+			configuredDashboard:  "resource:" + testDashboardResourceURI,
+			dashboardReaderErr:   errors.New("resource not found"),
+			expectedStatus:       keptnv2.StatusErrored,
+			expectedResult:       keptnv2.ResultFailed,
+			expectedMessage:      "resource not found",
+			expectedProblemKinds: []dashboard.LintProblemKind{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := NewLintDashboardTriggeredAdapterFromEvent(createTestLintDashboardTriggeredCloudEvent(t, tt.eventDashboard))
+			require.NoError(t, err)
+
+			dashboardReader := &dashboardReaderMock{t: t, err: tt.dashboardReaderErr}
+			if tt.dashboardFile != "" {
+				content, err := os.ReadFile(tt.dashboardFile)
+				require.NoError(t, err)
+				dashboardReader.dashboard = string(content)
+			}
+
+			eventSenderClient := &eventSenderClientMock{}
+			handler := NewLintDashboardTriggeredHandler(event, nil, eventSenderClient, dashboardReader, tt.configuredDashboard, ff.GetSLIFeatureFlags{})
+
+			err = handler.HandleEvent(context.Background(), context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 2, len(eventSenderClient.eventSink))
+
+			assert.Equal(t, "sh.keptn.event.lint-dashboard.started", eventSenderClient.eventSink[0].Type())
+			assert.Equal(t, "sh.keptn.event.lint-dashboard.finished", eventSenderClient.eventSink[1].Type())
+
+			finishedEventData := &lintDashboardFinishedEventData{}
+			require.NoError(t, eventSenderClient.eventSink[1].DataAs(finishedEventData))
+
+			assert.Equal(t, "my-project", finishedEventData.Project)
+			assert.Equal(t, tt.expectedStatus, finishedEventData.Status)
+			assert.Equal(t, tt.expectedResult, finishedEventData.Result)
+			assert.Contains(t, finishedEventData.Message, tt.expectedMessage)
+			assert.Equal(t, tt.expectedDashboardIDSet, finishedEventData.LintDashboard.DashboardID != "")
+
+			problemKinds := make([]dashboard.LintProblemKind, len(finishedEventData.LintDashboard.Problems))
+			for i, p := range finishedEventData.LintDashboard.Problems {
+				problemKinds[i] = p.Kind
+			}
+			assert.Equal(t, tt.expectedProblemKinds, problemKinds)
+		})
+	}
+}
+
+func createTestLintDashboardTriggeredCloudEvent(t *testing.T, dashboardProperty string) cloudevents.Event {
+	ev := cloudevents.NewEvent()
+	ev.SetSource("shipyard-controller")
+	ev.SetDataContentType(cloudevents.ApplicationJSON)
+	ev.SetType("sh.keptn.event.lint-dashboard.triggered")
+	ev.SetExtension("shkeptncontext", "7c2c890f-b3ac-4caa-8922-f44d2aa54ec9")
+
+	err := ev.SetData(cloudevents.ApplicationJSON, lintDashboardTriggeredEventData{
+		EventData: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "quality-gate",
+			Service: "test",
+		},
+		LintDashboard: lintDashboardTriggered{
+			Dashboard: dashboardProperty,
+		},
+	})
+	require.NoError(t, err)
+	return ev
+}
+
+type dashboardReaderMock struct {
+	t         *testing.T
+	dashboard string
+	err       error
+}
+
+func (m *dashboardReaderMock) GetDashboard(_ context.Context, project string, stage string, service string, resourceURI string) (string, error) {
+	assert.Equal(m.t, "my-project", project)
+	assert.Equal(m.t, "quality-gate", stage)
+	assert.Equal(m.t, "test", service)
+	assert.Equal(m.t, testDashboardResourceURI, resourceURI)
+
+	if m.err != nil {
+		return "", m.err
+	}
+	return m.dashboard, nil
+}
+
+type eventSenderClientMock struct {
+	eventSink []*cloudevents.Event
+}
+
+func (m *eventSenderClientMock) SendCloudEvent(factory adapter.CloudEventFactoryInterface) error {
+	if factory == nil {
+		return fmt.Errorf("missing factory")
+	}
+
+	ce, err := factory.CreateCloudEvent()
+	if err != nil {
+		return err
+	}
+
+	m.eventSink = append(m.eventSink, ce)
+	return nil
+}
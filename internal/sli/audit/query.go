@@ -0,0 +1,18 @@
+package audit
+
+// Query encapsulates an Audit logs query.
+type Query struct {
+	filter string
+}
+
+// NewQuery creates a new Query based on the provided filter.
+func NewQuery(filter string) Query {
+	return Query{
+		filter: filter,
+	}
+}
+
+// GetFilter returns the filter.
+func (q Query) GetFilter() string {
+	return q.filter
+}
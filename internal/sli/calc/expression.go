@@ -0,0 +1,352 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a parsed arithmetic expression over indicator values.
+// Supported are numbers, indicator names, the binary operators +, -, * and /, unary + and -, and parentheses.
+// Indicator names containing characters other than letters, digits or _ may be enclosed in braces, e.g. {error-count}.
+type Expression struct {
+	root       node
+	indicators []string
+}
+
+// ParseExpression parses the specified string into an Expression or returns an error.
+func ParseExpression(expression string) (*Expression, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &expressionParser{tokens: tokens}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.atEnd() {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", p.peek().text, p.peek().position)
+	}
+
+	return &Expression{
+		root:       root,
+		indicators: p.indicators,
+	}, nil
+}
+
+// GetIndicators returns the distinct names of the indicators referenced by the expression in order of their first appearance.
+func (e *Expression) GetIndicators() []string {
+	return e.indicators
+}
+
+// Evaluate evaluates the expression using the specified indicator values or returns an error.
+func (e *Expression) Evaluate(values map[string]float64) (float64, error) {
+	value, err := e.root.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, errors.New("expression did not evaluate to a finite number")
+	}
+
+	return value, nil
+}
+
+type tokenType int
+
+const (
+	numberToken tokenType = iota
+	indicatorToken
+	operatorToken
+	leftParenthesisToken
+	rightParenthesisToken
+)
+
+type token struct {
+	tokenType tokenType
+	text      string
+	position  int
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '+' || r == '-' || r == '*' || r == '/':
+			tokens = append(tokens, token{tokenType: operatorToken, text: string(r), position: i})
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{tokenType: leftParenthesisToken, text: string(r), position: i})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{tokenType: rightParenthesisToken, text: string(r), position: i})
+			i++
+
+		case r == '{':
+			end := i + 1
+			for end < len(runes) && runes[end] != '}' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("missing '}' for indicator name at position %d", i)
+			}
+			name := strings.TrimSpace(string(runes[i+1 : end]))
+			if name == "" {
+				return nil, fmt.Errorf("empty indicator name at position %d", i)
+			}
+			tokens = append(tokens, token{tokenType: indicatorToken, text: name, position: i})
+			i = end + 1
+
+		case unicode.IsDigit(r) || r == '.':
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{tokenType: numberToken, text: string(runes[i:end]), position: i})
+			i = end
+
+		case isIndicatorNameStart(r):
+			end := i
+			for end < len(runes) && isIndicatorNamePart(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenType: indicatorToken, text: string(runes[i:end]), position: i})
+			i = end
+
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", r, i)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("expression should not be empty")
+	}
+
+	return tokens, nil
+}
+
+func isIndicatorNameStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIndicatorNamePart(r rune) bool {
+	return isIndicatorNameStart(r) || unicode.IsDigit(r)
+}
+
+// expressionParser is a recursive descent parser for the following grammar:
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = ("+" | "-") unary | operand
+//	operand = number | indicator | "(" sum ")"
+type expressionParser struct {
+	tokens     []token
+	index      int
+	indicators []string
+}
+
+func (p *expressionParser) atEnd() bool {
+	return p.index >= len(p.tokens)
+}
+
+func (p *expressionParser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *expressionParser) nextIsOperator(operators ...string) bool {
+	if p.atEnd() || p.peek().tokenType != operatorToken {
+		return false
+	}
+
+	for _, o := range operators {
+		if p.peek().text == o {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *expressionParser) parseSum() (node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.nextIsOperator("+", "-") {
+		operator := p.peek().text
+		p.index++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseProduct() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.nextIsOperator("*", "/") {
+		operator := p.peek().text
+		p.index++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (node, error) {
+	if p.nextIsOperator("+", "-") {
+		operator := p.peek().text
+		p.index++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		if operator == "-" {
+			return &negationNode{operand: operand}, nil
+		}
+		return operand, nil
+	}
+
+	return p.parseOperand()
+}
+
+func (p *expressionParser) parseOperand() (node, error) {
+	if p.atEnd() {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	t := p.peek()
+	p.index++
+
+	switch t.tokenType {
+	case numberToken:
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", t.text, t.position)
+		}
+		return &numberNode{value: value}, nil
+
+	case indicatorToken:
+		p.addIndicator(t.text)
+		return &indicatorNode{name: t.text}, nil
+
+	case leftParenthesisToken:
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.atEnd() || p.peek().tokenType != rightParenthesisToken {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", t.position)
+		}
+		p.index++
+		return inner, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected '%s' at position %d", t.text, t.position)
+	}
+}
+
+func (p *expressionParser) addIndicator(name string) {
+	for _, indicator := range p.indicators {
+		if indicator == name {
+			return
+		}
+	}
+	p.indicators = append(p.indicators, name)
+}
+
+type node interface {
+	evaluate(values map[string]float64) (float64, error)
+}
+
+type numberNode struct {
+	value float64
+}
+
+func (n *numberNode) evaluate(_ map[string]float64) (float64, error) {
+	return n.value, nil
+}
+
+type indicatorNode struct {
+	name string
+}
+
+func (n *indicatorNode) evaluate(values map[string]float64) (float64, error) {
+	value, ok := values[n.name]
+	if !ok {
+		return 0, fmt.Errorf("no value for indicator '%s'", n.name)
+	}
+	return value, nil
+}
+
+type negationNode struct {
+	operand node
+}
+
+func (n *negationNode) evaluate(values map[string]float64) (float64, error) {
+	value, err := n.operand.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	return -value, nil
+}
+
+type binaryNode struct {
+	operator string
+	left     node
+	right    node
+}
+
+func (n *binaryNode) evaluate(values map[string]float64) (float64, error) {
+	left, err := n.left.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := n.right.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.operator {
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return left / right, nil
+	default:
+		return 0, fmt.Errorf("unknown operator: %s", n.operator)
+	}
+}
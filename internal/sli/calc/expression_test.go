@@ -0,0 +1,166 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExpression_Evaluate tests parsing and evaluating expressions.
+func TestExpression_Evaluate(t *testing.T) {
+	values := map[string]float64{
+		"errors":      5,
+		"requests":    200,
+		"error-count": 3,
+		"zero":        0,
+	}
+
+	tests := []struct {
+		name                 string
+		expression           string
+		expectedIndicators   []string
+		expectedValue        float64
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:               "ratio in percent",
+			expression:         "errors / requests * 100",
+			expectedIndicators: []string{"errors", "requests"},
+			expectedValue:      2.5,
+		},
+		{
+			name:               "operator precedence",
+			expression:         "1 + 2 * 3",
+			expectedIndicators: nil,
+			expectedValue:      7,
+		},
+		{
+			name:               "parentheses",
+			expression:         "(1 + 2) * 3",
+			expectedIndicators: nil,
+			expectedValue:      9,
+		},
+		{
+			name:               "left associative",
+			expression:         "10 - 4 - 3",
+			expectedIndicators: nil,
+			expectedValue:      3,
+		},
+		{
+			name:               "unary minus",
+			expression:         "-errors + -(-2)",
+			expectedIndicators: []string{"errors"},
+			expectedValue:      -3,
+		},
+		{
+			name:               "braced indicator name and duplicates",
+			expression:         "{error-count} + {error-count} + errors",
+			expectedIndicators: []string{"error-count", "errors"},
+			expectedValue:      11,
+		},
+		{
+			name:               "decimal numbers",
+			expression:         "requests * 0.5",
+			expectedIndicators: []string{"requests"},
+			expectedValue:      100,
+		},
+		{
+			name:                 "division by zero",
+			expression:           "errors / zero",
+			expectedIndicators:   []string{"errors", "zero"},
+			expectError:          true,
+			expectedErrorMessage: "division by zero",
+		},
+		{
+			name:                 "missing value",
+			expression:           "errors / unknown",
+			expectedIndicators:   []string{"errors", "unknown"},
+			expectError:          true,
+			expectedErrorMessage: "no value for indicator 'unknown'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expression, err := ParseExpression(tc.expression)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.EqualValues(t, tc.expectedIndicators, expression.GetIndicators())
+
+			value, err := expression.Evaluate(values)
+			if tc.expectError {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tc.expectedValue, value, 0.0000001)
+			}
+		})
+	}
+}
+
+// TestParseExpression_Errors tests that invalid expressions are rejected.
+func TestParseExpression_Errors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expression           string
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "empty",
+			expression:           "  ",
+			expectedErrorMessage: "expression should not be empty",
+		},
+		{
+			name:                 "unsupported character",
+			expression:           "errors % requests",
+			expectedErrorMessage: "unexpected character '%' at position 7",
+		},
+		{
+			name:                 "function calls are not supported",
+			expression:           "max(errors, requests)",
+			expectedErrorMessage: "unexpected character ','",
+		},
+		{
+			name:                 "missing operand",
+			expression:           "errors /",
+			expectedErrorMessage: "unexpected end of expression",
+		},
+		{
+			name:                 "missing closing parenthesis",
+			expression:           "(errors + 1",
+			expectedErrorMessage: "missing ')' for '(' at position 0",
+		},
+		{
+			name:                 "unexpected closing parenthesis",
+			expression:           "errors + 1)",
+			expectedErrorMessage: "unexpected ')' at position 10",
+		},
+		{
+			name:                 "missing operator",
+			expression:           "errors requests",
+			expectedErrorMessage: "unexpected 'requests' at position 7",
+		},
+		{
+			name:                 "invalid number",
+			expression:           "1.2.3",
+			expectedErrorMessage: "invalid number '1.2.3'",
+		},
+		{
+			name:                 "unterminated brace",
+			expression:           "{error-count + 1",
+			expectedErrorMessage: "missing '}'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expression, err := ParseExpression(tc.expression)
+			assert.Nil(t, expression)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+			}
+		})
+	}
+}
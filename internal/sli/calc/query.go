@@ -0,0 +1,41 @@
+package calc
+
+import "errors"
+
+// Query encapsulates a calculated SLI query.
+type Query struct {
+	expression string
+	parsed     *Expression
+}
+
+// NewQuery creates a new Query based on the provided arithmetic expression or returns an error.
+func NewQuery(expression string) (*Query, error) {
+	if expression == "" {
+		return nil, errors.New("expression should not be empty")
+	}
+
+	parsed, err := ParseExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		expression: expression,
+		parsed:     parsed,
+	}, nil
+}
+
+// GetExpression returns the expression.
+func (q Query) GetExpression() string {
+	return q.expression
+}
+
+// GetIndicators returns the names of the indicators the expression depends on.
+func (q Query) GetIndicators() []string {
+	return q.parsed.GetIndicators()
+}
+
+// Evaluate evaluates the expression using the specified indicator values or returns an error.
+func (q Query) Evaluate(values map[string]float64) (float64, error) {
+	return q.parsed.Evaluate(values)
+}
@@ -25,16 +25,18 @@ type CustomChartingTileProcessing struct {
 	customFilters []*keptnv2.SLIFilter
 	timeframe     common.Timeframe
 	featureFlags  ff.GetSLIFeatureFlags
+	sloDefaults   sloDefinitionDefaults
 }
 
 // NewCustomChartingTileProcessing creates a new CustomChartingTileProcessing.
-func NewCustomChartingTileProcessing(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, customFilters []*keptnv2.SLIFilter, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags) *CustomChartingTileProcessing {
+func NewCustomChartingTileProcessing(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, customFilters []*keptnv2.SLIFilter, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags, sloDefaults sloDefinitionDefaults) *CustomChartingTileProcessing {
 	return &CustomChartingTileProcessing{
 		client:        client,
 		eventData:     eventData,
 		customFilters: customFilters,
 		timeframe:     timeframe,
 		featureFlags:  flags,
+		sloDefaults:   sloDefaults,
 	}
 }
 
@@ -45,7 +47,7 @@ func (p *CustomChartingTileProcessing) Process(ctx context.Context, tile *dynatr
 		return nil
 	}
 
-	sloDefinitionParsingResult, err := parseSLODefinition(p.featureFlags, tile.FilterConfig.CustomName)
+	sloDefinitionParsingResult, err := parseSLODefinition(p.featureFlags, p.sloDefaults, tile.FilterConfig.CustomName)
 	if (err == nil) && (sloDefinitionParsingResult.exclude) {
 		log.WithField("tile.FilterConfig.CustomName", tile.FilterConfig.CustomName).Debug("Tile excluded as name includes exclude=true")
 		return nil
@@ -58,39 +60,45 @@ func (p *CustomChartingTileProcessing) Process(ctx context.Context, tile *dynatr
 	}
 
 	if err != nil {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "Custom charting tile title parsing error: "+err.Error())}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "Custom charting tile title parsing error: "+err.Error(), result.ErrorCodeInvalidDefinition)}
 	}
 
 	// get the tile specific management zone filter that might be needed by different tile processors
 	// Check for tile management zone filter - this would overwrite the dashboardManagementZoneFilter
-	tileManagementZoneFilter := NewManagementZoneFilter(dashboardFilter, tile.TileFilter.ManagementZone)
+	filter := NewFilter(dashboardFilter, tile.TileFilter)
 
 	chartConfig := tile.FilterConfig.ChartConfig
 	targetUnitID := chartConfig.LeftAxisCustomUnit
+	if sloDefinitionParsingResult.unit != "" {
+		targetUnitID = sloDefinitionParsingResult.unit
+	}
 	if len(chartConfig.Series) != 1 {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "Custom charting tile must have exactly one series")}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "Custom charting tile must have exactly one series", result.ErrorCodeInvalidDefinition)}
 	}
 
-	return p.processSeries(ctx, sloDefinition, &chartConfig.Series[0], targetUnitID, tileManagementZoneFilter, tile.FilterConfig.FiltersPerEntityType)
+	return p.processSeries(ctx, sloDefinition, &chartConfig.Series[0], targetUnitID, filter, tile.FilterConfig.FiltersPerEntityType)
 }
 
-func (p *CustomChartingTileProcessing) processSeries(ctx context.Context, sloDefinition result.SLO, series *dynatrace.Series, targetUnitID string, tileManagementZoneFilter *ManagementZoneFilter, filtersPerEntityType map[string]dynatrace.FilterMap) []result.SLIWithSLO {
-	metricsQuery, err := p.generateMetricQueryFromChartSeries(ctx, series, tileManagementZoneFilter, filtersPerEntityType)
+func (p *CustomChartingTileProcessing) processSeries(ctx context.Context, sloDefinition result.SLO, series *dynatrace.Series, targetUnitID string, filter *Filter, filtersPerEntityType map[string]dynatrace.FilterMap) []result.SLIWithSLO {
+	// Lets query the metric definition as we need to know how many dimension the metric has
+	metricDefinition, err := dynatrace.NewMetricsClient(p.client).GetMetricDefinitionByID(ctx, series.Metric)
 	if err != nil {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "Custom charting tile could not be converted to a metric query: "+err.Error())}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "error querying Metrics API v2 for metric definition: "+err.Error(), getQueryErrorCode(err, result.ErrorCodeQueryFailed))}
 	}
 
-	return NewMetricsQueryProcessing(p.client, targetUnitID, p.featureFlags).Process(ctx, sloDefinition, *metricsQuery, p.timeframe)
-}
-
-func (p *CustomChartingTileProcessing) generateMetricQueryFromChartSeries(ctx context.Context, series *dynatrace.Series, tileManagementZoneFilter *ManagementZoneFilter, filtersPerEntityType map[string]dynatrace.FilterMap) (*metrics.Query, error) {
-
-	// Lets query the metric definition as we need to know how many dimension the metric has
-	metricDefinition, err := dynatrace.NewMetricsClient(p.client).GetMetricDefinitionByID(ctx, series.Metric)
+	metricsQuery, tagsApplied, err := generateMetricQueryFromChartSeries(series, metricDefinition, filter, filtersPerEntityType)
 	if err != nil {
-		return nil, err
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "Custom charting tile could not be converted to a metric query: "+err.Error(), result.ErrorCodeInvalidDefinition)}
+	}
+
+	results := NewMetricsQueryProcessing(p.client, targetUnitID, p.featureFlags).Process(ctx, sloDefinition, *metricsQuery, p.timeframe)
+	if !tagsApplied {
+		return addWarning(results, tagsNotAppliedWarning)
 	}
+	return results
+}
 
+func generateMetricQueryFromChartSeries(series *dynatrace.Series, metricDefinition *dynatrace.MetricDefinition, filter *Filter, filtersPerEntityType map[string]dynatrace.FilterMap) (*metrics.Query, bool, error) {
 	// handle aggregation. If "NONE" is specified we go to the defaultAggregration
 	metricAggregation := metricDefinition.DefaultAggregation.Type
 	if series.Aggregation != "NONE" {
@@ -117,7 +125,7 @@ func (p *CustomChartingTileProcessing) generateMetricQueryFromChartSeries(ctx co
 	splitBy := ""
 	filterAggregator := ""
 	if len(series.Dimensions) > 1 {
-		return nil, errors.New("only a single dimension is supported")
+		return nil, false, errors.New("only a single dimension is supported")
 	} else if len(series.Dimensions) == 1 {
 		seriesDim := series.Dimensions[0]
 		splitBy = fmt.Sprintf(":splitBy(\"%s\")", seriesDim.Name)
@@ -125,7 +133,7 @@ func (p *CustomChartingTileProcessing) generateMetricQueryFromChartSeries(ctx co
 		// lets check if we need to apply a dimension filter
 		// TODO: support multiple filters - right now we only support 1
 		if len(seriesDim.Values) > 1 {
-			return nil, errors.New("only a single dimension filter is supported")
+			return nil, false, errors.New("only a single dimension filter is supported")
 		}
 
 		if len(seriesDim.Values) == 1 {
@@ -140,15 +148,17 @@ func (p *CustomChartingTileProcessing) generateMetricQueryFromChartSeries(ctx co
 		series.Metric, filterAggregator, splitBy, strings.ToLower(metricAggregation))
 	entitySelector, err := makeEntitySelector(series, filtersPerEntityType, metricDefinition)
 	if err != nil {
-		return nil, fmt.Errorf("could not create entity selector: %w", err)
+		return nil, false, fmt.Errorf("could not create entity selector: %w", err)
 	}
 
-	metricsQuery, err := metrics.NewQuery(metricSelector, entitySelector, "", tileManagementZoneFilter.ForMZSelector())
+	entitySelector, tagsApplied := filter.ForMetricsEntitySelector(entitySelector)
+
+	metricsQuery, err := metrics.NewQuery(metricSelector, entitySelector, "", filter.ForMZSelector())
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return metricsQuery, nil
+	return metricsQuery, tagsApplied, nil
 }
 
 func makeEntitySelector(series *dynatrace.Series, filtersPerEntityType map[string]dynatrace.FilterMap, metricDefinition *dynatrace.MetricDefinition) (string, error) {
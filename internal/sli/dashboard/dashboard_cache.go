@@ -0,0 +1,60 @@
+package dashboard
+
+import (
+	"sync"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// cacheKey identifies a dashboard on a specific Dynatrace tenant.
+type cacheKey struct {
+	tenant      string
+	dashboardID string
+}
+
+// Cache caches parsed dashboards retrieved by ID together with their last-modified time to avoid parsing the same dashboard for every sh.keptn.event.get-sli.triggered event.
+// As the dashboards API provides no way of getting the last-modified time alone, dashboards are still downloaded to detect modifications; only parsing their tiles is avoided.
+// Only the latest version of each dashboard is kept, and a cached dashboard is only used while its last-modified time is unchanged.
+// It is safe for concurrent use.
+type Cache struct {
+	mutex   sync.Mutex
+	entries map[cacheKey]dynatrace.Dashboard
+}
+
+// NewCache creates a new, empty Cache.
+func NewCache() *Cache {
+	return &Cache{
+		entries: make(map[cacheKey]dynatrace.Dashboard),
+	}
+}
+
+// get returns a copy of the latest cached dashboard with the specified ID for the specified tenant, or false if it is not cached.
+// The copy remains consistent even if the cache entry is replaced in the meantime.
+func (c *Cache) get(tenant string, dashboardID string) (*dynatrace.Dashboard, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	dashboard, ok := c.entries[cacheKey{tenant: tenant, dashboardID: dashboardID}]
+	if !ok {
+		return nil, false
+	}
+
+	return &dashboard, true
+}
+
+// put adds the dashboard with the specified ID for the specified tenant to the cache, replacing any previous version.
+// Dashboards without a last-modified time are not cached, as changes to them could not be detected.
+func (c *Cache) put(tenant string, dashboardID string, dashboard *dynatrace.Dashboard) {
+	if c == nil || dashboard.DashboardMetadata.LastModified == 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[cacheKey{tenant: tenant, dashboardID: dashboardID}] = *dashboard
+}
@@ -0,0 +1,93 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+func TestCache(t *testing.T) {
+	const tenant = "https://mySampleEnv.live.dynatrace.com"
+	const dashboardID = "12345678-1111-4444-8888-123456789012"
+	const lastModified = 1664625600000
+
+	tests := []struct {
+		name                 string
+		putLastModified      int64
+		getTenant            string
+		expectCached         bool
+		expectedLastModified int64
+	}{
+		{
+			name:                 "cached dashboard is returned",
+			putLastModified:      lastModified,
+			getTenant:            tenant,
+			expectCached:         true,
+			expectedLastModified: lastModified,
+		},
+		{
+			name:            "dashboard for other tenant is not returned",
+			putLastModified: lastModified,
+			getTenant:       "https://otherEnv.live.dynatrace.com",
+			expectCached:    false,
+		},
+		{
+			name:            "dashboard without last-modified time is not cached",
+			putLastModified: 0,
+			getTenant:       tenant,
+			expectCached:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewCache()
+			cache.put(tenant, dashboardID, &dynatrace.Dashboard{ID: dashboardID, DashboardMetadata: dynatrace.DashboardMetadata{LastModified: tt.putLastModified}})
+
+			dashboard, ok := cache.get(tt.getTenant, dashboardID)
+			assert.Equal(t, tt.expectCached, ok)
+			if tt.expectCached {
+				assert.Equal(t, dashboardID, dashboard.ID)
+				assert.Equal(t, tt.expectedLastModified, dashboard.DashboardMetadata.LastModified)
+			} else {
+				assert.Nil(t, dashboard)
+			}
+		})
+	}
+}
+
+func TestCache_PutReplacesPreviousVersion(t *testing.T) {
+	cache := NewCache()
+	cache.put("tenant", "dashboard", &dynatrace.Dashboard{ID: "dashboard", DashboardMetadata: dynatrace.DashboardMetadata{Name: "old", LastModified: 1}})
+	cache.put("tenant", "dashboard", &dynatrace.Dashboard{ID: "dashboard", DashboardMetadata: dynatrace.DashboardMetadata{Name: "new", LastModified: 2}})
+
+	dashboard, ok := cache.get("tenant", "dashboard")
+	if assert.True(t, ok) {
+		assert.Equal(t, "new", dashboard.DashboardMetadata.Name)
+		assert.EqualValues(t, 2, dashboard.DashboardMetadata.LastModified)
+	}
+}
+
+// TestCache_GetReturnsCopy tests that a dashboard returned by the cache is unaffected by the entry being replaced afterwards.
+func TestCache_GetReturnsCopy(t *testing.T) {
+	cache := NewCache()
+	cache.put("tenant", "dashboard", &dynatrace.Dashboard{ID: "dashboard", DashboardMetadata: dynatrace.DashboardMetadata{Name: "old", LastModified: 1}})
+
+	dashboard, ok := cache.get("tenant", "dashboard")
+	assert.True(t, ok)
+
+	cache.put("tenant", "dashboard", &dynatrace.Dashboard{ID: "dashboard", DashboardMetadata: dynatrace.DashboardMetadata{Name: "new", LastModified: 2}})
+
+	assert.Equal(t, "old", dashboard.DashboardMetadata.Name)
+	assert.EqualValues(t, 1, dashboard.DashboardMetadata.LastModified)
+}
+
+func TestCache_NilCacheDoesNotCache(t *testing.T) {
+	var cache *Cache
+	cache.put("tenant", "dashboard", &dynatrace.Dashboard{ID: "dashboard", DashboardMetadata: dynatrace.DashboardMetadata{LastModified: 1}})
+
+	dashboard, ok := cache.get("tenant", "dashboard")
+	assert.False(t, ok)
+	assert.Nil(t, dashboard)
+}
@@ -0,0 +1,201 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+)
+
+// LintProblemKind is the kind of a problem found while linting a dashboard.
+type LintProblemKind string
+
+const (
+	// MarkdownLintProblemKind indicates that the KQG configuration of a markdown tile is invalid.
+	MarkdownLintProblemKind LintProblemKind = "markdown"
+
+	// TileConfigurationLintProblemKind indicates that a tile is configured in a way that causes its SLIs to fail or prevents them from being retrieved, e.g. an SLO definition or thresholds that cannot be parsed, a timeframe that is not supported or an SLO tile without SLOs.
+	TileConfigurationLintProblemKind LintProblemKind = "tile_configuration"
+
+	// DuplicateSLINameLintProblemKind indicates that a tile produces an SLI whose name is also produced by another tile.
+	DuplicateSLINameLintProblemKind LintProblemKind = "duplicate_sli_name"
+
+	// DuplicateDisplayNameLintProblemKind indicates that a tile produces an SLO whose display name is also used by another tile.
+	DuplicateDisplayNameLintProblemKind LintProblemKind = "duplicate_display_name"
+
+	// UnsupportedTileTypeLintProblemKind indicates that a tile is of a type that is not supported and is thus ignored.
+	UnsupportedTileTypeLintProblemKind LintProblemKind = "unsupported_tile_type"
+)
+
+// LintProblem is a problem found while linting a dashboard.
+type LintProblem struct {
+	Kind      LintProblemKind `json:"kind"`
+	TileIndex int             `json:"tileIndex"`
+	TileName  string          `json:"tileName"`
+	TileType  string          `json:"tileType"`
+	Message   string          `json:"message"`
+}
+
+// LintReport is the result of linting a dashboard.
+type LintReport struct {
+	DashboardID string        `json:"dashboardId,omitempty"`
+	Problems    []LintProblem `json:"problems"`
+}
+
+// HasProblems returns true if any problems were found.
+func (r *LintReport) HasProblems() bool {
+	return len(r.Problems) > 0
+}
+
+// Linting lints a dashboard, i.e. validates its KQG configuration without querying any data or uploading SLOs.
+type Linting struct {
+	client          dynatrace.ClientInterface
+	eventData       adapter.EventContentAdapter
+	dashboardReader dashboardReaderInterface
+	featureFlags    ff.GetSLIFeatureFlags
+}
+
+// NewLinting creates a new Linting.
+func NewLinting(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, dashboardReader dashboardReaderInterface, flags ff.GetSLIFeatureFlags) *Linting {
+	return &Linting{
+		client:          client,
+		eventData:       eventData,
+		dashboardReader: dashboardReader,
+		featureFlags:    flags,
+	}
+}
+
+// Lint retrieves the dashboard specified by the dashboard property in the same way as for sh.keptn.event.get-sli.triggered events and lints it.
+// It returns an error if the dashboard cannot be retrieved.
+func (l *Linting) Lint(ctx context.Context, dashboardProperty string) (*LintReport, error) {
+	d, err := NewRetrieval(l.client, l.eventData, l.dashboardReader, nil).Retrieve(ctx, dashboardProperty)
+	if err != nil {
+		return nil, err
+	}
+
+	return LintDashboard(ctx, d, l.featureFlags)
+}
+
+// LintDashboard lints the specified dashboard, reporting markdown configuration errors, tile configuration errors, duplicate SLI or display names and unsupported tile types.
+// The tiles are processed exactly as for sh.keptn.event.get-sli.triggered events, but without querying any data, so only failures that do not depend on the data are reported.
+// SLO tiles are not checked for duplicate names, as their SLIs are named after the SLOs, which are only known once these have been queried.
+func LintDashboard(ctx context.Context, d *dynatrace.Dashboard, flags ff.GetSLIFeatureFlags) (*LintReport, error) {
+	end := time.Now().Add(-lintTimeframeOffset)
+	timeframe, err := common.NewTimeframe(end.Add(-lintTimeframeDuration), end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LintReport{
+		DashboardID: d.ID,
+		Problems:    []LintProblem{},
+	}
+
+	pr := newProcessingResult()
+	sections, err := processMarkdownTiles(pr, d.Tiles)
+	if err != nil {
+		report.Problems = append(report.Problems, LintProblem{Kind: MarkdownLintProblemKind, TileIndex: -1, TileType: dynatrace.MarkdownTileType, Message: err.Error()})
+		sections = newMarkdownSections()
+	}
+
+	var lintedResults []lintedResult
+	for _, t := range NewProcessing(newNoQueryClient(nil), nil, nil, *timeframe, nil, flags).processTiles(ctx, d, sections, pr.useTileTimeframes) {
+		report.Problems = append(report.Problems, lintTileResults(t)...)
+
+		// the SLI names of SLO tiles are the names of the underlying SLOs, which are only known once these have been queried
+		if t.tile.TileType == dynatrace.SLOTileType {
+			continue
+		}
+
+		for _, r := range t.results {
+			lintedResults = append(lintedResults, lintedResult{tileResults: t, result: r})
+		}
+	}
+
+	if !flags.SkipCheckDuplicateSLIAndDisplayNames() {
+		report.Problems = append(report.Problems, findDuplicateNames(lintedResults)...)
+	}
+
+	return report, nil
+}
+
+const (
+	// lintTimeframeDuration is the duration of the timeframe used for linting, which only affects tile timeframes as no data is queried.
+	lintTimeframeDuration = 5 * time.Minute
+
+	// lintTimeframeOffset is how long ago the timeframe used for linting ends, so that processing never waits for data to become available.
+	lintTimeframeOffset = time.Hour
+)
+
+// lintedResult is a result produced by a tile while linting.
+type lintedResult struct {
+	tileResults
+	result result.SLIWithSLO
+}
+
+// lintTileResults reports an unsupported tile type, an SLO tile without entities or the distinct messages of the tile's failed results.
+// Failures caused by querying being disabled are not reported.
+func lintTileResults(t tileResults) []LintProblem {
+	if !t.supported {
+		return []LintProblem{newTileLintProblem(UnsupportedTileTypeLintProblemKind, t, fmt.Sprintf("tile type %s is not supported and will be ignored", t.tile.TileType))}
+	}
+
+	if t.tile.TileType == dynatrace.SLOTileType && len(t.tile.AssignedEntities) == 0 {
+		return []LintProblem{newTileLintProblem(TileConfigurationLintProblemKind, t, "SLO tile contains no SLO IDs")}
+	}
+
+	var problems []LintProblem
+	reportedMessages := make(map[string]struct{})
+	for _, r := range t.results {
+		sliResult := r.SLIResult()
+		if sliResult.IndicatorResult != result.IndicatorResultFailed || sliResult.Diagnostics.ErrorCode == errorCodeQueryingDisabled {
+			continue
+		}
+
+		if _, ok := reportedMessages[sliResult.Message]; ok {
+			continue
+		}
+
+		reportedMessages[sliResult.Message] = struct{}{}
+		problems = append(problems, newTileLintProblem(TileConfigurationLintProblemKind, t, sliResult.Message))
+	}
+	return problems
+}
+
+func newTileLintProblem(kind LintProblemKind, t tileResults, message string) LintProblem {
+	return LintProblem{
+		Kind:      kind,
+		TileIndex: t.tileIndex,
+		TileName:  t.tile.Name,
+		TileType:  t.tile.TileType,
+		Message:   message,
+	}
+}
+
+// findDuplicateNames reports each result whose SLI name or display name is also used by another result, using the same checks as for sh.keptn.event.get-sli.triggered events.
+func findDuplicateNames(lintedResults []lintedResult) []LintProblem {
+	results := make([]result.SLIWithSLO, 0, len(lintedResults))
+	for _, r := range lintedResults {
+		results = append(results, r.result)
+	}
+
+	sliNameChecker := newDuplicateSLINameChecker(results)
+	displayNameChecker := newDuplicateDisplayNameChecker(results)
+
+	var problems []LintProblem
+	for _, r := range lintedResults {
+		if sliNameChecker.hasDuplicateName(r.result) {
+			problems = append(problems, newTileLintProblem(DuplicateSLINameLintProblemKind, r.tileResults, fmt.Sprintf("duplicate SLI name '%s'", r.result.SLIResult().Metric)))
+		}
+
+		if displayNameChecker.hasDuplicateDisplayName(r.result) {
+			problems = append(problems, newTileLintProblem(DuplicateDisplayNameLintProblemKind, r.tileResults, fmt.Sprintf("duplicate display name '%s'", r.result.SLODefinition().DisplayName)))
+		}
+	}
+	return problems
+}
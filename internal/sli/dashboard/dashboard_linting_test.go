@@ -0,0 +1,200 @@
+package dashboard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+)
+
+func TestLintDashboard(t *testing.T) {
+	tests := []struct {
+		name             string
+		tiles            []dynatrace.Tile
+		featureFlags     ff.GetSLIFeatureFlags
+		expectedProblems []LintProblem
+	}{
+		{
+			name: "valid dashboard has no problems",
+			tiles: []dynatrace.Tile{
+				createLintMarkdownTile("KQG.Total.Pass=90%;KQG.Total.Warning=75%"),
+				{Name: "Overview", TileType: dynatrace.HeaderTileType},
+				createLintDataExplorerTile("Response time; sli=response_time; pass=<100", "resolution=Inf&(builtin:service.response.time)"),
+				createLintDataExplorerTile("Not evaluated", "resolution=Inf&(builtin:service.errors.total.rate)"),
+				{Name: "Problems", TileType: dynatrace.OpenProblemsTileType},
+				{Name: "Synthetic", TileType: dynatrace.SyntheticTestsTileType, AssignedEntities: []string{"SYNTHETIC_TEST-1"}},
+			},
+			expectedProblems: []LintProblem{},
+		},
+		{
+			name: "failures caused by querying being disabled are not reported",
+			tiles: []dynatrace.Tile{
+				{Name: "Hosts", TileType: dynatrace.HostsTileType},
+				{Name: "Services", TileType: dynatrace.ServicesTileType},
+				{Name: "SLO", TileType: dynatrace.SLOTileType, AssignedEntities: []string{"7d07efde-b714-3e6e-ad95-08490e2540c4"}},
+				{Name: "User sessions", TileType: dynatrace.USQLTileType, CustomName: "Sessions;sli=sessions", Query: "SELECT count(*) FROM usersession", Type: dynatrace.SingleValueVisualizationType},
+				{Name: "Logs", TileType: dynatrace.DQLTileType, CustomName: "Logs;sli=logs", Query: "fetch logs | summarize count()"},
+				{Name: "Synthetic monitors", TileType: dynatrace.SyntheticTestsTileType},
+			},
+			expectedProblems: []LintProblem{},
+		},
+		{
+			name: "invalid markdown is reported",
+			tiles: []dynatrace.Tile{
+				createLintMarkdownTile("KQG.Tile.Timeframes=maybe"),
+			},
+			expectedProblems: []LintProblem{
+				{Kind: MarkdownLintProblemKind, TileIndex: -1, TileType: dynatrace.MarkdownTileType, Message: "markdown tile parsing error: invalid value for 'kqg.tile.timeframes': maybe"},
+			},
+		},
+		{
+			name: "invalid SLO definition and other tile errors are reported",
+			tiles: []dynatrace.Tile{
+				createLintDataExplorerTile("Response time; sli=response_time; pass=<<100", ""),
+			},
+			expectedProblems: []LintProblem{
+				{Kind: TileConfigurationLintProblemKind, TileIndex: 0, TileName: "Response time; sli=response_time; pass=<<100", TileType: dynatrace.DataExplorerTileType, Message: "error validating Data Explorer tile: error parsing SLO definition: invalid definition for 'pass': invalid criteria value(s): <<100; Data Explorer tile has no metric expressions"},
+			},
+		},
+		{
+			name: "invalid thresholds are reported",
+			tiles: []dynatrace.Tile{
+				createLintDataExplorerTileWithThresholds("Response time; sli=response_time", "resolution=Inf&(builtin:service.response.time)"),
+			},
+			expectedProblems: []LintProblem{
+				{Kind: TileConfigurationLintProblemKind, TileIndex: 0, TileName: "Response time; sli=response_time", TileType: dynatrace.DataExplorerTileType, Message: "error validating Data Explorer tile: error parsing thresholds: missing value at position 3"},
+			},
+		},
+		{
+			name: "unsupported tile timeframes are reported",
+			tiles: []dynatrace.Tile{
+				createLintMarkdownTile("KQG.Tile.Timeframes=true"),
+				createLintDataExplorerTileWithTimeframe("Response time; sli=response_time", "resolution=Inf&(builtin:service.response.time)", "today"),
+			},
+			expectedProblems: []LintProblem{
+				{Kind: TileConfigurationLintProblemKind, TileIndex: 1, TileName: "Response time; sli=response_time", TileType: dynatrace.DataExplorerTileType, Message: "could not determine timeframe of tile 'Response time; sli=response_time': could not parse tile timeframe: unsupported relative time 'today'"},
+			},
+		},
+		{
+			name: "custom charting tiles with multiple series are reported",
+			tiles: []dynatrace.Tile{
+				{
+					Name:     "Custom chart",
+					TileType: dynatrace.CustomChartingTileType,
+					FilterConfig: &dynatrace.FilterConfig{
+						CustomName:  "Response time; sli=response_time",
+						ChartConfig: dynatrace.ChartConfig{Series: []dynatrace.Series{{Metric: "builtin:service.response.time"}, {Metric: "builtin:service.errors.total.rate"}}},
+					},
+				},
+			},
+			expectedProblems: []LintProblem{
+				{Kind: TileConfigurationLintProblemKind, TileIndex: 0, TileName: "Custom chart", TileType: dynatrace.CustomChartingTileType, Message: "Custom charting tile must have exactly one series"},
+			},
+		},
+		{
+			name: "SLO tiles without entities are reported but synthetic tiles without entities are not",
+			tiles: []dynatrace.Tile{
+				{Name: "Static SLO", TileType: dynatrace.SLOTileType},
+				{Name: "Synthetic", TileType: dynatrace.SyntheticHTTPMonitorTileType},
+			},
+			expectedProblems: []LintProblem{
+				{Kind: TileConfigurationLintProblemKind, TileIndex: 0, TileName: "Static SLO", TileType: dynatrace.SLOTileType, Message: "SLO tile contains no SLO IDs"},
+			},
+		},
+		{
+			name: "unsupported tile types are reported",
+			tiles: []dynatrace.Tile{
+				{Name: "Service health", TileType: "SERVICE_VERSATILE"},
+			},
+			expectedProblems: []LintProblem{
+				{Kind: UnsupportedTileTypeLintProblemKind, TileIndex: 0, TileName: "Service health", TileType: "SERVICE_VERSATILE", Message: "tile type SERVICE_VERSATILE is not supported and will be ignored"},
+			},
+		},
+		{
+			name: "duplicate SLI and display names are reported",
+			tiles: []dynatrace.Tile{
+				createLintDataExplorerTile("Response time; sli=response_time", "resolution=Inf&(builtin:service.response.time)"),
+				createLintDataExplorerTile("Response time; sli=response_time", "resolution=Inf&(builtin:service.response.time)"),
+				{Name: "Problems", TileType: dynatrace.OpenProblemsTileType},
+			},
+			expectedProblems: []LintProblem{
+				{Kind: DuplicateSLINameLintProblemKind, TileIndex: 0, TileName: "Response time; sli=response_time", TileType: dynatrace.DataExplorerTileType, Message: "duplicate SLI name 'response_time'"},
+				{Kind: DuplicateDisplayNameLintProblemKind, TileIndex: 0, TileName: "Response time; sli=response_time", TileType: dynatrace.DataExplorerTileType, Message: "duplicate display name 'Response time'"},
+				{Kind: DuplicateSLINameLintProblemKind, TileIndex: 1, TileName: "Response time; sli=response_time", TileType: dynatrace.DataExplorerTileType, Message: "duplicate SLI name 'response_time'"},
+				{Kind: DuplicateDisplayNameLintProblemKind, TileIndex: 1, TileName: "Response time; sli=response_time", TileType: dynatrace.DataExplorerTileType, Message: "duplicate display name 'Response time'"},
+			},
+		},
+		{
+			name: "duplicate names are not reported if check is skipped",
+			tiles: []dynatrace.Tile{
+				createLintDataExplorerTile("Response time; sli=response_time", "resolution=Inf&(builtin:service.response.time)"),
+				createLintDataExplorerTile("Response time; sli=response_time", "resolution=Inf&(builtin:service.response.time)"),
+			},
+			featureFlags:     ff.NewGetSLIFeatureFlags(false, false, true),
+			expectedProblems: []LintProblem{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := LintDashboard(context.Background(), &dynatrace.Dashboard{ID: "12345678-1111-4444-8888-123456789012", Tiles: tt.tiles}, tt.featureFlags)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, "12345678-1111-4444-8888-123456789012", report.DashboardID)
+			assert.Equal(t, tt.expectedProblems, report.Problems)
+			assert.Equal(t, len(tt.expectedProblems) > 0, report.HasProblems())
+		})
+	}
+}
+
+func createLintMarkdownTile(markdown string) dynatrace.Tile {
+	return dynatrace.Tile{
+		Name:     "Markdown",
+		TileType: dynatrace.MarkdownTileType,
+		Markdown: markdown,
+	}
+}
+
+func createLintDataExplorerTile(name string, metricExpression string) dynatrace.Tile {
+	tile := dynatrace.Tile{
+		Name:     name,
+		TileType: dynatrace.DataExplorerTileType,
+		Queries:  []dynatrace.DataExplorerQuery{{ID: "A", Enabled: true}},
+	}
+
+	if metricExpression != "" {
+		tile.MetricExpressions = []string{metricExpression}
+	}
+	return tile
+}
+
+func createLintDataExplorerTileWithTimeframe(name string, metricExpression string, timeframe string) dynatrace.Tile {
+	tile := createLintDataExplorerTile(name, metricExpression)
+	tile.TileFilter.Timeframe = timeframe
+	return tile
+}
+
+func createLintDataExplorerTileWithThresholds(name string, metricExpression string) dynatrace.Tile {
+	tile := createLintDataExplorerTile(name, metricExpression)
+	tile.VisualConfig = &dynatrace.VisualizationConfiguration{
+		Thresholds: []dynatrace.VisualizationThreshold{
+			{
+				Visible: true,
+				Rules: []dynatrace.VisualizationThresholdRule{
+					{Value: floatPointer(0), Color: "#7dc540"},
+					{Value: floatPointer(50), Color: "#f5d30f"},
+					{Color: "#dc172a"},
+				},
+			},
+		},
+	}
+	return tile
+}
+
+func floatPointer(f float64) *float64 {
+	return &f
+}
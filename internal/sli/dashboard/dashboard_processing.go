@@ -1,33 +1,40 @@
 package dashboard
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+	"sort"
 	"strings"
 
 	keptncommon "github.com/keptn/go-utils/pkg/lib"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
 )
 
-// sloUploaderInterface can write SLOs.
+// sloUploaderInterface can read and write SLOs.
 type sloUploaderInterface interface {
 
+	// GetSLOs gets the SLOs stored for exactly the specified project, stage and service.
+	GetSLOs(ctx context.Context, project string, stage string, service string) (*keptncommon.ServiceLevelObjectives, error)
+
 	// UploadSLOs uploads the SLOs for the specified project, stage and service.
 	UploadSLOs(ctx context.Context, project string, stage string, service string, slos *keptncommon.ServiceLevelObjectives) error
 }
 
 // processingResult collects the results of dashboard processing.
 type processingResult struct {
-	totalScore keptncommon.SLOScore
-	comparison keptncommon.SLOComparison
-	results    []result.SLIWithSLO
+	totalScore        keptncommon.SLOScore
+	comparison        keptncommon.SLOComparison
+	useTileTimeframes bool
+	results           []result.SLIWithSLO
 }
 
 func newProcessingResult() *processingResult {
@@ -41,6 +48,7 @@ func newProcessingResult() *processingResult {
 func (pr *processingResult) applyMarkdownResult(markdownParsingResult markdownParsingResult) {
 	pr.totalScore = markdownParsingResult.totalScore
 	pr.comparison = markdownParsingResult.comparison
+	pr.useTileTimeframes = markdownParsingResult.useTileTimeframes
 }
 
 func (pr *processingResult) addSLIWithSLOs(results []result.SLIWithSLO) {
@@ -121,6 +129,7 @@ type Processing struct {
 	timeframe     common.Timeframe
 	sloUploader   sloUploaderInterface
 	featureFlags  ff.GetSLIFeatureFlags
+	slosUploaded  bool
 }
 
 // NewProcessing will create a new Processing
@@ -142,7 +151,7 @@ func (p *Processing) Process(ctx context.Context, dashboard *dynatrace.Dashboard
 		return nil, NewProcessingError(err)
 	}
 
-	err = p.sloUploader.UploadSLOs(ctx, p.eventData.GetProject(), p.eventData.GetStage(), p.eventData.GetService(), processingResult.getSLOs(p.featureFlags))
+	err = p.uploadSLOsIfChanged(ctx, processingResult.getSLOs(p.featureFlags))
 	if err != nil {
 		return nil, NewUploadSLOsError(err)
 	}
@@ -154,49 +163,250 @@ func (p *Processing) Process(ctx context.Context, dashboard *dynatrace.Dashboard
 	return checkForDuplicatesInResults(processingResult.getResults()), nil
 }
 
+// SLOsUploaded returns true if the SLOs were uploaded by the last call to Process, or false if they were unchanged and thus not uploaded.
+func (p *Processing) SLOsUploaded() bool {
+	return p.slosUploaded
+}
+
+// uploadSLOsIfChanged uploads the SLOs unless they are semantically identical to the stored ones, thereby avoiding unnecessary commits to the Keptn configuration repository.
+func (p *Processing) uploadSLOsIfChanged(ctx context.Context, slos *keptncommon.ServiceLevelObjectives) error {
+	p.slosUploaded = false
+
+	storedSLOs, err := p.sloUploader.GetSLOs(ctx, p.eventData.GetProject(), p.eventData.GetStage(), p.eventData.GetService())
+	if err != nil {
+		log.WithError(err).Debug("Could not get stored SLOs, uploading SLOs")
+	} else if areSLOsEqual(storedSLOs, slos) {
+		log.Debug("SLOs are unchanged, skipping upload")
+		return nil
+	}
+
+	err = p.sloUploader.UploadSLOs(ctx, p.eventData.GetProject(), p.eventData.GetStage(), p.eventData.GetService(), slos)
+	if err != nil {
+		return err
+	}
+
+	p.slosUploaded = true
+	return nil
+}
+
+// areSLOsEqual returns true if both SLOs would be serialized to the same YAML, i.e. if they are semantically identical.
+func areSLOsEqual(a *keptncommon.ServiceLevelObjectives, b *keptncommon.ServiceLevelObjectives) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	aYAML, err := yaml.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	bYAML, err := yaml.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(aYAML, bYAML)
+}
+
 func (p *Processing) process(ctx context.Context, dashboard *dynatrace.Dashboard) (*processingResult, error) {
 	log.Debug("Dashboard will be parsed!")
 
 	pr := newProcessingResult()
-	markdownAlreadyProcessed := false
-	for _, tile := range dashboard.Tiles {
+	sections, err := processMarkdownTiles(pr, dashboard.Tiles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range p.processTiles(ctx, dashboard, sections, pr.useTileTimeframes) {
+		pr.addSLIWithSLOs(t.results)
+	}
+
+	return pr, nil
+}
+
+// tileResults are the results of processing a single dashboard tile.
+type tileResults struct {
+	tileIndex int
+	tile      dynatrace.Tile
+
+	// supported is false if the tile type is not supported and the tile was thus ignored.
+	supported bool
+	results   []result.SLIWithSLO
+}
+
+// processTiles processes all non-markdown tiles of the specified dashboard using the SLO definition defaults of their markdown sections, returning the results of each tile in tile order.
+// If tile timeframes are used, tiles whose timeframe cannot be determined fail.
+func (p *Processing) processTiles(ctx context.Context, dashboard *dynatrace.Dashboard, sections *markdownSections, useTileTimeframes bool) []tileResults {
+	var allTileResults []tileResults
+	for i, tile := range dashboard.Tiles {
 		if tile.TileType == dynatrace.MarkdownTileType {
-			res, err := NewMarkdownTileProcessing().TryProcess(&tile)
+			continue
+		}
+
+		tr := tileResults{tileIndex: i, tile: tile}
+		timeframe := p.timeframe
+		if useTileTimeframes {
+			tileTimeframe, err := getTileTimeframe(p.timeframe, &tile)
 			if err != nil {
-				return nil, fmt.Errorf("markdown tile parsing error: %w", err)
+				tr.results, tr.supported = p.failTile(ctx, tile, dashboard.GetFilter(), sections.getSLODefaultsForTile(i), fmt.Sprintf("could not determine timeframe of tile '%s': %v", tile.Name, err))
+				allTileResults = append(allTileResults, tr)
+				continue
 			}
-			if res != nil {
-				if markdownAlreadyProcessed {
-					return nil, fmt.Errorf("only one markdown tile allowed for KQG configuration")
-				}
+			timeframe = *tileTimeframe
+		}
 
-				pr.applyMarkdownResult(*res)
-				markdownAlreadyProcessed = true
-			}
+		results, supported := p.processTile(ctx, tile, dashboard.GetFilter(), sections.getSLODefaultsForTile(i), timeframe)
+		tr.results, tr.supported = withDefaultTimeframe(results, timeframe), supported
+		allTileResults = append(allTileResults, tr)
+	}
+
+	return allTileResults
+}
+
+// failTile returns a failed result with the specified message for each SLI the tile produces when processed without querying any data.
+// The SLI names and SLO definitions only match those of successful processing if they do not depend on queried data:
+// SLO tiles fall back to names of the form slo_<id> as the SLO names are not retrieved, and tiles producing an SLI per dimension value or monitor,
+// e.g. multi-dimension USQL tiles or synthetic tiles without assigned monitors, produce a single failed result instead.
+// It returns false if the tile type is not supported.
+func (p *Processing) failTile(ctx context.Context, tile dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter, sloDefaults sloDefinitionDefaults, message string) ([]result.SLIWithSLO, bool) {
+	noQueryProcessing := *p
+	noQueryProcessing.client = newNoQueryClient(p.client.Credentials())
+
+	results, supported := noQueryProcessing.processTile(ctx, tile, dashboardFilter, sloDefaults, p.timeframe)
+
+	var failedResults []result.SLIWithSLO
+	for _, r := range results {
+		failedResults = append(failedResults, result.NewSLIWithSLO(
+			result.NewFailedSLIResult(r.SLIResult().Metric, message, result.ErrorCodeInvalidDefinition),
+			r.SLODefinition(),
+		))
+	}
+	return failedResults, supported
+}
+
+// withDefaultTimeframe sets the specified timeframe in the diagnostics of any results that do not already include one.
+func withDefaultTimeframe(results []result.SLIWithSLO, timeframe common.Timeframe) []result.SLIWithSLO {
+	timeframedResults := make([]result.SLIWithSLO, 0, len(results))
+	for _, r := range results {
+		timeframedResults = append(timeframedResults, result.NewSLIWithSLO(r.SLIResult().WithDefaultTimeframe(timeframe), r.SLODefinition()))
+	}
+	return timeframedResults
+}
+
+// processMarkdownTiles processes the markdown tiles of a dashboard in reading order, i.e. from top to bottom and left to right, or in section order for notebooks, where all tiles have the same bounds.
+// The first markdown tile containing a KQG configuration may set the total score and comparison, while all such markdown tiles set the SLO definition defaults for the tiles following them.
+func processMarkdownTiles(pr *processingResult, tiles []dynatrace.Tile) (*markdownSections, error) {
+	sections := newMarkdownSections()
+	sloDefaults := newSLODefinitionDefaults()
+	for _, i := range getTileIndicesInReadingOrder(tiles) {
+		tile := tiles[i]
+		if tile.TileType != dynatrace.MarkdownTileType {
+			sections.setSLODefaultsForTile(i, sloDefaults)
+			continue
+		}
+
+		res, err := NewMarkdownTileProcessing().TryProcess(&tile)
+		if err != nil {
+			return nil, fmt.Errorf("markdown tile parsing error: %w", err)
+		}
+		if res == nil {
 			continue
 		}
-		pr.addSLIWithSLOs(p.processTile(ctx, tile, dashboard.GetFilter()))
+
+		if !sections.configured {
+			pr.applyMarkdownResult(*res)
+		} else if res.configuresScoreOrComparison {
+			return nil, fmt.Errorf("total score and comparison may only be configured in the first markdown tile")
+		} else if res.configuresTileTimeframes {
+			return nil, fmt.Errorf("tile timeframes may only be configured in the first markdown tile")
+		}
+
+		sections.configured = true
+		sloDefaults = res.sloDefaults
 	}
 
-	return pr, nil
+	return sections, nil
+}
+
+// getTileIndicesInReadingOrder returns the indices of the specified tiles ordered from top to bottom and left to right.
+// The sort is stable, so tiles with the same bounds, such as the sections of a notebook, keep their order.
+func getTileIndicesInReadingOrder(tiles []dynatrace.Tile) []int {
+	indices := make([]int, len(tiles))
+	for i := range tiles {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		a := tiles[indices[i]].Bounds
+		b := tiles[indices[j]].Bounds
+		if a.Top != b.Top {
+			return a.Top < b.Top
+		}
+		return a.Left < b.Left
+	})
+	return indices
 }
 
-func (p *Processing) processTile(ctx context.Context, tile dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter) []result.SLIWithSLO {
+// processTile processes the specified tile using the specified timeframe, which is the event's timeframe unless tile timeframes are enabled.
+// It returns false if the tile type is not supported.
+func (p *Processing) processTile(ctx context.Context, tile dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter, sloDefaults sloDefinitionDefaults, timeframe common.Timeframe) ([]result.SLIWithSLO, bool) {
 	switch tile.TileType {
 	case dynatrace.SLOTileType:
-		return NewSLOTileProcessing(p.client, p.timeframe, p.featureFlags).Process(ctx, &tile)
+		results := NewSLOTileProcessing(p.client, timeframe, p.featureFlags, sloDefaults).Process(ctx, &tile)
+		return NewFilter(dashboardFilter, tile.TileFilter).addWarningIfNotApplicable(results), true
 	case dynatrace.OpenProblemsTileType:
-		return NewProblemTileProcessing(p.client, p.timeframe).Process(ctx, &tile, dashboardFilter)
+		return NewProblemTileProcessing(p.client, timeframe, sloDefaults).Process(ctx, &tile, dashboardFilter), true
+	case dynatrace.HostsTileType:
+		return NewHostHealthTileProcessing(p.client, timeframe, sloDefaults).Process(ctx, &tile, dashboardFilter), true
+	case dynatrace.ServicesTileType:
+		return NewServiceHealthTileProcessing(p.client, timeframe, sloDefaults).Process(ctx, &tile, dashboardFilter), true
 	case dynatrace.DataExplorerTileType:
-		return NewDataExplorerTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe, p.featureFlags).Process(ctx, &tile, dashboardFilter)
+		return NewDataExplorerTileProcessing(p.client, p.eventData, p.customFilters, timeframe, p.featureFlags, sloDefaults).Process(ctx, &tile, dashboardFilter), true
 	case dynatrace.CustomChartingTileType:
-		return NewCustomChartingTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe, p.featureFlags).Process(ctx, &tile, dashboardFilter)
+		return NewCustomChartingTileProcessing(p.client, p.eventData, p.customFilters, timeframe, p.featureFlags, sloDefaults).Process(ctx, &tile, dashboardFilter), true
 	case dynatrace.USQLTileType:
-		return NewUSQLTileProcessing(p.client, p.eventData, p.customFilters, p.timeframe, p.featureFlags).Process(ctx, &tile)
+		results := NewUSQLTileProcessing(p.client, p.eventData, p.customFilters, timeframe, p.featureFlags, sloDefaults).Process(ctx, &tile)
+		return NewFilter(dashboardFilter, tile.TileFilter).addWarningIfNotApplicable(results), true
+	case dynatrace.DQLTileType:
+		results := NewDQLTileProcessing(p.client, timeframe, p.featureFlags, sloDefaults).Process(ctx, &tile)
+		return NewFilter(dashboardFilter, tile.TileFilter).addWarningIfNotApplicable(results), true
+	case dynatrace.SyntheticTestsTileType, dynatrace.SyntheticSingleWebCheckTileType, dynatrace.SyntheticHTTPMonitorTileType:
+		return NewSyntheticTileProcessing(p.client, timeframe, p.featureFlags, sloDefaults).Process(ctx, &tile, dashboardFilter), true
+	case dynatrace.MarkdownTileType, dynatrace.HeaderTileType:
+		// markdown tiles are processed beforehand and header tiles are purely informational
+		return nil, true
 	default:
-		// we do not do markdowns (HEADER) or synthetic tests (SYNTHETIC_TESTS)
-		return nil
+		return nil, false
+	}
+}
+
+// markdownSections are the sections of a dashboard, each starting at a markdown tile and applying its SLO definition defaults to the tiles following it.
+type markdownSections struct {
+	// configured is true if a markdown tile containing a KQG configuration was found.
+	configured bool
+
+	// sloDefaults maps the index of each non-markdown tile to the SLO definition defaults of its section.
+	sloDefaults map[int]sloDefinitionDefaults
+}
+
+func newMarkdownSections() *markdownSections {
+	return &markdownSections{
+		sloDefaults: make(map[int]sloDefinitionDefaults),
+	}
+}
+
+func (s *markdownSections) setSLODefaultsForTile(tileIndex int, sloDefaults sloDefinitionDefaults) {
+	s.sloDefaults[tileIndex] = sloDefaults
+}
+
+// getSLODefaultsForTile returns the SLO definition defaults of the section containing the tile with the specified index, or the general defaults if there is none.
+func (s *markdownSections) getSLODefaultsForTile(tileIndex int) sloDefinitionDefaults {
+	sloDefaults, ok := s.sloDefaults[tileIndex]
+	if !ok {
+		return newSLODefinitionDefaults()
 	}
+
+	return sloDefaults
 }
 
 func checkForDuplicatesInResults(results []result.SLIWithSLO) []result.SLIWithSLO {
@@ -218,9 +428,19 @@ func checkForDuplicatesInResults(results []result.SLIWithSLO) []result.SLIWithSL
 }
 
 func addErrorAndFailResult(r result.SLIWithSLO, message string) result.SLIWithSLO {
-	return result.NewFailedSLIWithSLOAndQuery(
+	sliResult := r.SLIResult()
+
+	// the duplicate name replaces any previous cause of failure, so only the remaining diagnostics are kept
+	diagnostics := sliResult.Diagnostics
+	diagnostics.ErrorCode = ""
+
+	return result.NewSLIWithSLO(
+		result.NewFailedSLIResultWithQuery(
+			sliResult.Metric,
+			strings.Join([]string{message, sliResult.Message}, "; "),
+			sliResult.Query,
+			result.ErrorCodeInvalidDefinition,
+		).WithDiagnostics(diagnostics),
 		r.SLODefinition(),
-		r.SLIResult().Query,
-		strings.Join([]string{message, r.SLIResult().Message}, "; "),
 	)
 }
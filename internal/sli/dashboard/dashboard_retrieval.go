@@ -2,29 +2,58 @@ package dashboard
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 )
 
 const queryDashboardProperty = "query"
 
+// tagsDashboardProperty is the dashboard property selecting the dashboard whose keptn_project, keptn_stage and keptn_service tags best match the event.
+const tagsDashboardProperty = "tags"
+
+// resourceDashboardPropertyPrefix is the prefix of dashboard properties referencing a dashboard stored as a Keptn resource, e.g. resource:dynatrace/kqg-dashboard.json.
+const resourceDashboardPropertyPrefix = "resource:"
+
+// notebookDashboardPropertyPrefix is the prefix of dashboard properties referencing a Dynatrace Notebook, e.g. notebook:<id>.
+const notebookDashboardPropertyPrefix = "notebook:"
+
+// dashboardReaderInterface can read dashboards stored as resources.
+type dashboardReaderInterface interface {
+
+	// GetDashboard gets the dashboard resource with the specified URI for the specified project, stage and service, checking first on the service, then stage and then project level.
+	GetDashboard(ctx context.Context, project string, stage string, service string, resourceURI string) (string, error)
+}
+
 type Retrieval struct {
-	client    dynatrace.ClientInterface
-	eventData adapter.EventContentAdapter
+	client          dynatrace.ClientInterface
+	eventData       adapter.EventContentAdapter
+	dashboardReader dashboardReaderInterface
+	cache           *Cache
 }
 
-func NewRetrieval(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter) *Retrieval {
+// NewRetrieval creates a new Retrieval. Dashboards retrieved by ID are cached in the specified cache, which may be nil to disable caching.
+func NewRetrieval(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, dashboardReader dashboardReaderInterface, cache *Cache) *Retrieval {
 	return &Retrieval{
-		client:    client,
-		eventData: eventData,
+		client:          client,
+		eventData:       eventData,
+		dashboardReader: dashboardReader,
+		cache:           cache,
 	}
 }
 
 // Retrieve Depending on the dashboard parameter which is pulled from dynatrace.conf.yaml:dashboard this method either
 //   - query:        queries all dashboards on the Dynatrace Tenant and returns the one that matches project/service/stage, or
+//   - tags:         queries all dashboards tagged with the project and returns the one whose tags best match stage/service, or
 //   - dashboard-ID: if this is a valid dashboard ID it will query the dashboard with this ID, e.g: ddb6a571-4bda-4e8b-a9c0-4a3e02c2e14a, or
+//   - resource:URI: it will read the dashboard JSON from the Keptn resource with this URI, checking first on service, then stage and then project level, or
+//   - notebook:ID:  it will load the Dynatrace Notebook with this ID and convert its DQL and markdown sections to tiles.
 //
 // It returns a parsed Dynatrace Dashboard and the actual dashboard ID in case we queried a dashboard.
 func (r *Retrieval) Retrieve(ctx context.Context, dashboardProperty string) (*dynatrace.Dashboard, error) {
@@ -37,12 +66,48 @@ func (r *Retrieval) Retrieve(ctx context.Context, dashboardProperty string) (*dy
 }
 
 func (r *Retrieval) retrieve(ctx context.Context, dashboardProperty string) (*dynatrace.Dashboard, error) {
+	if IsResourceProperty(dashboardProperty) {
+		return r.retrieveResource(ctx, strings.TrimPrefix(dashboardProperty, resourceDashboardPropertyPrefix))
+	}
+
+	if IsNotebookProperty(dashboardProperty) {
+		return r.retrieveNotebook(ctx, strings.TrimPrefix(dashboardProperty, notebookDashboardPropertyPrefix))
+	}
+
+	if dashboardProperty == tagsDashboardProperty {
+		return r.findDynatraceDashboardByTags(ctx)
+	}
+
 	dashboardID, err := r.convertDashboardPropertyToID(ctx, dashboardProperty)
 	if err != nil {
 		return nil, err
 	}
 
-	return dynatrace.NewDashboardsClient(r.client).GetByID(ctx, dashboardID)
+	return r.getDashboardByID(ctx, dashboardID)
+}
+
+// getDashboardByID gets the dashboard with the specified ID from the Dynatrace tenant, reusing the cached parsed dashboard if its last-modified time is unchanged.
+func (r *Retrieval) getDashboardByID(ctx context.Context, dashboardID string) (*dynatrace.Dashboard, error) {
+	tenant := r.client.Credentials().GetTenant()
+
+	var lastModified int64
+	cachedDashboard, ok := r.cache.get(tenant, dashboardID)
+	if ok {
+		lastModified = cachedDashboard.DashboardMetadata.LastModified
+	}
+
+	dashboard, err := dynatrace.NewDashboardsClient(r.client).GetByIDIfModified(ctx, dashboardID, lastModified)
+	if err != nil {
+		return nil, err
+	}
+
+	if dashboard == nil {
+		log.WithField("dashboardID", dashboardID).Debug("Using cached dashboard as it is unmodified")
+		return cachedDashboard, nil
+	}
+
+	r.cache.put(tenant, dashboardID, dashboard)
+	return dashboard, nil
 }
 
 func (r *Retrieval) convertDashboardPropertyToID(ctx context.Context, dashboardProperty string) (string, error) {
@@ -66,3 +131,93 @@ func (r *Retrieval) findDynatraceDashboard(ctx context.Context) (string, error)
 
 	return dashboardList.SearchForDashboardMatching(r.eventData.GetProject(), r.eventData.GetStage(), r.eventData.GetService())
 }
+
+// findDynatraceDashboardByTags retrieves all dashboards tagged with the event's project and selects the one whose stage and service tags match best.
+// As the dashboard list does not include tags, each candidate dashboard is retrieved in full.
+func (r *Retrieval) findDynatraceDashboardByTags(ctx context.Context) (*dynatrace.Dashboard, error) {
+	dashboardList, err := dynatrace.NewDashboardsClient(r.client).GetByTag(ctx, dynatrace.NewKeptnProjectDashboardTag(r.eventData.GetProject()))
+	if err != nil {
+		return nil, fmt.Errorf("could not get dashboard list: %w", err)
+	}
+
+	dashboards := make([]*dynatrace.Dashboard, 0, len(dashboardList.Dashboards))
+	for _, dashboardStub := range dashboardList.Dashboards {
+		dashboard, err := r.getDashboardByID(ctx, dashboardStub.ID)
+		if err != nil {
+			return nil, err
+		}
+		dashboards = append(dashboards, dashboard)
+	}
+
+	return dynatrace.SearchForDashboardMatchingTags(dashboards, r.eventData.GetProject(), r.eventData.GetStage(), r.eventData.GetService())
+}
+
+func (r *Retrieval) retrieveResource(ctx context.Context, resourceURI string) (*dynatrace.Dashboard, error) {
+	if resourceURI == "" {
+		return nil, fmt.Errorf("invalid 'dashboard' property - resource URI should not be empty")
+	}
+
+	resource, err := r.dashboardReader.GetDashboard(ctx, r.eventData.GetProject(), r.eventData.GetStage(), r.eventData.GetService(), resourceURI)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &dynatrace.Dashboard{}
+	err = json.Unmarshal([]byte(resource), dashboard)
+	if err != nil {
+		return nil, common.NewUnmarshalJSONError("Dynatrace dashboard", err)
+	}
+
+	return dashboard, nil
+}
+
+func (r *Retrieval) retrieveNotebook(ctx context.Context, notebookID string) (*dynatrace.Dashboard, error) {
+	if notebookID == "" {
+		return nil, fmt.Errorf("invalid 'dashboard' property - notebook ID should not be empty")
+	}
+
+	notebook, err := dynatrace.NewDocumentsClient(r.client).GetNotebookByID(ctx, notebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDashboardFromNotebook(notebookID, notebook), nil
+}
+
+// IsResourceProperty returns true if the dashboard property references a dashboard stored as a Keptn resource, i.e. has the form resource:<uri>.
+func IsResourceProperty(dashboardProperty string) bool {
+	return strings.HasPrefix(dashboardProperty, resourceDashboardPropertyPrefix)
+}
+
+// IsNotebookProperty returns true if the dashboard property references a Dynatrace Notebook, i.e. has the form notebook:<id>.
+func IsNotebookProperty(dashboardProperty string) bool {
+	return strings.HasPrefix(dashboardProperty, notebookDashboardPropertyPrefix)
+}
+
+// newDashboardFromNotebook converts the DQL and markdown sections of a Dynatrace Notebook into dashboard tiles so that they can be processed like a dashboard.
+// The title of a DQL section is used as the tile's custom name and thus carries the SLO definition.
+func newDashboardFromNotebook(notebookID string, notebook *dynatrace.Notebook) *dynatrace.Dashboard {
+	tiles := make([]dynatrace.Tile, 0, len(notebook.Sections))
+	for _, section := range notebook.Sections {
+		switch section.Type {
+		case dynatrace.DQLNotebookSectionType:
+			tiles = append(tiles, dynatrace.Tile{
+				Name:       section.Title,
+				TileType:   dynatrace.DQLTileType,
+				CustomName: section.Title,
+				Query:      section.GetQuery(),
+			})
+		case dynatrace.MarkdownNotebookSectionType:
+			tiles = append(tiles, dynatrace.Tile{
+				Name:     "Markdown",
+				TileType: dynatrace.MarkdownTileType,
+				Markdown: section.Markdown,
+			})
+		}
+	}
+
+	return &dynatrace.Dashboard{
+		ID:    notebookID,
+		Tiles: tiles,
+	}
+}
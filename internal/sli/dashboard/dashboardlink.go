@@ -40,3 +40,21 @@ func (dashboardLink *DashboardLink) String() string {
 		common.TimestampToUnixMillisecondsString(dashboardLink.timeframe.End()),
 		managementZone)
 }
+
+// NotebookLink is a link to a Dynatrace Notebook.
+type NotebookLink struct {
+	apiURL     string
+	notebookID string
+}
+
+// NewNotebookLink creates a new NotebookLink.
+func NewNotebookLink(apiURL string, notebookID string) *NotebookLink {
+	return &NotebookLink{
+		apiURL:     apiURL,
+		notebookID: notebookID,
+	}
+}
+
+func (notebookLink *NotebookLink) String() string {
+	return fmt.Sprintf("%s/ui/apps/dynatrace.notebooks/notebook/%s", notebookLink.apiURL, notebookLink.notebookID)
+}
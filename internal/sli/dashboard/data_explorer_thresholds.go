@@ -142,6 +142,28 @@ func (tc *thresholdConfiguration) createPassAndWarningProvider() (passAndWarning
 	return nil, &invalidThresholdTypeSequenceError{thresholdTypes: tc.thresholdTypeConfiguration()}
 }
 
+// createRelativePassAndWarningProvider creates a pass and warning provider interpreting the threshold values as percentage changes relative to previous evaluations.
+// The boundary of the pass range furthest from the fail range is ignored so that improvements always pass.
+func (tc *thresholdConfiguration) createRelativePassAndWarningProvider() (passAndWarningProvider, error) {
+	provider, err := tc.createPassAndWarningProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := provider.(type) {
+	case *passWarnFailThresholdConfiguration:
+		return &relativeThresholdConfiguration{operator: "<", passValue: c.warnValue, warnValue: &c.failValue}, nil
+	case *passFailThresholdConfiguration:
+		return &relativeThresholdConfiguration{operator: "<", passValue: c.failValue}, nil
+	case *failWarnPassThresholdConfiguration:
+		return &relativeThresholdConfiguration{operator: ">=", passValue: c.passValue, warnValue: &c.warnValue}, nil
+	case *failPassThresholdConfiguration:
+		return &relativeThresholdConfiguration{operator: ">=", passValue: c.passValue}, nil
+	default:
+		return nil, fmt.Errorf("unexpected threshold configuration %T", provider)
+	}
+}
+
 type threshold struct {
 	thresholdType thresholdType
 	value         float64
@@ -198,8 +220,9 @@ func (err *invalidThresholdTypeSequenceError) Error() string {
 }
 
 // tryGetThresholdPassAndWarningProvider tries to get pass and warning criteria defined using the thresholds placed on a Data Explorer tile.
+// If relative is true, the threshold values are interpreted as percentage changes and relative criteria such as <+10% are provided instead of absolute ones.
 // It returns either a pass and warning provider and no error (conversion succeeded), nil for the provider and no error (no thresholds set), or nil for the provider and an error (conversion failed).
-func tryGetThresholdPassAndWarningProvider(tile *dynatrace.Tile) (passAndWarningProvider, error) {
+func tryGetThresholdPassAndWarningProvider(tile *dynatrace.Tile, relative bool) (passAndWarningProvider, error) {
 	thresholdRules, err := getThresholdRulesFromTile(tile)
 	if err != nil {
 		return nil, err
@@ -214,6 +237,10 @@ func tryGetThresholdPassAndWarningProvider(tile *dynatrace.Tile) (passAndWarning
 		return nil, err
 	}
 
+	if relative {
+		return thresholdConfiguration.createRelativePassAndWarningProvider()
+	}
+
 	return thresholdConfiguration.createPassAndWarningProvider()
 }
 
@@ -470,3 +497,34 @@ func (c *failPassThresholdConfiguration) getPass() result.SLOCriteriaList {
 func (c *failPassThresholdConfiguration) getWarning() result.SLOCriteriaList {
 	return nil
 }
+
+// relativeThresholdConfiguration provides relative criteria, e.g. <+10%, against previous evaluations.
+type relativeThresholdConfiguration struct {
+	operator  string
+	passValue float64
+	warnValue *float64
+}
+
+func (c *relativeThresholdConfiguration) getPass() result.SLOCriteriaList {
+	return result.SLOCriteriaList{
+		{
+			Criteria: []string{
+				fmt.Sprintf("%s%+f%%", c.operator, c.passValue),
+			},
+		},
+	}
+}
+
+func (c *relativeThresholdConfiguration) getWarning() result.SLOCriteriaList {
+	if c.warnValue == nil {
+		return nil
+	}
+
+	return result.SLOCriteriaList{
+		{
+			Criteria: []string{
+				fmt.Sprintf("%s%+f%%", c.operator, *c.warnValue),
+			},
+		},
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+	"regexp"
 	"strings"
 
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
@@ -13,6 +13,7 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
 )
@@ -24,32 +25,38 @@ type DataExplorerTileProcessing struct {
 	customFilters []*keptnv2.SLIFilter
 	timeframe     common.Timeframe
 	featureFlags  ff.GetSLIFeatureFlags
+	sloDefaults   sloDefinitionDefaults
 }
 
 // NewDataExplorerTileProcessing creates a new DataExplorerTileProcessing.
-func NewDataExplorerTileProcessing(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, customFilters []*keptnv2.SLIFilter, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags) *DataExplorerTileProcessing {
+func NewDataExplorerTileProcessing(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, customFilters []*keptnv2.SLIFilter, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags, sloDefaults sloDefinitionDefaults) *DataExplorerTileProcessing {
 	return &DataExplorerTileProcessing{
 		client:        client,
 		eventData:     eventData,
 		customFilters: customFilters,
 		timeframe:     timeframe,
 		featureFlags:  flags,
+		sloDefaults:   sloDefaults,
 	}
 }
 
 // Process processes the specified Data Explorer dashboard tile.
 func (p *DataExplorerTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter) []result.SLIWithSLO {
-	validatedDataExplorerTile, err := newDataExplorerTileValidator(tile, dashboardFilter, p.featureFlags).tryValidate()
+	validatedDataExplorerTile, err := newDataExplorerTileValidator(tile, dashboardFilter, p.featureFlags, p.sloDefaults).tryValidate()
 	var validationErr *dataExplorerTileValidationError
 	if errors.As(err, &validationErr) {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(validationErr.sloDefinition, err.Error())}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(validationErr.sloDefinition, err.Error(), result.ErrorCodeInvalidDefinition)}
 	}
 
 	if validatedDataExplorerTile == nil {
 		return []result.SLIWithSLO{}
 	}
 
-	return p.createMetricsQueryProcessing(validatedDataExplorerTile).Process(ctx, validatedDataExplorerTile.sloDefinition, validatedDataExplorerTile.query, p.timeframe)
+	results := p.createMetricsQueryProcessing(validatedDataExplorerTile).Process(ctx, validatedDataExplorerTile.sloDefinition, validatedDataExplorerTile.query, p.timeframe)
+	if !validatedDataExplorerTile.tagsApplied {
+		return addWarning(results, tagsNotAppliedWarning)
+	}
+	return results
 }
 
 func (p *DataExplorerTileProcessing) createMetricsQueryProcessing(validatedTile *validatedDataExplorerTile) *MetricsQueryProcessing {
@@ -77,18 +84,20 @@ type dataExplorerTileValidator struct {
 	tile            *dynatrace.Tile
 	dashboardFilter *dynatrace.DashboardFilter
 	featureFlags    ff.GetSLIFeatureFlags
+	sloDefaults     sloDefinitionDefaults
 }
 
-func newDataExplorerTileValidator(tile *dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter, flags ff.GetSLIFeatureFlags) *dataExplorerTileValidator {
+func newDataExplorerTileValidator(tile *dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter, flags ff.GetSLIFeatureFlags, sloDefaults sloDefinitionDefaults) *dataExplorerTileValidator {
 	return &dataExplorerTileValidator{
 		tile:            tile,
 		dashboardFilter: dashboardFilter,
 		featureFlags:    flags,
+		sloDefaults:     sloDefaults,
 	}
 }
 
 func (v *dataExplorerTileValidator) tryValidate() (*validatedDataExplorerTile, error) {
-	sloDefinitionParsingResult, err := parseSLODefinition(v.featureFlags, v.tile.Name)
+	sloDefinitionParsingResult, err := parseSLODefinition(v.featureFlags, v.sloDefaults, v.tile.Name)
 	if (err == nil) && (sloDefinitionParsingResult.exclude) {
 		log.WithField("tileName", v.tile.Name).Debug("Tile excluded as name includes exclude=true")
 		return nil, nil
@@ -106,13 +115,18 @@ func (v *dataExplorerTileValidator) tryValidate() (*validatedDataExplorerTile, e
 		errs = append(errs, err)
 	}
 
-	queryID, err := getQueryID(v.tile.Queries)
+	visibleQuery, err := getVisibleQuery(v.tile.Queries)
 	if err != nil {
 		errs = append(errs, err)
 	}
 
+	queryID := ""
+	if visibleQuery != nil {
+		queryID = visibleQuery.ID
+	}
+
 	if (len(sloDefinition.Pass) == 0) && (len(sloDefinition.Warning) == 0) {
-		passAndWarningProvider, err := tryGetThresholdPassAndWarningProvider(v.tile)
+		passAndWarningProvider, err := tryGetThresholdPassAndWarningProvider(v.tile, v.sloDefaults.relativeThresholds)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -123,17 +137,31 @@ func (v *dataExplorerTileValidator) tryValidate() (*validatedDataExplorerTile, e
 		}
 	}
 
-	query, err := createMetricsQueryForMetricExpressions(v.tile.MetricExpressions, NewManagementZoneFilter(v.dashboardFilter, v.tile.TileFilter.ManagementZone))
+	filter := NewFilter(v.dashboardFilter, v.tile.TileFilter)
+	query, err := createMetricsQueryForVisibleQuery(visibleQuery, v.tile.Queries, v.tile.MetricExpressions, "", filter.ForMZSelector())
 	if err != nil {
-		log.WithError(err).Warn("createMetricsQueryForMetricExpressions returned an error, SLI will not be used")
+		log.WithError(err).Warn("createMetricsQueryForVisibleQuery returned an error, SLI will not be used")
 		errs = append(errs, err)
 	}
 
+	tagsApplied := true
+	if query != nil {
+		query, tagsApplied, err = applyEntityFilter(*query, filter)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	targetUnitID, err := getUnitTransform(v.tile.VisualConfig, queryID)
 	if err != nil {
 		errs = append(errs, err)
 	}
 
+	// a unit specified in the title takes precedence over the tile's unit transform
+	if sloDefinitionParsingResult.unit != "" {
+		targetUnitID = sloDefinitionParsingResult.unit
+	}
+
 	if len(errs) > 0 {
 		return nil, &dataExplorerTileValidationError{
 			sloDefinition: sloDefinition,
@@ -146,31 +174,166 @@ func (v *dataExplorerTileValidator) tryValidate() (*validatedDataExplorerTile, e
 		targetUnitID:             targetUnitID,
 		singleValueVisualization: isSingleValueVisualizationType(v.tile.VisualConfig),
 		query:                    *query,
+		tagsApplied:              tagsApplied,
 	}, nil
 }
 
-// getQueryID gets the single enabled query ID or returns an error.
-func getQueryID(queries []dynatrace.DataExplorerQuery) (string, error) {
+// getVisibleQuery gets the single enabled, i.e. visible, query or returns an error.
+// Hidden queries may still be referenced by an expression in the visible query.
+func getVisibleQuery(queries []dynatrace.DataExplorerQuery) (*dynatrace.DataExplorerQuery, error) {
 	if len(queries) == 0 {
-		return "", errors.New("Data Explorer tile has no query")
+		return nil, errors.New("Data Explorer tile has no query")
 	}
 
-	enabledQueryIDs := make([]string, 0, len(queries))
+	enabledQueries := make([]dynatrace.DataExplorerQuery, 0, len(queries))
 	for _, q := range queries {
 		if q.Enabled {
-			enabledQueryIDs = append(enabledQueryIDs, q.ID)
+			enabledQueries = append(enabledQueries, q)
+		}
+	}
+
+	if len(enabledQueries) == 0 {
+		return nil, errors.New("Data Explorer tile has no query enabled")
+	}
+
+	if len(enabledQueries) > 1 {
+		return nil, fmt.Errorf("Data Explorer tile has %d queries enabled but only one is supported", len(enabledQueries))
+	}
+
+	return &enabledQueries[0], nil
+}
+
+// tryResolveExpressionMetricSelector resolves the metric selector of an expression query by replacing references to other queries with their metric selectors.
+// It returns false if the query is not an expression over other queries or if a referenced query does not specify a metric selector, e.g. as it was defined using the Build tab.
+func tryResolveExpressionMetricSelector(query dynatrace.DataExplorerQuery, queries []dynatrace.DataExplorerQuery) (string, bool) {
+	metricSelectorsByID := make(map[string]string, len(queries))
+	for _, q := range queries {
+		if q.ID != query.ID {
+			metricSelectorsByID[q.ID] = q.MetricSelector
 		}
 	}
 
-	if len(enabledQueryIDs) == 0 {
-		return "", errors.New("Data Explorer tile has no query enabled")
+	referencesFound := false
+	resolvable := true
+	resolvedMetricSelector := replaceQueryReferences(query.MetricSelector, func(reference string) string {
+		metricSelector, ok := metricSelectorsByID[reference]
+		if !ok {
+			return reference
+		}
+
+		referencesFound = true
+		if metricSelector == "" {
+			resolvable = false
+			return reference
+		}
+
+		return "(" + metricSelector + ")"
+	})
+
+	if !referencesFound || !resolvable {
+		return "", false
 	}
 
-	if len(enabledQueryIDs) > 1 {
-		return "", fmt.Errorf("Data Explorer tile has %d queries enabled but only one is supported", len(enabledQueryIDs))
+	return resolvedMetricSelector, true
+}
+
+// replaceQueryReferences replaces possible references to other queries in a Data Explorer expression, e.g. A and B in (A)/(B)*100, using the specified function.
+// Only single capital letters are considered, and string literals such as "A" as well as the arguments of transformations and functions, e.g. SERVICE-A in filter(eq("dt.entity.service",SERVICE-A)), are left unchanged.
+func replaceQueryReferences(expression string, replace func(reference string) string) string {
+	var sb strings.Builder
+	for i := 0; i < len(expression); {
+		switch {
+		case expression[i] == '"':
+			end := findStringLiteralEnd(expression, i)
+			sb.WriteString(expression[i:end])
+			i = end
+
+		case expression[i] == '(' && i > 0 && isExpressionNameChar(expression[i-1]):
+			end := findArgumentsEnd(expression, i)
+			sb.WriteString(expression[i:end])
+			i = end
+
+		case isExpressionNameChar(expression[i]):
+			end := findNameEnd(expression, i)
+			name := expression[i:end]
+			if isQueryReference(name) {
+				name = replace(name)
+			}
+			sb.WriteString(name)
+			i = end
+
+		default:
+			sb.WriteByte(expression[i])
+			i++
+		}
 	}
 
-	return enabledQueryIDs[0], nil
+	return sb.String()
+}
+
+// findStringLiteralEnd returns the index following the string literal starting at the specified index, taking escaped quotes (~") into account.
+func findStringLiteralEnd(expression string, start int) int {
+	for i := start + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '~':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return len(expression)
+}
+
+// findArgumentsEnd returns the index following the closing parenthesis matching the opening parenthesis at the specified index.
+func findArgumentsEnd(expression string, start int) int {
+	depth := 0
+	for i := start; i < len(expression); {
+		switch expression[i] {
+		case '"':
+			i = findStringLiteralEnd(expression, i)
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+
+	return len(expression)
+}
+
+// findNameEnd returns the index following the name, e.g. a metric key or query reference, starting at the specified index.
+// Hyphens are only considered part of names other than query references, so that expressions such as A-B are still split into references.
+func findNameEnd(expression string, start int) int {
+	i := start
+	for i < len(expression) {
+		if isExpressionNameChar(expression[i]) {
+			i++
+			continue
+		}
+
+		if expression[i] == '-' && !isQueryReference(expression[start:i]) && i+1 < len(expression) && isExpressionNameChar(expression[i+1]) {
+			i++
+			continue
+		}
+
+		break
+	}
+
+	return i
+}
+
+func isExpressionNameChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '.' || c == ':'
+}
+
+func isQueryReference(name string) bool {
+	return len(name) == 1 && name[0] >= 'A' && name[0] <= 'Z'
 }
 
 func getUnitTransform(visualConfig *dynatrace.VisualizationConfiguration, queryID string) (string, error) {
@@ -226,7 +389,77 @@ func isSingleValueVisualizationType(visualConfig *dynatrace.VisualizationConfigu
 	return visualConfig.Type == dynatrace.SingleValueVisualizationConfigurationType
 }
 
-func createMetricsQueryForMetricExpressions(metricExpressions []string, managementZoneFilter *ManagementZoneFilter) (*metrics.Query, error) {
+// createMetricsQueryForVisibleQuery creates the metrics query for the visible query of a Data Explorer tile.
+// If the visible query is an expression over other queries which can be resolved, the resolved expression is used, otherwise the query is based on the tile's metric expressions.
+func createMetricsQueryForVisibleQuery(visibleQuery *dynatrace.DataExplorerQuery, queries []dynatrace.DataExplorerQuery, metricExpressions []string, entitySelector string, mzSelector string) (*metrics.Query, error) {
+	if visibleQuery != nil {
+		if metricSelector, ok := tryResolveExpressionMetricSelector(*visibleQuery, queries); ok {
+			resolution, err := getResolutionFromMetricExpressions(metricExpressions)
+			if err != nil {
+				return nil, err
+			}
+
+			return metrics.NewQuery(metricSelector, entitySelector, resolution, mzSelector)
+		}
+	}
+
+	return createMetricsQueryForMetricExpressions(metricExpressions, entitySelector, mzSelector)
+}
+
+// entityDimensionPattern matches entity dimension keys in metric selectors, e.g. dt.entity.service, capturing the entity type.
+var entityDimensionPattern = regexp.MustCompile(`dt\.entity\.([a-z0-9_]+)`)
+
+// applyEntityFilter returns a copy of the specified query with an entity selector for the tile's entities and the dashboard's tags.
+// As tags can only be applied for a known entity type, this is derived from the single entity dimension referenced by the metric selector, e.g. dt.entity.service.
+// It returns false if the tags cannot be applied.
+func applyEntityFilter(query metrics.Query, filter *Filter) (*metrics.Query, bool, error) {
+	entitySelector := ""
+	if filter.hasTags() {
+		entitySelector = getEntityTypeSelector(query.GetMetricSelector())
+	}
+
+	entitySelector, tagsApplied := filter.ForMetricsEntitySelector(entitySelector)
+	filteredQuery, err := metrics.NewQuery(query.GetMetricSelector(), entitySelector, query.GetResolution(), query.GetMZSelector())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return filteredQuery, tagsApplied, nil
+}
+
+// getEntityTypeSelector returns an entity selector for the single entity type whose dimension is referenced by the specified metric selector, e.g. type(SERVICE), or an empty string if no or more than one entity type is referenced.
+func getEntityTypeSelector(metricSelector string) string {
+	entityType := ""
+	for _, match := range entityDimensionPattern.FindAllStringSubmatch(metricSelector, -1) {
+		if entityType != "" && entityType != match[1] {
+			return ""
+		}
+		entityType = match[1]
+	}
+
+	if entityType == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("type(%s)", strings.ToUpper(entityType))
+}
+
+// getResolutionFromMetricExpressions gets the resolution from the first metric expression, if available.
+func getResolutionFromMetricExpressions(metricExpressions []string) (string, error) {
+	if len(metricExpressions) == 0 {
+		return "", nil
+	}
+
+	pieces := strings.SplitN(metricExpressions[0], "&", 2)
+	resolution, err := parseResolutionKeyValuePair(pieces[0])
+	if err != nil {
+		return "", fmt.Errorf("could not parse resolution metric expression component: %w", err)
+	}
+
+	return resolution, nil
+}
+
+func createMetricsQueryForMetricExpressions(metricExpressions []string, entitySelector string, mzSelector string) (*metrics.Query, error) {
 	if len(metricExpressions) == 0 {
 		return nil, errors.New("Data Explorer tile has no metric expressions")
 	}
@@ -235,10 +468,10 @@ func createMetricsQueryForMetricExpressions(metricExpressions []string, manageme
 		log.WithField("metricExpressions", metricExpressions).Warn("processMetricExpressions found more than 2 metric expressions")
 	}
 
-	return createMetricsQueryForMetricExpression(metricExpressions[0], managementZoneFilter)
+	return createMetricsQueryForMetricExpression(metricExpressions[0], entitySelector, mzSelector)
 }
 
-func createMetricsQueryForMetricExpression(metricExpression string, managementZoneFilter *ManagementZoneFilter) (*metrics.Query, error) {
+func createMetricsQueryForMetricExpression(metricExpression string, entitySelector string, mzSelector string) (*metrics.Query, error) {
 	pieces := strings.SplitN(metricExpression, "&", 2)
 	if len(pieces) != 2 {
 		return nil, fmt.Errorf("metric expression does not contain two components: %s", metricExpression)
@@ -249,7 +482,7 @@ func createMetricsQueryForMetricExpression(metricExpression string, managementZo
 		return nil, fmt.Errorf("could not parse resolution metric expression component: %w", err)
 	}
 
-	return metrics.NewQuery(pieces[1], "", resolution, managementZoneFilter.ForMZSelector())
+	return metrics.NewQuery(pieces[1], entitySelector, resolution, mzSelector)
 }
 
 // parseResolutionKeyValuePair parses the resolution key value pair, returning resolution or error. In the case that no resolution is set in UI, i.e. resolution=null, an empty string is returned.
@@ -276,4 +509,5 @@ type validatedDataExplorerTile struct {
 	targetUnitID             string
 	singleValueVisualization bool
 	query                    metrics.Query
+	tagsApplied              bool
 }
@@ -0,0 +1,112 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+func TestTryResolveExpressionMetricSelector(t *testing.T) {
+	const errorCountSelector = "builtin:service.errors.total.count:splitBy():sum"
+	const requestCountSelector = "builtin:service.requestCount.total:splitBy():sum"
+
+	tests := []struct {
+		name                   string
+		queries                []dynatrace.DataExplorerQuery
+		expectedMetricSelector string
+		expectResolved         bool
+	}{
+		{
+			name: "expression over hidden code queries is resolved",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", MetricSelector: errorCountSelector},
+				{ID: "B", MetricSelector: requestCountSelector},
+				{ID: "C", MetricSelector: "A/B*100", Enabled: true},
+			},
+			expectedMetricSelector: "(" + errorCountSelector + ")/(" + requestCountSelector + ")*100",
+			expectResolved:         true,
+		},
+		{
+			name: "query referenced multiple times is resolved",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", MetricSelector: errorCountSelector},
+				{ID: "B", MetricSelector: "(A + A) / 2", Enabled: true},
+			},
+			expectedMetricSelector: "((" + errorCountSelector + ") + (" + errorCountSelector + ")) / 2",
+			expectResolved:         true,
+		},
+		{
+			name: "quoted dimension values and entity IDs in filters are not replaced",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", MetricSelector: errorCountSelector},
+				{ID: "B", MetricSelector: "builtin:service.requestCount.total:filter(and(eq(\"dt.entity.service\",SERVICE-A),eq(\"version\",\"A\"))):splitBy():sum"},
+				{ID: "C", MetricSelector: "(A)/(B)*100", Enabled: true},
+			},
+			expectedMetricSelector: "((" + errorCountSelector + "))/((builtin:service.requestCount.total:filter(and(eq(\"dt.entity.service\",SERVICE-A),eq(\"version\",\"A\"))):splitBy():sum))*100",
+			expectResolved:         true,
+		},
+		{
+			name: "references in string literals and function arguments of the expression are not replaced",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", MetricSelector: errorCountSelector},
+				{ID: "B", MetricSelector: requestCountSelector},
+				{ID: "C", MetricSelector: "(A):filter(eq(\"B\",B))-B", Enabled: true},
+			},
+			expectedMetricSelector: "((" + errorCountSelector + ")):filter(eq(\"B\",B))-(" + requestCountSelector + ")",
+			expectResolved:         true,
+		},
+		{
+			name: "hyphenated names are not references",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", MetricSelector: errorCountSelector},
+				{ID: "B", MetricSelector: "ext:custom-A/SERVICE-A", Enabled: true},
+			},
+			expectResolved: false,
+		},
+		{
+			name: "code query is not an expression",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", MetricSelector: "builtin:security.securityProblem.open.managementZone:filter(and(or(eq(\"Risk Level\",HIGH)))):sum", Enabled: true},
+			},
+			expectResolved: false,
+		},
+		{
+			name: "builder query is not an expression",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", Enabled: true},
+			},
+			expectResolved: false,
+		},
+		{
+			name: "reference to unknown query is not resolved",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A", MetricSelector: errorCountSelector},
+				{ID: "C", MetricSelector: "D*100", Enabled: true},
+			},
+			expectResolved: false,
+		},
+		{
+			name: "expression over builder queries is not resolved",
+			queries: []dynatrace.DataExplorerQuery{
+				{ID: "A"},
+				{ID: "B", MetricSelector: requestCountSelector},
+				{ID: "C", MetricSelector: "A/B", Enabled: true},
+			},
+			expectResolved: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visibleQuery, err := getVisibleQuery(tt.queries)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			metricSelector, ok := tryResolveExpressionMetricSelector(*visibleQuery, tt.queries)
+			assert.Equal(t, tt.expectResolved, ok)
+			assert.Equal(t, tt.expectedMetricSelector, metricSelector)
+		})
+	}
+}
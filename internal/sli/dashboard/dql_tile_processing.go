@@ -0,0 +1,86 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dql"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+// DQLTileProcessing represents the processing of a DQL tile, i.e. a DQL query section of a Dynatrace Notebook.
+type DQLTileProcessing struct {
+	client       dynatrace.ClientInterface
+	timeframe    common.Timeframe
+	featureFlags ff.GetSLIFeatureFlags
+	sloDefaults  sloDefinitionDefaults
+}
+
+// NewDQLTileProcessing creates a new DQLTileProcessing.
+func NewDQLTileProcessing(client dynatrace.ClientInterface, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags, sloDefaults sloDefinitionDefaults) *DQLTileProcessing {
+	return &DQLTileProcessing{
+		client:       client,
+		timeframe:    timeframe,
+		featureFlags: flags,
+		sloDefaults:  sloDefaults,
+	}
+}
+
+// Process processes the specified DQL tile.
+// The query should return a single record with a single numeric field, which is used as the SLI value.
+func (p *DQLTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile) []result.SLIWithSLO {
+	sloDefinitionParsingResult, err := parseSLODefinition(p.featureFlags, p.sloDefaults, tile.CustomName)
+	if (err == nil) && (sloDefinitionParsingResult.exclude) {
+		log.WithField("tile.CustomName", tile.CustomName).Debug("Tile excluded as name includes exclude=true")
+		return nil
+	}
+
+	sloDefinition := sloDefinitionParsingResult.sloDefinition
+	if sloDefinition.SLI == "" {
+		log.WithField("tile.CustomName", tile.CustomName).Debug("Omitted DQL tile as no SLI name could be derived")
+		return nil
+	}
+
+	if err != nil {
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "DQL tile title parsing error: "+err.Error(), result.ErrorCodeInvalidDefinition)}
+	}
+
+	query, err := dql.NewQuery(tile.Query)
+	if err != nil {
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "error creating DQL query: "+err.Error(), result.ErrorCodeInvalidDefinition)}
+	}
+
+	request := dynatrace.NewDQLClientQueryRequest(*query, p.timeframe)
+	dqlResult, err := dynatrace.NewDQLClient(p.client).GetByQuery(ctx, request)
+	if err != nil {
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), "error querying Grail query API: "+err.Error(), getQueryErrorCode(err, result.ErrorCodeQueryFailed))}
+	}
+
+	if len(dqlResult.Records) != 1 {
+		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), fmt.Sprintf("DQL query should return exactly one record but returned %d", len(dqlResult.Records)), result.ErrorCodeUnexpectedResult)}
+	}
+
+	record := dqlResult.Records[0]
+	if len(record) != 1 {
+		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), fmt.Sprintf("DQL record should contain exactly one field but contains %d", len(record)), result.ErrorCodeUnexpectedResult)}
+	}
+
+	// the record contains exactly one field, so use its name whatever it may be
+	var field string
+	for f := range record {
+		field = f
+	}
+
+	value, err := record.GetNumericFieldValue(field)
+	if err != nil {
+		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error(), result.ErrorCodeUnexpectedResult)}
+	}
+
+	return []result.SLIWithSLO{result.NewSuccessfulSLIWithSLOQueryAndUnit(sloDefinition, value, request.RequestString(), unit.Unspecified)}
+}
@@ -0,0 +1,189 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+)
+
+const allManagementZonesID = "all"
+
+const (
+	filterNotAppliedWarning = "dashboard or tile filter cannot be applied to this tile, SLI is not filtered"
+	tagsNotAppliedWarning   = "dashboard tags cannot be applied without an entity type, SLI is not filtered by tags"
+)
+
+// Filter is the filter applied to a tile, combining the dashboard filter with the tile filter.
+// It consists of a management zone, where a tile's management zone takes precedence over the dashboard's, the dashboard's tags and the tile's entities.
+type Filter struct {
+	dashboardFilter *dynatrace.DashboardFilter
+	tileFilter      dynatrace.TileFilter
+}
+
+// NewFilter creates a new Filter for the specified dashboard filter, which may be nil, and tile filter.
+func NewFilter(dashboardFilter *dynatrace.DashboardFilter, tileFilter dynatrace.TileFilter) *Filter {
+	return &Filter{
+		dashboardFilter: dashboardFilter,
+		tileFilter:      tileFilter,
+	}
+}
+
+// ForProblemsQuery returns the problem selector criteria, starting with a comma, and the entity selector for a Problems API v2 query.
+// The specified entity selector, which may be empty, is extended with the tile's entities.
+// As on the dashboard, entities must have all of the dashboard's tags: if the entity selector specifies an entity type, the tags are added to it, otherwise a single tag can be added to the problem selector.
+// If a ManagementZone for a Dashboard tile is given, then it will take precedence over the ManagementZone of the DashboardFilter.
+// It returns false if the tags cannot be applied.
+func (filter *Filter) ForProblemsQuery(entitySelector string) (string, string, bool) {
+	problemSelector := ""
+	if mz := filter.getManagementZone(); mz != nil {
+		problemSelector = createFilterQueryForProblemSelector(*mz)
+	}
+
+	entitySelector, ok := filter.forEntitySelector(entitySelector)
+	if ok {
+		return problemSelector, entitySelector, true
+	}
+
+	// entityTags matches entities with any of the specified tags, so it only has the dashboard's semantics for a single tag
+	if tags := filter.getTags(); len(tags) == 1 {
+		return problemSelector + fmt.Sprintf(",entityTags(%q)", tags[0]), entitySelector, true
+	}
+
+	return problemSelector, entitySelector, false
+}
+
+// ForMZSelector returns the ID of the ManagementZone in a valid representation for the mzSelector.
+// If a ManagementZone for a Dashboard tile is given, then it will take precedence over the ManagementZone of the DashboardFilter
+// If none of both are given it will return an empty string
+func (filter *Filter) ForMZSelector() string {
+	if mz := filter.getManagementZone(); mz != nil {
+		return createFilterQueryForMZSelector(*mz)
+	}
+
+	return ""
+}
+
+// ForMetricsEntitySelector extends the specified entity selector, which may be empty, with the tile's entities and the dashboard's tags.
+// As on the dashboard, entities must have all of the dashboard's tags.
+// As tags can only be applied to entity selectors specifying an entity type, it returns false if the tags cannot be applied.
+func (filter *Filter) ForMetricsEntitySelector(entitySelector string) (string, bool) {
+	return filter.forEntitySelector(entitySelector)
+}
+
+func (filter *Filter) forEntitySelector(entitySelector string) (string, bool) {
+	entitySelector = appendToEntitySelector(entitySelector, filter.createEntityIDsSelector())
+
+	tags := filter.getTags()
+	if len(tags) == 0 {
+		return entitySelector, true
+	}
+
+	if !strings.HasPrefix(entitySelector, "type(") {
+		return entitySelector, false
+	}
+
+	for _, tag := range tags {
+		entitySelector += fmt.Sprintf(",tag(%q)", tag)
+	}
+	return entitySelector, true
+}
+
+// hasTags returns true if the dashboard filter specifies tags.
+func (filter *Filter) hasTags() bool {
+	return len(filter.getTags()) > 0
+}
+
+// getEntities returns the entities specified by the tile's filter.
+func (filter *Filter) getEntities() []string {
+	return filter.tileFilter.Entities
+}
+
+// isEmpty returns true if the filter does not restrict the tile in any way.
+func (filter *Filter) isEmpty() bool {
+	mz := filter.getManagementZone()
+	return (mz == nil || mz.ID == allManagementZonesID) && len(filter.getTags()) == 0 && len(filter.getEntities()) == 0
+}
+
+// addWarningIfNotApplicable adds a warning to the messages of the specified results of a tile that cannot be filtered if the filter restricts the tile.
+func (filter *Filter) addWarningIfNotApplicable(results []result.SLIWithSLO) []result.SLIWithSLO {
+	if filter.isEmpty() {
+		return results
+	}
+
+	return addWarning(results, filterNotAppliedWarning)
+}
+
+// addWarning adds the specified warning to the messages of the specified results.
+func addWarning(results []result.SLIWithSLO, warning string) []result.SLIWithSLO {
+	warnedResults := make([]result.SLIWithSLO, 0, len(results))
+	for _, r := range results {
+		warnedResults = append(warnedResults, result.NewSLIWithSLO(r.SLIResult().WithAdditionalMessage(warning), r.SLODefinition()))
+	}
+	return warnedResults
+}
+
+func (filter *Filter) getManagementZone() *dynatrace.ManagementZoneEntry {
+	if filter.tileFilter.ManagementZone != nil {
+		return filter.tileFilter.ManagementZone
+	}
+
+	if filter.dashboardFilter != nil {
+		return filter.dashboardFilter.ManagementZone
+	}
+
+	return nil
+}
+
+func (filter *Filter) getTags() []string {
+	if filter.dashboardFilter == nil {
+		return nil
+	}
+
+	return filter.dashboardFilter.Tags
+}
+
+func (filter *Filter) createEntityIDsSelector() string {
+	entities := filter.getEntities()
+	if len(entities) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("entityId(%s)", joinQuoted(entities))
+}
+
+// appendToEntitySelector appends the specified criterion to the entity selector, adding a comma if required.
+func appendToEntitySelector(entitySelector string, criterion string) string {
+	if criterion == "" {
+		return entitySelector
+	}
+
+	if entitySelector == "" {
+		return criterion
+	}
+
+	return entitySelector + "," + criterion
+}
+
+func joinQuoted(values []string) string {
+	quotedValues := make([]string, len(values))
+	for i, v := range values {
+		quotedValues[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quotedValues, ",")
+}
+
+func createFilterQueryForProblemSelector(mz dynatrace.ManagementZoneEntry) string {
+	if mz.ID == allManagementZonesID {
+		return ""
+	}
+	return fmt.Sprintf(",managementZones(%q)", mz.Name)
+}
+
+func createFilterQueryForMZSelector(mz dynatrace.ManagementZoneEntry) string {
+	if mz.ID == allManagementZonesID {
+		return ""
+	}
+	return fmt.Sprintf("mzName(%q)", mz.Name)
+}
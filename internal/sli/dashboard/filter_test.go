@@ -0,0 +1,110 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+func TestFilter(t *testing.T) {
+	dashboardManagementZone := &dynatrace.ManagementZoneEntry{ID: "2311420533206603714", Name: "ap_mz_1"}
+	tileManagementZone := &dynatrace.ManagementZoneEntry{ID: "-6219736993013608218", Name: "ap_mz_2"}
+
+	tests := []struct {
+		name                           string
+		dashboardFilter                *dynatrace.DashboardFilter
+		tileFilter                     dynatrace.TileFilter
+		baseEntitySelector             string
+		expectedProblemSelector        string
+		expectedMZSelector             string
+		expectedProblemsEntitySelector string
+		expectedProblemsTagsApplied    bool
+		expectedMetricsEntitySelector  string
+		expectedMetricsTagsApplied     bool
+		expectedEmpty                  bool
+	}{
+		{
+			name:                        "no filters",
+			expectedProblemsTagsApplied: true,
+			expectedMetricsTagsApplied:  true,
+			expectedEmpty:               true,
+		},
+		{
+			name:                        "all management zones",
+			dashboardFilter:             &dynatrace.DashboardFilter{ManagementZone: &dynatrace.ManagementZoneEntry{ID: "all", Name: "All"}},
+			expectedProblemsTagsApplied: true,
+			expectedMetricsTagsApplied:  true,
+			expectedEmpty:               true,
+		},
+		{
+			name:                        "tile management zone takes precedence",
+			dashboardFilter:             &dynatrace.DashboardFilter{ManagementZone: dashboardManagementZone},
+			tileFilter:                  dynatrace.TileFilter{ManagementZone: tileManagementZone},
+			expectedProblemSelector:     `,managementZones("ap_mz_2")`,
+			expectedMZSelector:          `mzName("ap_mz_2")`,
+			expectedProblemsTagsApplied: true,
+			expectedMetricsTagsApplied:  true,
+		},
+		{
+			name:                        "single dashboard tag without entity type",
+			dashboardFilter:             &dynatrace.DashboardFilter{Tags: []string{"env:prod"}},
+			expectedProblemSelector:     `,entityTags("env:prod")`,
+			expectedProblemsTagsApplied: true,
+			expectedMetricsTagsApplied:  false,
+		},
+		{
+			name:                        "dashboard tags without entity type",
+			dashboardFilter:             &dynatrace.DashboardFilter{Tags: []string{"env:prod", "team"}},
+			expectedProblemsTagsApplied: false,
+			expectedMetricsTagsApplied:  false,
+		},
+		{
+			name:                           "dashboard tags with entity type",
+			dashboardFilter:                &dynatrace.DashboardFilter{Tags: []string{"env:prod", "team"}},
+			baseEntitySelector:             "type(SERVICE)",
+			expectedProblemsEntitySelector: `type(SERVICE),tag("env:prod"),tag("team")`,
+			expectedProblemsTagsApplied:    true,
+			expectedMetricsEntitySelector:  `type(SERVICE),tag("env:prod"),tag("team")`,
+			expectedMetricsTagsApplied:     true,
+		},
+		{
+			name:                           "tile entities",
+			tileFilter:                     dynatrace.TileFilter{Entities: []string{"SERVICE-1", "SERVICE-2"}},
+			expectedProblemsEntitySelector: `entityId("SERVICE-1","SERVICE-2")`,
+			expectedProblemsTagsApplied:    true,
+			expectedMetricsEntitySelector:  `entityId("SERVICE-1","SERVICE-2")`,
+			expectedMetricsTagsApplied:     true,
+		},
+		{
+			name:                           "management zone, tags and tile entities with entity type",
+			dashboardFilter:                &dynatrace.DashboardFilter{ManagementZone: dashboardManagementZone, Tags: []string{"env:prod"}},
+			tileFilter:                     dynatrace.TileFilter{Entities: []string{"HOST-1"}},
+			baseEntitySelector:             `type("HOST")`,
+			expectedProblemSelector:        `,managementZones("ap_mz_1")`,
+			expectedMZSelector:             `mzName("ap_mz_1")`,
+			expectedProblemsEntitySelector: `type("HOST"),entityId("HOST-1"),tag("env:prod")`,
+			expectedProblemsTagsApplied:    true,
+			expectedMetricsEntitySelector:  `type("HOST"),entityId("HOST-1"),tag("env:prod")`,
+			expectedMetricsTagsApplied:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewFilter(tt.dashboardFilter, tt.tileFilter)
+
+			problemSelector, problemsEntitySelector, problemsTagsApplied := filter.ForProblemsQuery(tt.baseEntitySelector)
+			assert.Equal(t, tt.expectedProblemSelector, problemSelector)
+			assert.Equal(t, tt.expectedProblemsEntitySelector, problemsEntitySelector)
+			assert.Equal(t, tt.expectedProblemsTagsApplied, problemsTagsApplied)
+			assert.Equal(t, tt.expectedMZSelector, filter.ForMZSelector())
+
+			metricsEntitySelector, tagsApplied := filter.ForMetricsEntitySelector(tt.baseEntitySelector)
+			assert.Equal(t, tt.expectedMetricsEntitySelector, metricsEntitySelector)
+			assert.Equal(t, tt.expectedMetricsTagsApplied, tagsApplied)
+			assert.Equal(t, tt.expectedEmpty, filter.isEmpty())
+		})
+	}
+}
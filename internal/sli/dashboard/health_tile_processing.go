@@ -0,0 +1,115 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/problems"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+const (
+	unhealthyHostsIndicatorName    = "unhealthy_hosts"
+	unhealthyServicesIndicatorName = "unhealthy_services"
+)
+
+const (
+	hostEntityType    = "HOST"
+	serviceEntityType = "SERVICE"
+)
+
+// HealthTileProcessing represents the processing of a host or service health dashboard tile.
+type HealthTileProcessing struct {
+	client        dynatrace.ClientInterface
+	timeframe     common.Timeframe
+	entityType    string
+	indicatorName string
+	sloDefaults   sloDefinitionDefaults
+}
+
+// NewHostHealthTileProcessing creates a new HealthTileProcessing for host health tiles.
+func NewHostHealthTileProcessing(client dynatrace.ClientInterface, timeframe common.Timeframe, sloDefaults sloDefinitionDefaults) *HealthTileProcessing {
+	return &HealthTileProcessing{
+		client:        client,
+		timeframe:     timeframe,
+		entityType:    hostEntityType,
+		indicatorName: unhealthyHostsIndicatorName,
+		sloDefaults:   sloDefaults,
+	}
+}
+
+// NewServiceHealthTileProcessing creates a new HealthTileProcessing for service health tiles.
+func NewServiceHealthTileProcessing(client dynatrace.ClientInterface, timeframe common.Timeframe, sloDefaults sloDefinitionDefaults) *HealthTileProcessing {
+	return &HealthTileProcessing{
+		client:        client,
+		timeframe:     timeframe,
+		entityType:    serviceEntityType,
+		indicatorName: unhealthyServicesIndicatorName,
+		sloDefaults:   sloDefaults,
+	}
+}
+
+// Process retrieves the number of unhealthy entities, i.e. entities impacted by open problems, and returns this as a TileResult.
+// An SLO definition with a pass criteria of <= 0 is also included as we don't allow unhealthy entities.
+func (p *HealthTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter) []result.SLIWithSLO {
+	if p.sloDefaults.exclude {
+		log.WithField("tileType", tile.TileType).Debug("Tile excluded as markdown section default includes exclude=true")
+		return nil
+	}
+
+	filter := NewFilter(dashboardFilter, tile.TileFilter)
+
+	problemSelector, entitySelector, ok := filter.ForProblemsQuery(fmt.Sprintf("type(%q)", p.entityType))
+	results := []result.SLIWithSLO{p.processHealthTile(ctx, problems.NewQuery("status(\"open\")"+problemSelector, entitySelector), filter.getEntities())}
+	if !ok {
+		return addWarning(results, tagsNotAppliedWarning)
+	}
+	return results
+}
+
+func (p *HealthTileProcessing) processHealthTile(ctx context.Context, query problems.Query, entityIDs []string) result.SLIWithSLO {
+	sloDefinition := p.sloDefaults.applyToFixedSLODefinition(result.SLO{
+		SLI:    p.indicatorName,
+		Pass:   result.SLOCriteriaList{{Criteria: []string{"<=0"}}},
+		KeySLI: true,
+	})
+
+	request := dynatrace.NewProblemsV2ClientQueryRequest(query, p.timeframe)
+	openProblems, err := dynatrace.NewProblemsV2Client(p.client).GetByQuery(ctx, request)
+	if err != nil {
+		return result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), "error querying Problems API v2: "+err.Error(), getQueryErrorCode(err, result.ErrorCodeQueryFailed))
+	}
+
+	return result.NewSuccessfulSLIWithSLOQueryAndUnit(sloDefinition, float64(p.countUnhealthyEntities(openProblems, entityIDs)), request.RequestString(), unit.Count)
+}
+
+// countUnhealthyEntities counts the distinct entities of the tile's entity type impacted by the specified problems.
+// If entity IDs are specified, only these entities are counted.
+func (p *HealthTileProcessing) countUnhealthyEntities(openProblems []dynatrace.ProblemV2, entityIDs []string) int {
+	filteredEntities := make(map[string]struct{}, len(entityIDs))
+	for _, entityID := range entityIDs {
+		filteredEntities[entityID] = struct{}{}
+	}
+
+	unhealthyEntities := make(map[string]struct{})
+	for _, problem := range openProblems {
+		for _, entity := range problem.ImpactedEntities {
+			if entity.EntityID.Type != p.entityType {
+				continue
+			}
+
+			if _, ok := filteredEntities[entity.EntityID.ID]; len(filteredEntities) > 0 && !ok {
+				continue
+			}
+
+			unhealthyEntities[entity.EntityID.ID] = struct{}{}
+		}
+	}
+
+	return len(unhealthyEntities)
+}
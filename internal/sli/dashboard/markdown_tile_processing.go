@@ -36,6 +36,18 @@ func (err *invalidValueError) Error() string {
 type markdownParsingResult struct {
 	totalScore keptncommon.SLOScore
 	comparison keptncommon.SLOComparison
+
+	// configuresScoreOrComparison is true if the markdown specifies any total score or comparison keys.
+	configuresScoreOrComparison bool
+
+	// sloDefaults are the SLO definition defaults for the tiles below the markdown tile.
+	sloDefaults sloDefinitionDefaults
+
+	// useTileTimeframes is true if tiles should be evaluated against their own timeframe and timeshift rather than the event's timeframe.
+	useTileTimeframes bool
+
+	// configuresTileTimeframes is true if the markdown specifies the tile timeframes key.
+	configuresTileTimeframes bool
 }
 
 type MarkdownTileProcessing struct {
@@ -46,8 +58,8 @@ func NewMarkdownTileProcessing() *MarkdownTileProcessing {
 	return &MarkdownTileProcessing{}
 }
 
-// TryProcess tries to process the specified markdown tile as a KQG total score and comparison configuration.
-// If it is not such a tile, i.e. does not contain "KQG.", it returns nil with nil error. Otherwise it returns the parsing result or an error, overwriting the default values for SLOScore and SLOComparison with the contents found in the markdown
+// TryProcess tries to process the specified markdown tile as a KQG total score, comparison and SLO defaults configuration.
+// If it is not such a tile, i.e. does not contain "KQG.", it returns nil with nil error. Otherwise it returns the parsing result or an error, overwriting the default values for SLOScore, SLOComparison and SLO definition defaults with the contents found in the markdown
 func (p *MarkdownTileProcessing) TryProcess(tile *dynatrace.Tile) (*markdownParsingResult, error) {
 	return tryParseMarkdownConfiguration(tile.Markdown)
 }
@@ -67,6 +79,13 @@ const (
 	CompareFunctionP50         = "p50"
 	CompareFunctionP90         = "p90"
 	CompareFunctionP95         = "p95"
+	DefaultWeight              = "kqg.default.weight"
+	DefaultKey                 = "kqg.default.key"
+	DefaultExclude             = "kqg.default.exclude"
+	DefaultThresholds          = "kqg.default.thresholds"
+	DefaultThresholdsAbsolute  = "absolute"
+	DefaultThresholdsRelative  = "relative"
+	TileTimeframes             = "kqg.tile.timeframes"
 )
 
 // tryParseMarkdownConfiguration tries to parse a text that can be used in a Markdown tile to specify global SLO properties.
@@ -76,8 +95,9 @@ func tryParseMarkdownConfiguration(markdown string) (*markdownParsingResult, err
 	}
 
 	result := &markdownParsingResult{
-		totalScore: common.CreateDefaultSLOScore(),
-		comparison: common.CreateDefaultSLOComparison(),
+		totalScore:  common.CreateDefaultSLOScore(),
+		comparison:  common.CreateDefaultSLOComparison(),
+		sloDefaults: newSLODefinitionDefaults(),
 	}
 
 	var errs []error
@@ -142,6 +162,62 @@ func tryParseMarkdownConfiguration(markdown string) (*markdownParsingResult, err
 			}
 			result.comparison.AggregateFunction = aggregateFunc
 			keyFound[CompareFunction] = true
+		case DefaultWeight:
+			if keyFound[DefaultWeight] {
+				errs = append(errs, &duplicateKeyError{key: DefaultWeight})
+				break
+			}
+			weight, err := parseDefaultWeight(kv.value)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			result.sloDefaults.weight = weight
+			keyFound[DefaultWeight] = true
+		case DefaultKey:
+			if keyFound[DefaultKey] {
+				errs = append(errs, &duplicateKeyError{key: DefaultKey})
+				break
+			}
+			keySLI, err := parseBoolValue(DefaultKey, kv.value)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			result.sloDefaults.keySLI = keySLI
+			result.sloDefaults.keySLIConfigured = true
+			keyFound[DefaultKey] = true
+		case DefaultExclude:
+			if keyFound[DefaultExclude] {
+				errs = append(errs, &duplicateKeyError{key: DefaultExclude})
+				break
+			}
+			exclude, err := parseBoolValue(DefaultExclude, kv.value)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			result.sloDefaults.exclude = exclude
+			keyFound[DefaultExclude] = true
+		case DefaultThresholds:
+			if keyFound[DefaultThresholds] {
+				errs = append(errs, &duplicateKeyError{key: DefaultThresholds})
+				break
+			}
+			relativeThresholds, err := parseDefaultThresholds(kv.value)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			result.sloDefaults.relativeThresholds = relativeThresholds
+			keyFound[DefaultThresholds] = true
+		case TileTimeframes:
+			if keyFound[TileTimeframes] {
+				errs = append(errs, &duplicateKeyError{key: TileTimeframes})
+				break
+			}
+			useTileTimeframes, err := parseBoolValue(TileTimeframes, kv.value)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			result.useTileTimeframes = useTileTimeframes
+			keyFound[TileTimeframes] = true
 		}
 	}
 
@@ -151,6 +227,9 @@ func tryParseMarkdownConfiguration(markdown string) (*markdownParsingResult, err
 		}
 	}
 
+	result.configuresScoreOrComparison = keyFound[TotalPass] || keyFound[TotalWarning] || keyFound[CompareWithScore] || keyFound[CompareResults] || keyFound[CompareFunction]
+	result.configuresTileTimeframes = keyFound[TileTimeframes]
+
 	result.comparison.CompareWith = CompareResultsSingle
 	if result.comparison.NumberOfComparisonResults > 1 {
 		result.comparison.CompareWith = CompareResultsMultiple
@@ -165,6 +244,18 @@ func isNotAPercentValue(value string) bool {
 	return !pattern.MatchString(value)
 }
 
+// parseDefaultThresholds parses the thresholds value, returning true for relative and false for absolute thresholds.
+func parseDefaultThresholds(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case DefaultThresholdsAbsolute:
+		return false, nil
+	case DefaultThresholdsRelative:
+		return true, nil
+	}
+
+	return false, &invalidValueError{key: DefaultThresholds, value: value}
+}
+
 func parseCompareWithScore(value string) (string, error) {
 	switch value {
 	case CompareWithScorePass, CompareWithScoreAll, CompareWithScorePassOrWarn:
@@ -195,3 +286,25 @@ func parseAggregateFunction(value string) (string, error) {
 
 	return "", &invalidValueError{key: CompareFunction, value: value}
 }
+
+func parseDefaultWeight(value string) (int, error) {
+	weight, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &invalidValueError{key: DefaultWeight, value: value}
+	}
+
+	if weight < 1 {
+		return 0, &invalidValueError{key: DefaultWeight, value: value}
+	}
+
+	return weight, nil
+}
+
+func parseBoolValue(key string, value string) (bool, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &invalidValueError{key: key, value: value}
+	}
+
+	return b, nil
+}
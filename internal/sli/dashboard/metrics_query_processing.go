@@ -56,29 +56,36 @@ func (r *MetricsQueryProcessing) Process(ctx context.Context, sloDefinition resu
 }
 
 func (r *MetricsQueryProcessing) createTileResultsForError(sloDefinition result.SLO, request dynatrace.MetricsClientQueryRequest, err error) []result.SLIWithSLO {
+	diagnostics := dynatrace.NewMetricsDiagnosticsFromError(request, err)
+	diagnostics.ErrorCode = getQueryErrorCode(err, diagnostics.ErrorCode)
+
 	var qpErrorType *dynatrace.MetricsQueryProcessingError
 	if errors.As(err, &qpErrorType) {
-		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error())}
+		return []result.SLIWithSLO{result.NewSLIWithSLO(result.NewWarningSLIResultWithQuery(sloDefinition.SLI, err.Error(), request.RequestString(), diagnostics.ErrorCode).WithDiagnostics(diagnostics), sloDefinition)}
 	}
-	return []result.SLIWithSLO{result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error())}
-
+	return []result.SLIWithSLO{result.NewSLIWithSLO(result.NewFailedSLIResultWithQuery(sloDefinition.SLI, err.Error(), request.RequestString(), diagnostics.ErrorCode).WithDiagnostics(diagnostics), sloDefinition)}
 }
 
 func (r *MetricsQueryProcessing) processResults(sloDefinition result.SLO, processingResults *dynatrace.MetricsProcessingResults) []result.SLIWithSLO {
 	request := processingResults.Request()
 	results := processingResults.Results()
+	diagnostics := dynatrace.NewMetricsDiagnostics(processingResults)
 	if len(results) == 1 {
-		return []result.SLIWithSLO{result.NewSuccessfulSLIWithSLOAndQuery(sloDefinition, results[0].Value(), request.RequestString())}
+		return []result.SLIWithSLO{result.NewSLIWithSLO(result.NewSuccessfulSLIResultWithQueryAndUnit(sloDefinition.SLI, results[0].Value(), request.RequestString(), processingResults.UnitID()).WithDiagnostics(diagnostics), sloDefinition)}
 	}
 
 	var tileResults []result.SLIWithSLO
 	for _, res := range results {
+		tileSLODefinition := createSLODefinitionForName(r.featureFlags, sloDefinition, res.Name())
 		tileResults = append(
 			tileResults,
-			result.NewSuccessfulSLIWithSLOAndQuery(
-				createSLODefinitionForName(r.featureFlags, sloDefinition, res.Name()),
-				res.Value(),
-				request.RequestString()))
+			result.NewSLIWithSLO(
+				result.NewSuccessfulSLIResultWithQueryAndUnit(
+					tileSLODefinition.SLI,
+					res.Value(),
+					request.RequestString(),
+					processingResults.UnitID()).WithDiagnostics(diagnostics),
+				tileSLODefinition))
 	}
 
 	return tileResults
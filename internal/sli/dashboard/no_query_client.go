@@ -0,0 +1,60 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+)
+
+// errQueryingDisabled is returned by the noQueryClient for every request.
+var errQueryingDisabled = errors.New("querying is disabled")
+
+// errorCodeQueryingDisabled classifies results that failed because the noQueryClient refused a request, which is expected while linting.
+const errorCodeQueryingDisabled result.ErrorCode = "querying_disabled"
+
+// getQueryErrorCode returns errorCodeQueryingDisabled if the specified error was caused by querying being disabled, otherwise the specified error code.
+func getQueryErrorCode(err error, errorCode result.ErrorCode) result.ErrorCode {
+	if errors.Is(err, errQueryingDisabled) {
+		return errorCodeQueryingDisabled
+	}
+	return errorCode
+}
+
+// noQueryClient is a dynatrace.ClientInterface that refuses all requests.
+// It allows tiles to be processed into SLO definitions without querying any data.
+type noQueryClient struct {
+	credentials *credentials.DynatraceCredentials
+}
+
+func newNoQueryClient(credentials *credentials.DynatraceCredentials) *noQueryClient {
+	return &noQueryClient{
+		credentials: credentials,
+	}
+}
+
+// Get refuses the get request.
+func (c *noQueryClient) Get(_ context.Context, _ string) ([]byte, error) {
+	return nil, errQueryingDisabled
+}
+
+// Post refuses the post request.
+func (c *noQueryClient) Post(_ context.Context, _ string, _ []byte) ([]byte, error) {
+	return nil, errQueryingDisabled
+}
+
+// Put refuses the put request.
+func (c *noQueryClient) Put(_ context.Context, _ string, _ []byte) ([]byte, error) {
+	return nil, errQueryingDisabled
+}
+
+// Delete refuses the delete request.
+func (c *noQueryClient) Delete(_ context.Context, _ string) ([]byte, error) {
+	return nil, errQueryingDisabled
+}
+
+// Credentials returns the credentials the client was created with, which may be nil.
+func (c *noQueryClient) Credentials() *credentials.DynatraceCredentials {
+	return c.credentials
+}
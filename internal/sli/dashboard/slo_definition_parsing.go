@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/slo"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,11 +18,50 @@ const (
 	sloDefKey     = "key"
 	sloDefWeight  = "weight"
 	sloDefExclude = "exclude"
+	sloDefField   = "field"
+	sloDefUnit    = "unit"
 )
 
+// sloDefinitionDefaults are the defaults applied to an SLO definition unless its title overrides them, e.g. as specified by a markdown tile for the tiles below it.
+type sloDefinitionDefaults struct {
+	weight  int
+	keySLI  bool
+	exclude bool
+
+	// keySLIConfigured is true if the key SLI default was explicitly configured and should therefore also override the key SLI setting of fixed SLO definitions.
+	keySLIConfigured bool
+
+	// relativeThresholds is true if Data Explorer tile thresholds specify relative rather than absolute criteria.
+	relativeThresholds bool
+}
+
+// newSLODefinitionDefaults creates the sloDefinitionDefaults used if no markdown tile specifies any: a weight of 1, no key SLI, not excluded and absolute thresholds.
+func newSLODefinitionDefaults() sloDefinitionDefaults {
+	return sloDefinitionDefaults{
+		weight:             1,
+		keySLI:             false,
+		exclude:            false,
+		relativeThresholds: false,
+	}
+}
+
+// applyToFixedSLODefinition applies the defaults to the fixed SLO definition of a tile whose title cannot specify one, e.g. an open problems tile.
+// The key SLI setting of the fixed SLO definition is only overridden if the key SLI default was explicitly configured.
+func (d sloDefinitionDefaults) applyToFixedSLODefinition(sloDefinition result.SLO) result.SLO {
+	sloDefinition.Weight = d.weight
+	if d.keySLIConfigured {
+		sloDefinition.KeySLI = d.keySLI
+	}
+	return sloDefinition
+}
+
 type sloDefinitionParsingResult struct {
 	sloDefinition result.SLO
 	exclude       bool
+	field         string
+
+	// unit is the ID of the unit the SLI values should be converted to or empty if no conversion is required.
+	unit string
 }
 
 // parseSLODefinition takes a value such as
@@ -28,19 +69,22 @@ type sloDefinitionParsingResult struct {
 //	Example 1: Some description;sli=teststep_rt;pass=<500ms,<+10%;warning=<1000ms,<+20%;weight=1;key=true
 //	Example 2: Response time (P95);sli=svc_rt_p95;pass=<+10%,<600
 //	Example 3: Host Disk Queue Length (max);sli=host_disk_queue;pass=<=0;warning=<1;key=false
+//	Example 4: Error budget;field=errorBudget;pass=>0
+//	Example 5: Response time;sli=svc_rt;unit=ms;pass=<500
 //
 // can also take a value like
 //
 //	"KQG;project=myproject;pass=90%;warning=75%;"
 //
+// Weight, key SLI and exclude are initialized from the specified defaults.
 // This will return a SLO object or an error if parsing was not possible
-func parseSLODefinition(flags ff.GetSLIFeatureFlags, sloDefinition string) (sloDefinitionParsingResult, error) {
+func parseSLODefinition(flags ff.GetSLIFeatureFlags, defaults sloDefinitionDefaults, sloDefinition string) (sloDefinitionParsingResult, error) {
 	res := sloDefinitionParsingResult{
 		sloDefinition: result.SLO{
-			Weight: 1,
-			KeySLI: false,
+			Weight: defaults.weight,
+			KeySLI: defaults.keySLI,
 		},
-		exclude: false,
+		exclude: defaults.exclude,
 	}
 	var errs []error
 
@@ -129,6 +173,33 @@ func parseSLODefinition(flags ff.GetSLIFeatureFlags, sloDefinition string) (sloD
 				break
 			}
 			res.exclude = val
+
+		case sloDefField:
+			if keyFound[sloDefField] {
+				errs = append(errs, &duplicateKeyError{key: sloDefField})
+				break
+			}
+			keyFound[sloDefField] = true
+
+			if !slo.IsValidField(kv.value) {
+				errs = append(errs, fmt.Errorf("invalid definition for '%s': unknown SLO field: %v", sloDefField, kv.value))
+				break
+			}
+			res.field = kv.value
+
+		case sloDefUnit:
+			if keyFound[sloDefUnit] {
+				errs = append(errs, &duplicateKeyError{key: sloDefUnit})
+				break
+			}
+			keyFound[sloDefUnit] = true
+
+			u, ok := unit.Lookup(kv.value)
+			if !ok {
+				errs = append(errs, fmt.Errorf("invalid definition for '%s': unknown unit: %v", sloDefUnit, kv.value))
+				break
+			}
+			res.unit = u.ID()
 		}
 	}
 
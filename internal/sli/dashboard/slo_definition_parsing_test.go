@@ -74,10 +74,25 @@ func TestParseSLODefinition_SuccessCases(t *testing.T) {
 			sloString: "example data explorer tile; exclude=true",
 			want:      createSLODefinitionParsingResult(true, "example_data_explorer_tile", "example data explorer tile", [][]string{}, [][]string{}, 1, false),
 		},
+		{
+			name:      "SLO field",
+			sloString: "Error budget;field=errorBudget;pass=>0",
+			want:      createSLODefinitionParsingResultWithField(createSLODefinitionParsingResult(false, "error_budget", "Error budget", [][]string{{">0"}}, [][]string{}, 1, false), "errorBudget"),
+		},
+		{
+			name:      "unit symbol",
+			sloString: "Response time;sli=svc_rt;unit=ms;pass=<500",
+			want:      createSLODefinitionParsingResultWithUnit(createSLODefinitionParsingResult(false, "svc_rt", "Response time", [][]string{{"<500"}}, [][]string{}, 1, false), "MilliSecond"),
+		},
+		{
+			name:      "unit ID in lowercase",
+			sloString: "Disk available;sli=disk_avail;unit=gibibyte",
+			want:      createSLODefinitionParsingResultWithUnit(createSLODefinitionParsingResult(false, "disk_avail", "Disk available", [][]string{}, [][]string{}, 1, false), "GibiByte"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseSLODefinition(ff.GetSLIFeatureFlags{}, tt.sloString)
+			got, err := parseSLODefinition(ff.GetSLIFeatureFlags{}, newSLODefinitionDefaults(), tt.sloString)
 			if assert.NoError(t, err) {
 				assert.EqualValues(t, tt.want, got)
 			}
@@ -218,6 +233,30 @@ func TestParseSLODefinition_ErrorCases(t *testing.T) {
 			want:        createSLODefinitionParsingResult(true, "first_name", "first_name", [][]string{{"<600"}}, [][]string{}, 1, false),
 			errMessages: []string{"'exclude'", "duplicate key"},
 		},
+		{
+			name:        "unknown SLO field",
+			sloString:   "sli=first_name;field=status",
+			want:        createSLODefinitionParsingResult(false, "first_name", "first_name", [][]string{}, [][]string{}, 1, false),
+			errMessages: []string{"'field'", "unknown SLO field", "status"},
+		},
+		{
+			name:        "duplicate SLO field",
+			sloString:   "sli=first_name;field=errorBudget;field=target",
+			want:        createSLODefinitionParsingResultWithField(createSLODefinitionParsingResult(false, "first_name", "first_name", [][]string{}, [][]string{}, 1, false), "errorBudget"),
+			errMessages: []string{"'field'", "duplicate key"},
+		},
+		{
+			name:        "unknown unit",
+			sloString:   "sli=first_name;unit=fortnight",
+			want:        createSLODefinitionParsingResult(false, "first_name", "first_name", [][]string{}, [][]string{}, 1, false),
+			errMessages: []string{"'unit'", "unknown unit", "fortnight"},
+		},
+		{
+			name:        "duplicate unit",
+			sloString:   "sli=first_name;unit=ms;unit=s",
+			want:        createSLODefinitionParsingResultWithUnit(createSLODefinitionParsingResult(false, "first_name", "first_name", [][]string{}, [][]string{}, 1, false), "MilliSecond"),
+			errMessages: []string{"'unit'", "duplicate key"},
+		},
 		{
 			name:        "duplication for sli, key, weight, exclude",
 			sloString:   "sli=first_name;weight=7;key=false;exclude=false;sli=last_name;pass=<600;weight=3;key=true;exclude=true",
@@ -227,7 +266,7 @@ func TestParseSLODefinition_ErrorCases(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseSLODefinition(ff.GetSLIFeatureFlags{}, tt.sloString)
+			got, err := parseSLODefinition(ff.GetSLIFeatureFlags{}, newSLODefinitionDefaults(), tt.sloString)
 			if assert.Error(t, err) {
 				for _, errMessage := range tt.errMessages {
 					assert.Contains(t, err.Error(), errMessage)
@@ -261,3 +300,13 @@ func createSLODefinitionParsingResult(exclude bool, indicatorName string, displa
 		},
 	}
 }
+
+func createSLODefinitionParsingResultWithField(parsingResult sloDefinitionParsingResult, field string) sloDefinitionParsingResult {
+	parsingResult.field = field
+	return parsingResult
+}
+
+func createSLODefinitionParsingResultWithUnit(parsingResult sloDefinitionParsingResult, unitID string) sloDefinitionParsingResult {
+	parsingResult.unit = unitID
+	return parsingResult
+}
@@ -3,12 +3,15 @@ package dashboard
 import (
 	"context"
 	"fmt"
-	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+
+	log "github.com/sirupsen/logrus"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
-	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/slo"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/slo"
+	v1slo "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/slo"
 )
 
 // SLOTileProcessing represents the processing of a SLO dashboard tile.
@@ -16,36 +19,50 @@ type SLOTileProcessing struct {
 	client       dynatrace.ClientInterface
 	timeframe    common.Timeframe
 	featureFlags ff.GetSLIFeatureFlags
+	sloDefaults  sloDefinitionDefaults
 }
 
 // NewSLOTileProcessing creates a new SLOTileProcessing.
-func NewSLOTileProcessing(client dynatrace.ClientInterface, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags) *SLOTileProcessing {
+func NewSLOTileProcessing(client dynatrace.ClientInterface, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags, sloDefaults sloDefinitionDefaults) *SLOTileProcessing {
 	return &SLOTileProcessing{
 		client:       client,
 		timeframe:    timeframe,
 		featureFlags: flags,
+		sloDefaults:  sloDefaults,
 	}
 }
 
 // Process processes the specified SLO dashboard tile.
+// The tile's title may specify the SLO field to be used as well as pass and warning criteria, weight and key SLI.
 func (p *SLOTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile) []result.SLIWithSLO {
 	if len(tile.AssignedEntities) == 0 {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(result.CreateInformationalSLO("slo_tile_without_slo"), "SLO tile contains no SLO IDs")}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(result.CreateInformationalSLO("slo_tile_without_slo"), "SLO tile contains no SLO IDs", result.ErrorCodeInvalidDefinition)}
+	}
+
+	sloDefinitionParsingResult, err := parseSLODefinition(p.featureFlags, p.sloDefaults, tile.Name)
+	if (err == nil) && (sloDefinitionParsingResult.exclude) {
+		log.WithField("tileName", tile.Name).Debug("Tile excluded as name includes exclude=true")
+		return nil
 	}
 
 	var results []result.SLIWithSLO
 	for _, sloID := range tile.AssignedEntities {
-		results = append(results, p.processSLO(ctx, sloID))
+		if err != nil {
+			results = append(results, result.NewFailedSLIWithSLO(result.CreateInformationalSLO(p.createFallbackIndicatorName(sloID)), "SLO tile title parsing error: "+err.Error(), result.ErrorCodeInvalidDefinition))
+			continue
+		}
+
+		results = append(results, p.processSLO(ctx, sloID, sloDefinitionParsingResult))
 	}
 	return results
 }
 
 // processSLO processes an SLO by querying the data from the Dynatrace API.
 // Returns a TileResult with sliResult, sliIndicatorName, sliQuery & sloDefinition
-func (p *SLOTileProcessing) processSLO(ctx context.Context, sloID string) result.SLIWithSLO {
-	query, err := slo.NewQuery(sloID)
+func (p *SLOTileProcessing) processSLO(ctx context.Context, sloID string, sloDefinitionParsingResult sloDefinitionParsingResult) result.SLIWithSLO {
+	query, err := v1slo.NewQuery(sloID, sloDefinitionParsingResult.field)
 	if err != nil {
-		return result.NewFailedSLIWithSLO(result.CreateInformationalSLO("slo_without_id"), err.Error())
+		return result.NewFailedSLIWithSLO(result.CreateInformationalSLO("slo_without_id"), err.Error(), result.ErrorCodeInvalidDefinition)
 	}
 
 	// Step 1: Query the Dynatrace API to get the actual value for this sloID
@@ -53,28 +70,41 @@ func (p *SLOTileProcessing) processSLO(ctx context.Context, sloID string) result
 	sloResult, err := dynatrace.NewSLOClient(p.client).Get(ctx, request)
 	if err != nil {
 		return result.NewFailedSLIWithSLO(
-			result.CreateInformationalSLO(
-				cleanIndicatorName(p.featureFlags.SkipLowercaseSLINames(), "slo_"+sloID)),
-			"error querying Service level objectives API: "+err.Error())
+			result.CreateInformationalSLO(p.createFallbackIndicatorName(sloID)),
+			"error querying Service level objectives API: "+err.Error(),
+			getQueryErrorCode(err, result.ErrorCodeQueryFailed))
 	}
 
-	indicatorName := cleanIndicatorName(p.featureFlags.SkipLowercaseSLINames(), sloResult.Name)
+	indicatorName := sloResult.Name
+	if query.GetField() != slo.EvaluatedPercentageField {
+		indicatorName = indicatorName + "_" + query.GetField()
+	}
 
-	// TODO: 2021-12-20: check: maybe in the future we will allow users to add additional SLO defs via the Tile Name, e.g: weight or KeySli
+	sloDefinition := result.SLO{
+		SLI:     cleanIndicatorName(p.featureFlags.SkipLowercaseSLINames(), indicatorName),
+		Pass:    sloDefinitionParsingResult.sloDefinition.Pass,
+		Warning: sloDefinitionParsingResult.sloDefinition.Warning,
+		Weight:  sloDefinitionParsingResult.sloDefinition.Weight,
+		KeySLI:  sloDefinitionParsingResult.sloDefinition.KeySLI,
+	}
 
+	// if no criteria are specified in the tile title, the evaluated percentage is compared with the thresholds of the SLO itself, all other fields are informational
 	// see https://github.com/keptn-contrib/dynatrace-sli-service/issues/97#issuecomment-766110172 for explanation about mappings to pass and warning
-	passCriterion := result.SLOCriteria{Criteria: []string{fmt.Sprintf(">=%f", sloResult.Warning)}}
-	warningCriterion := result.SLOCriteria{Criteria: []string{fmt.Sprintf(">=%f", sloResult.Target)}}
-
-	return result.NewSuccessfulSLIWithSLOAndQuery(
-		result.SLO{
-			SLI:     indicatorName,
-			Pass:    result.SLOCriteriaList{&passCriterion},
-			Warning: result.SLOCriteriaList{&warningCriterion},
-			Weight:  1,
-			KeySLI:  false,
-		},
-		sloResult.EvaluatedPercentage,
-		request.RequestString(),
-	)
+	if len(sloDefinition.Pass) == 0 && len(sloDefinition.Warning) == 0 && query.GetField() == slo.EvaluatedPercentageField {
+		passCriterion := result.SLOCriteria{Criteria: []string{fmt.Sprintf(">=%f", sloResult.Warning)}}
+		warningCriterion := result.SLOCriteria{Criteria: []string{fmt.Sprintf(">=%f", sloResult.Target)}}
+		sloDefinition.Pass = result.SLOCriteriaList{&passCriterion}
+		sloDefinition.Warning = result.SLOCriteriaList{&warningCriterion}
+	}
+
+	value, err := sloResult.GetFieldValue(query.GetField())
+	if err != nil {
+		return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error(), result.ErrorCodeUnexpectedResult)
+	}
+
+	return result.NewSuccessfulSLIWithSLOQueryAndUnit(sloDefinition, value, request.RequestString(), query.GetUnitID())
+}
+
+func (p *SLOTileProcessing) createFallbackIndicatorName(sloID string) string {
+	return cleanIndicatorName(p.featureFlags.SkipLowercaseSLINames(), "slo_"+sloID)
 }
@@ -0,0 +1,155 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/synthetic"
+)
+
+const syntheticIndicatorName = "synthetic"
+
+// SyntheticTileProcessing represents the processing of a synthetic monitor dashboard tile.
+type SyntheticTileProcessing struct {
+	client       dynatrace.ClientInterface
+	timeframe    common.Timeframe
+	featureFlags ff.GetSLIFeatureFlags
+	sloDefaults  sloDefinitionDefaults
+}
+
+// NewSyntheticTileProcessing creates a new SyntheticTileProcessing.
+func NewSyntheticTileProcessing(client dynatrace.ClientInterface, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags, sloDefaults sloDefinitionDefaults) *SyntheticTileProcessing {
+	return &SyntheticTileProcessing{
+		client:       client,
+		timeframe:    timeframe,
+		featureFlags: flags,
+		sloDefaults:  sloDefaults,
+	}
+}
+
+// Process processes the specified synthetic monitor dashboard tile.
+// For each monitor assigned to the tile, or if none are assigned, for each monitor of the tile's type matching the dashboard and tile filter, an availability SLI and an informational duration SLI are returned.
+// The tile's title may specify the SLI name, pass and warning criteria, weight and key SLI of the availability SLIs. If not specified, the SLI name is "synthetic" and the pass criteria is >= 100.
+func (p *SyntheticTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile, dashboardFilter *dynatrace.DashboardFilter) []result.SLIWithSLO {
+	sloDefinitionParsingResult, err := parseSLODefinition(p.featureFlags, p.sloDefaults, tile.Name)
+	if (err == nil) && (sloDefinitionParsingResult.exclude) {
+		log.WithField("tile.Name", tile.Name).Debug("Tile excluded as name includes exclude=true")
+		return nil
+	}
+
+	sloDefinition := sloDefinitionParsingResult.sloDefinition
+	if sloDefinition.SLI == "" {
+		sloDefinition.SLI = syntheticIndicatorName
+	}
+	if len(sloDefinition.Pass) == 0 {
+		sloDefinition.Pass = result.SLOCriteriaList{{Criteria: []string{">=100"}}}
+	}
+
+	if err != nil {
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "synthetic tile title parsing error: "+err.Error(), result.ErrorCodeInvalidDefinition)}
+	}
+
+	filter := NewFilter(dashboardFilter, tile.TileFilter)
+	if len(tile.AssignedEntities) > 0 {
+		// the assigned monitors are shown regardless of the filter
+		return filter.addWarningIfNotApplicable(p.processSyntheticMonitors(ctx, tile.AssignedEntities, sloDefinition))
+	}
+
+	monitorIDs, err := p.getMonitorIDs(ctx, tile.TileType, filter)
+	if err != nil {
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "error querying Monitored entities API v2 for synthetic monitors: "+err.Error(), getQueryErrorCode(err, result.ErrorCodeQueryFailed))}
+	}
+
+	if len(monitorIDs) == 0 {
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "no synthetic monitors match the dashboard and tile filter", result.ErrorCodeNoData)}
+	}
+
+	return p.processSyntheticMonitors(ctx, monitorIDs, sloDefinition)
+}
+
+// getMonitorIDs returns the IDs of all monitors shown by a synthetic tile of the specified type that match the filter.
+func (p *SyntheticTileProcessing) getMonitorIDs(ctx context.Context, tileType string, filter *Filter) ([]string, error) {
+	var monitorIDs []string
+	for _, entityType := range getSyntheticMonitorEntityTypes(tileType) {
+		// the tags can always be applied as the entity selector specifies an entity type
+		entitySelector, _ := filter.forEntitySelector(fmt.Sprintf("type(%q)", entityType))
+		entitySelector = appendToEntitySelector(entitySelector, filter.ForMZSelector())
+
+		entityIDs, err := dynatrace.NewEntitiesClient(p.client).GetIDsBySelector(ctx, entitySelector, p.timeframe)
+		if err != nil {
+			return nil, err
+		}
+		monitorIDs = append(monitorIDs, entityIDs...)
+	}
+	return monitorIDs, nil
+}
+
+// getSyntheticMonitorEntityTypes returns the entity types of the monitors shown by a synthetic tile of the specified type.
+func getSyntheticMonitorEntityTypes(tileType string) []string {
+	switch tileType {
+	case dynatrace.SyntheticSingleWebCheckTileType:
+		return []string{synthetic.BrowserMonitorEntityType}
+	case dynatrace.SyntheticHTTPMonitorTileType:
+		return []string{synthetic.HTTPMonitorEntityType}
+	default:
+		return []string{synthetic.BrowserMonitorEntityType, synthetic.HTTPMonitorEntityType}
+	}
+}
+
+func (p *SyntheticTileProcessing) processSyntheticMonitors(ctx context.Context, monitorIDs []string, sloDefinition result.SLO) []result.SLIWithSLO {
+	var results []result.SLIWithSLO
+	for _, monitorID := range monitorIDs {
+		availabilitySLODefinition := sloDefinition
+		availabilitySLODefinition.SLI = p.buildIndicatorName(sloDefinition.SLI, synthetic.AvailabilityMetric, monitorID)
+		availabilitySLODefinition.DisplayName = buildSyntheticDisplayName(sloDefinition.DisplayName, synthetic.AvailabilityMetric, monitorID)
+
+		durationSLODefinition := result.SLO{
+			SLI:         p.buildIndicatorName(sloDefinition.SLI, synthetic.DurationMetric, monitorID),
+			DisplayName: buildSyntheticDisplayName(sloDefinition.DisplayName, synthetic.DurationMetric, monitorID),
+			Weight:      sloDefinition.Weight,
+		}
+
+		results = append(results,
+			p.processSyntheticMonitor(ctx, monitorID, synthetic.AvailabilityMetric, availabilitySLODefinition),
+			p.processSyntheticMonitor(ctx, monitorID, synthetic.DurationMetric, durationSLODefinition))
+	}
+	return results
+}
+
+func (p *SyntheticTileProcessing) buildIndicatorName(baseIndicatorName string, metric string, monitorID string) string {
+	return cleanIndicatorName(p.featureFlags.SkipLowercaseSLINames(), baseIndicatorName+"_"+metric+"_"+monitorID)
+}
+
+func buildSyntheticDisplayName(baseDisplayName string, metric string, monitorID string) string {
+	if baseDisplayName == "" {
+		return ""
+	}
+
+	return baseDisplayName + " " + metric + " (" + monitorID + ")"
+}
+
+func (p *SyntheticTileProcessing) processSyntheticMonitor(ctx context.Context, monitorID string, metric string, sloDefinition result.SLO) result.SLIWithSLO {
+	query, err := synthetic.NewQuery(monitorID, metric)
+	if err != nil {
+		return result.NewFailedSLIWithSLO(sloDefinition, err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewSyntheticClientQueryRequest(*query, p.timeframe)
+	value, err := dynatrace.NewSyntheticClient(p.client).GetByQuery(ctx, request)
+	if err != nil {
+		var qpErrorType *dynatrace.MetricsQueryProcessingError
+		if errors.As(err, &qpErrorType) {
+			return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error(), getQueryErrorCode(err, dynatrace.GetErrorCodeFromMetricsError(err)))
+		}
+		return result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error(), getQueryErrorCode(err, dynatrace.GetErrorCodeFromMetricsError(err)))
+	}
+
+	return result.NewSuccessfulSLIWithSLOQueryAndUnit(sloDefinition, value, request.RequestString(), synthetic.GetUnitID(metric))
+}
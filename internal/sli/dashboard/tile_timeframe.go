@@ -0,0 +1,108 @@
+package dashboard
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// relativeTimePattern matches relative times as used by Dynatrace for tile timeframes and timeshifts, e.g. -2h, -7d or 1w.
+var relativeTimePattern = regexp.MustCompile(`^-?(\d+)([smhdwMy])$`)
+
+// relativeTime is an amount of a time unit, e.g. 2 hours or 1 week.
+type relativeTime struct {
+	amount int
+	unit   string
+}
+
+// parseRelativeTime parses a relative time such as -2h, -7d or 1w, ignoring the sign.
+func parseRelativeTime(value string) (*relativeTime, error) {
+	matches := relativeTimePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return nil, fmt.Errorf("unsupported relative time '%s'", value)
+	}
+
+	amount, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("unsupported relative time '%s': %w", value, err)
+	}
+
+	return &relativeTime{amount: amount, unit: matches[2]}, nil
+}
+
+// before returns the time the relative time before the specified time.
+func (r *relativeTime) before(t time.Time) time.Time {
+	switch r.unit {
+	case "s":
+		return t.Add(-time.Duration(r.amount) * time.Second)
+	case "m":
+		return t.Add(-time.Duration(r.amount) * time.Minute)
+	case "h":
+		return t.Add(-time.Duration(r.amount) * time.Hour)
+	case "d":
+		return t.AddDate(0, 0, -r.amount)
+	case "w":
+		return t.AddDate(0, 0, -7*r.amount)
+	case "M":
+		return t.AddDate(0, -r.amount, 0)
+	default:
+		return t.AddDate(-r.amount, 0, 0)
+	}
+}
+
+// parseTileTimeframe parses a tile timeframe such as -2h, "-2h to now" or "-2h to -1h" into its start and, unless it ends now, its end.
+func parseTileTimeframe(value string) (*relativeTime, *relativeTime, error) {
+	fromValue, toValue, hasTo := strings.Cut(value, " to ")
+	from, err := parseRelativeTime(fromValue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !hasTo || strings.TrimSpace(toValue) == "now" {
+		return from, nil, nil
+	}
+
+	to, err := parseRelativeTime(toValue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return from, to, nil
+}
+
+// getTileTimeframe gets the timeframe a tile should be evaluated against based on the event's timeframe.
+// Tile timeframes are relative to the end of the event's timeframe: -2h or "-2h to now" replaces the start of the timeframe, while "-2h to -1h" also replaces its end.
+// A timeshift such as -1w moves the resulting timeframe into the past, e.g. to compare with the same period one week ago.
+func getTileTimeframe(eventTimeframe common.Timeframe, tile *dynatrace.Tile) (*common.Timeframe, error) {
+	start := eventTimeframe.Start()
+	end := eventTimeframe.End()
+
+	if tile.TileFilter.Timeframe != "" {
+		from, to, err := parseTileTimeframe(tile.TileFilter.Timeframe)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tile timeframe: %w", err)
+		}
+
+		start = from.before(eventTimeframe.End())
+		if to != nil {
+			end = to.before(eventTimeframe.End())
+		}
+	}
+
+	if tile.TimeFrameShift != "" {
+		timeshift, err := parseRelativeTime(tile.TimeFrameShift)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tile timeshift: %w", err)
+		}
+
+		start = timeshift.before(start)
+		end = timeshift.before(end)
+	}
+
+	return common.NewTimeframe(start, end)
+}
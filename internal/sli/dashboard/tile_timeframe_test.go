@@ -0,0 +1,125 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+func TestGetTileTimeframe(t *testing.T) {
+	eventStart := time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC)
+	eventEnd := time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC)
+
+	eventTimeframe, err := common.NewTimeframe(eventStart, eventEnd)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		tileTimeframe  string
+		timeFrameShift string
+		expectedStart  time.Time
+		expectedEnd    time.Time
+		expectError    bool
+	}{
+		{
+			name:          "no tile timeframe or timeshift uses event timeframe",
+			expectedStart: eventStart,
+			expectedEnd:   eventEnd,
+		},
+		{
+			name:          "tile timeframe ends at end of event timeframe",
+			tileTimeframe: "-2h",
+			expectedStart: eventEnd.Add(-2 * time.Hour),
+			expectedEnd:   eventEnd,
+		},
+		{
+			name:          "tile timeframe to now",
+			tileTimeframe: "-30m to now",
+			expectedStart: eventEnd.Add(-30 * time.Minute),
+			expectedEnd:   eventEnd,
+		},
+		{
+			name:          "tile timeframe in weeks",
+			tileTimeframe: "-2w",
+			expectedStart: time.Date(2022, 9, 15, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   eventEnd,
+		},
+		{
+			name:           "timeshift moves event timeframe",
+			timeFrameShift: "-1w",
+			expectedStart:  time.Date(2022, 9, 21, 0, 0, 0, 0, time.UTC),
+			expectedEnd:    time.Date(2022, 9, 22, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "timeshift without sign",
+			timeFrameShift: "1d",
+			expectedStart:  time.Date(2022, 9, 27, 0, 0, 0, 0, time.UTC),
+			expectedEnd:    eventStart,
+		},
+		{
+			name:           "tile timeframe and timeshift",
+			tileTimeframe:  "-6h",
+			timeFrameShift: "-1M",
+			expectedStart:  time.Date(2022, 8, 28, 18, 0, 0, 0, time.UTC),
+			expectedEnd:    time.Date(2022, 8, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "tile timeframe range ends before end of event timeframe",
+			tileTimeframe: "-2h to -1h",
+			expectedStart: eventEnd.Add(-2 * time.Hour),
+			expectedEnd:   eventEnd.Add(-1 * time.Hour),
+		},
+		{
+			name:           "tile timeframe range and timeshift",
+			tileTimeframe:  "-2d to -1d",
+			timeFrameShift: "-1w",
+			expectedStart:  time.Date(2022, 9, 20, 0, 0, 0, 0, time.UTC),
+			expectedEnd:    time.Date(2022, 9, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "tile timeframe range ending before it starts",
+			tileTimeframe: "-1h to -2h",
+			expectError:   true,
+		},
+		{
+			name:          "unsupported end of tile timeframe range",
+			tileTimeframe: "-2h to today",
+			expectError:   true,
+		},
+		{
+			name:          "unsupported tile timeframe",
+			tileTimeframe: "today",
+			expectError:   true,
+		},
+		{
+			name:           "unsupported timeshift",
+			timeFrameShift: "-1 week",
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tile := &dynatrace.Tile{
+				TileFilter:     dynatrace.TileFilter{Timeframe: tt.tileTimeframe},
+				TimeFrameShift: tt.timeFrameShift,
+			}
+
+			timeframe, err := getTileTimeframe(*eventTimeframe, tile)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, timeframe)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStart, timeframe.Start())
+			assert.Equal(t, tt.expectedEnd, timeframe.End())
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/usql"
 )
 
@@ -25,23 +26,25 @@ type USQLTileProcessing struct {
 	customFilters []*keptnv2.SLIFilter
 	timeframe     common.Timeframe
 	featureFlags  ff.GetSLIFeatureFlags
+	sloDefaults   sloDefinitionDefaults
 }
 
 // NewUSQLTileProcessing creates a new USQLTileProcessing.
-func NewUSQLTileProcessing(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, customFilters []*keptnv2.SLIFilter, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags) *USQLTileProcessing {
+func NewUSQLTileProcessing(client dynatrace.ClientInterface, eventData adapter.EventContentAdapter, customFilters []*keptnv2.SLIFilter, timeframe common.Timeframe, flags ff.GetSLIFeatureFlags, sloDefaults sloDefinitionDefaults) *USQLTileProcessing {
 	return &USQLTileProcessing{
 		client:        client,
 		eventData:     eventData,
 		customFilters: customFilters,
 		timeframe:     timeframe,
 		featureFlags:  flags,
+		sloDefaults:   sloDefaults,
 	}
 }
 
 // Process processes the specified USQL dashboard tile.
 // TODO: 2022-03-07: Investigate if all error and warning cases are covered. E.g. what happens if a query returns no results?
 func (p *USQLTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile) []result.SLIWithSLO {
-	sloDefinitionParsingResult, err := parseSLODefinition(p.featureFlags, tile.CustomName)
+	sloDefinitionParsingResult, err := parseSLODefinition(p.featureFlags, p.sloDefaults, tile.CustomName)
 	if (err == nil) && (sloDefinitionParsingResult.exclude) {
 		log.WithField("tile.CustomName", tile.Name).Debug("Tile excluded as name includes exclude=true")
 		return nil
@@ -54,18 +57,18 @@ func (p *USQLTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile)
 	}
 
 	if err != nil {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "User Sessions Query tile title parsing error: "+err.Error())}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "User Sessions Query tile title parsing error: "+err.Error(), result.ErrorCodeInvalidDefinition)}
 	}
 
 	query, err := usql.NewQuery(tile.Query)
 	if err != nil {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "error creating USQL query: "+err.Error())}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLO(sloDefinition, "error creating USQL query: "+err.Error(), result.ErrorCodeInvalidDefinition)}
 	}
 
 	request := dynatrace.NewUSQLClientQueryRequest(*query, p.timeframe)
 	usqlResult, err := dynatrace.NewUSQLClient(p.client).GetByQuery(ctx, request)
 	if err != nil {
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), "error querying User sessions API: "+err.Error())}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), "error querying User sessions API: "+err.Error(), getQueryErrorCode(err, result.ErrorCodeQueryFailed))}
 	}
 
 	switch tile.Type {
@@ -75,33 +78,33 @@ func (p *USQLTileProcessing) Process(ctx context.Context, tile *dynatrace.Tile)
 		return processQueryResultForMultipleValues(*usqlResult, sloDefinition, tile.Type, request, p.featureFlags)
 	default:
 		// generate failed tile result specifically because it is unsupported
-		return []result.SLIWithSLO{result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), "unsupported USQL visualization type: "+tile.Type)}
+		return []result.SLIWithSLO{result.NewFailedSLIWithSLOAndQuery(sloDefinition, request.RequestString(), "unsupported USQL visualization type: "+tile.Type, result.ErrorCodeInvalidDefinition)}
 	}
 }
 
 func processQueryResultForSingleValue(usqlResult dynatrace.DTUSQLResult, sloDefinition result.SLO, request dynatrace.USQLClientQueryRequest) result.SLIWithSLO {
 	if len(usqlResult.Values) == 0 {
-		return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), zeroValuesMessage)
+		return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), zeroValuesMessage, result.ErrorCodeNoData)
 	}
 
 	if len(usqlResult.ColumnNames) != 1 || len(usqlResult.Values) != 1 {
-		return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), fmt.Sprintf("USQL visualization type %s should only return a single result", dynatrace.SingleValueVisualizationType))
+		return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), fmt.Sprintf("USQL visualization type %s should only return a single result", dynatrace.SingleValueVisualizationType), result.ErrorCodeUnexpectedResult)
 	}
 	dimensionValue, err := tryCastDimensionValueToNumeric(usqlResult.Values[0][0])
 	if err != nil {
-		return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error())
+		return result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), err.Error(), result.ErrorCodeUnexpectedResult)
 	}
 
-	return result.NewSuccessfulSLIWithSLOAndQuery(sloDefinition, dimensionValue, request.RequestString())
+	return result.NewSuccessfulSLIWithSLOQueryAndUnit(sloDefinition, dimensionValue, request.RequestString(), unit.Unspecified)
 }
 
 func processQueryResultForMultipleValues(usqlResult dynatrace.DTUSQLResult, sloDefinition result.SLO, visualizationType string, request dynatrace.USQLClientQueryRequest, flags ff.GetSLIFeatureFlags) []result.SLIWithSLO {
 	if len(usqlResult.Values) == 0 {
-		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), zeroValuesMessage)}
+		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), zeroValuesMessage, result.ErrorCodeNoData)}
 	}
 
 	if len(usqlResult.ColumnNames) < 2 {
-		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), fmt.Sprintf("USQL result type %s should have at least two columns", visualizationType))}
+		return []result.SLIWithSLO{result.NewWarningSLIWithSLOAndQuery(sloDefinition, request.RequestString(), fmt.Sprintf("USQL result type %s should have at least two columns", visualizationType), result.ErrorCodeUnexpectedResult)}
 	}
 
 	var tileResults []result.SLIWithSLO
@@ -161,7 +164,7 @@ func tryCastDimensionNameToString(dimensionName interface{}) (string, error) {
 }
 
 func newSuccessfulTileResultForDimensionNameAndValue(dimensionName string, dimensionValue float64, sloDefinition result.SLO, request dynatrace.USQLClientQueryRequest, flags ff.GetSLIFeatureFlags) result.SLIWithSLO {
-	return result.NewSuccessfulSLIWithSLOAndQuery(
+	return result.NewSuccessfulSLIWithSLOQueryAndUnit(
 		result.SLO{
 			SLI:         buildIndicatorNameWithDimensionName(flags, sloDefinition.SLI, dimensionName),
 			DisplayName: buildDisplayNameWithDimensionName(sloDefinition.DisplayName, dimensionName),
@@ -172,6 +175,7 @@ func newSuccessfulTileResultForDimensionNameAndValue(dimensionName string, dimen
 		},
 		dimensionValue,
 		request.RequestString(),
+		unit.Unspecified,
 	)
 }
 
@@ -187,6 +191,7 @@ func newWarningTileResultWithIndexFromSLODefinitionAndQuery(index int, sloDefini
 		},
 		request.RequestString(),
 		message,
+		result.ErrorCodeUnexpectedResult,
 	)
 }
 
@@ -0,0 +1,23 @@
+package dql
+
+import "errors"
+
+// Query encapsulates a DQL query.
+type Query struct {
+	query string
+}
+
+// NewQuery creates a new Query based on the provided DQL query or returns an error.
+func NewQuery(query string) (*Query, error) {
+	if query == "" {
+		return nil, errors.New("DQL query should not be empty")
+	}
+	return &Query{
+		query: query,
+	}, nil
+}
+
+// GetQuery returns the DQL query.
+func (m Query) GetQuery() string {
+	return m.query
+}
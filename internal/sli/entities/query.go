@@ -0,0 +1,31 @@
+package entities
+
+import "errors"
+
+// Query encapsulates a Monitored entities query.
+type Query struct {
+	entitySelector string
+	from           string
+}
+
+// NewQuery creates a new Query based on the provided entity selector and optional start of the timeframe or returns an error.
+func NewQuery(entitySelector string, from string) (*Query, error) {
+	if entitySelector == "" {
+		return nil, errors.New("entity selector should not be empty")
+	}
+
+	return &Query{
+		entitySelector: entitySelector,
+		from:           from,
+	}, nil
+}
+
+// GetEntitySelector returns the entity selector.
+func (q Query) GetEntitySelector() string {
+	return q.entitySelector
+}
+
+// GetFrom returns the start of the timeframe or an empty string if none was specified.
+func (q Query) GetFrom() string {
+	return q.from
+}
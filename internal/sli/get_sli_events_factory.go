@@ -7,20 +7,22 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 )
 
 func NewErroredGetSLIFinishedEventFactory(incomingEvent GetSLITriggeredAdapterInterface, err error) *GetSLIFinishedEventFactory {
 	return &GetSLIFinishedEventFactory{
 		incomingEvent: incomingEvent,
-		eventData:     newGetSLIFinishedEventData(incomingEvent, keptnv2.StatusErrored, keptnv2.ResultFailed, err.Error(), nil),
+		eventData:     newGetSLIFinishedEventData(incomingEvent, keptnv2.StatusErrored, keptnv2.ResultFailed, err.Error(), nil, nil),
 	}
 }
 
 func NewSuccessfulGetSLIFinishedEventFactoryFromError(incomingEvent GetSLITriggeredAdapterInterface, err error) *GetSLIFinishedEventFactory {
+	sliResults := makeSLIResultsForError(err, incomingEvent)
 	return &GetSLIFinishedEventFactory{
 		incomingEvent: incomingEvent,
-		eventData:     newGetSLIFinishedEventData(incomingEvent, keptnv2.StatusSucceeded, keptnv2.ResultFailed, err.Error(), makeSLIResultsForError(err, incomingEvent)),
+		eventData:     newGetSLIFinishedEventData(incomingEvent, keptnv2.StatusSucceeded, keptnv2.ResultFailed, err.Error(), sliResults, makeDiagnosticsForError(sliResults)),
 	}
 }
 
@@ -29,11 +31,11 @@ func NewSuccessfulGetSLIFinishedEventFactoryFromResults(incomingEvent GetSLITrig
 
 	return &GetSLIFinishedEventFactory{
 		incomingEvent: incomingEvent,
-		eventData:     newGetSLIFinishedEventData(incomingEvent, keptnv2.StatusSucceeded, resultSummarizer.OverallResult(), resultSummarizer.SummaryMessage(), convertResults(results)),
+		eventData:     newGetSLIFinishedEventData(incomingEvent, keptnv2.StatusSucceeded, resultSummarizer.OverallResult(), resultSummarizer.SummaryMessage(), convertResults(results), convertDiagnostics(results)),
 	}
 }
 
-func newGetSLIFinishedEventData(incomingEvent GetSLITriggeredAdapterInterface, status keptnv2.StatusType, result keptnv2.ResultType, message string, results []sliResult) *getSLIFinishedEventData {
+func newGetSLIFinishedEventData(incomingEvent GetSLITriggeredAdapterInterface, status keptnv2.StatusType, result keptnv2.ResultType, message string, results []sliResult, diagnostics []indicatorDiagnostics) *getSLIFinishedEventData {
 	return &getSLIFinishedEventData{
 		EventData: keptnv2.EventData{
 			Project: incomingEvent.GetProject(),
@@ -46,6 +48,7 @@ func newGetSLIFinishedEventData(incomingEvent GetSLITriggeredAdapterInterface, s
 		},
 		GetSLI: getSLIFinished{
 			IndicatorValues: results,
+			Diagnostics:     diagnostics,
 			Start:           incomingEvent.GetSLIStart(),
 			End:             incomingEvent.GetSLIEnd(),
 		},
@@ -66,15 +69,30 @@ type getSLIFinished struct {
 	End string `json:"end"`
 	// IndicatorValues defines the fetched SLI values
 	IndicatorValues []sliResult `json:"indicatorValues,omitempty"`
+	// Diagnostics defines structured details on how each SLI value was obtained
+	Diagnostics []indicatorDiagnostics `json:"diagnostics,omitempty"`
 }
 
-// sliResult is a simplified keptnv2.SLIResult with an additional query field.
+// sliResult is a simplified keptnv2.SLIResult with additional query and unit fields.
 type sliResult struct {
 	Metric  string  `json:"metric"`
 	Value   float64 `json:"value"`
 	Success bool    `json:"success"`
 	Message string  `json:"message,omitempty"`
 	Query   string  `json:"query,omitempty"`
+	Unit    string  `json:"unit,omitempty"`
+}
+
+// indicatorDiagnostics provides structured details on how the value of an indicator was obtained, so that failures can be grouped without parsing messages.
+type indicatorDiagnostics struct {
+	Metric         string   `json:"metric"`
+	ErrorCode      string   `json:"errorCode,omitempty"`
+	Warnings       []string `json:"warnings,omitempty"`
+	EntitySelector string   `json:"entitySelector,omitempty"`
+	Start          string   `json:"start,omitempty"`
+	End            string   `json:"end,omitempty"`
+	Unit           string   `json:"unit,omitempty"`
+	Retries        []string `json:"retries,omitempty"`
 }
 
 // GetSLIStartedEventFactory is a factory for get-sli.started cloud events.
@@ -143,6 +161,14 @@ func makeFailedResult(indicatorName string, err error) sliResult {
 	return sliResult{Metric: indicatorName, Success: false, Message: err.Error()}
 }
 
+func makeDiagnosticsForError(sliResults []sliResult) []indicatorDiagnostics {
+	diagnostics := make([]indicatorDiagnostics, len(sliResults))
+	for i, r := range sliResults {
+		diagnostics[i] = indicatorDiagnostics{Metric: r.Metric, ErrorCode: string(result.ErrorCodeProcessing)}
+	}
+	return diagnostics
+}
+
 // convertResults converts the indicator values to sliResults for serialization.
 func convertResults(results []result.SLIWithSLO) []sliResult {
 	convertedIndicatorValues := make([]sliResult, len(results))
@@ -154,7 +180,30 @@ func convertResults(results []result.SLIWithSLO) []sliResult {
 				Value:   sr.Value,
 				Success: sr.Success,
 				Message: sr.Message,
-				Query:   sr.Query}
+				Query:   sr.Query,
+				Unit:    sr.Unit}
 	}
 	return convertedIndicatorValues
 }
+
+// convertDiagnostics converts the diagnostics of the indicator values to indicatorDiagnostics for serialization.
+func convertDiagnostics(results []result.SLIWithSLO) []indicatorDiagnostics {
+	convertedDiagnostics := make([]indicatorDiagnostics, len(results))
+	for i, r := range results {
+		sr := r.SLIResult()
+		convertedDiagnostics[i] =
+			indicatorDiagnostics{
+				Metric:         sr.Metric,
+				ErrorCode:      string(sr.Diagnostics.ErrorCode),
+				Warnings:       sr.Diagnostics.Warnings,
+				EntitySelector: sr.Diagnostics.EntitySelector,
+				Unit:           sr.Unit,
+				Retries:        sr.Diagnostics.Retries,
+			}
+		if sr.Diagnostics.Timeframe != nil {
+			convertedDiagnostics[i].Start = timeutils.GetKeptnTimeStamp(sr.Diagnostics.Timeframe.Start())
+			convertedDiagnostics[i].End = timeutils.GetKeptnTimeStamp(sr.Diagnostics.Timeframe.End())
+		}
+	}
+	return convertedDiagnostics
+}
@@ -3,19 +3,19 @@ package sli
 import (
 	"context"
 	"fmt"
-	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+	"strconv"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/query"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
 
 	keptncommon "github.com/keptn/go-utils/pkg/lib"
 	log "github.com/sirupsen/logrus"
-
-	"github.com/keptn-contrib/dynatrace-service/internal/common"
 )
 
 const NoMetricIndicator = "no_metric"
@@ -28,6 +28,7 @@ type GetSLIEventHandler struct {
 	secretName        string
 	dashboardProperty string
 	featureFlags      ff.GetSLIFeatureFlags
+	dashboardCache    *dashboard.Cache
 }
 
 // configClientInterface is a subset of a keptn.ConfigClientInterface for processing sh.keptn.event.get-sli.triggered events.
@@ -40,11 +41,14 @@ type configClientInterface interface {
 	// GetSLOs gets the SLOs stored for exactly the specified project, stage and service.
 	GetSLOs(ctx context.Context, project string, stage string, service string) (*keptncommon.ServiceLevelObjectives, error)
 
+	// GetDashboard gets the dashboard resource with the specified URI for the specified project, stage and service, checking first on the service, then stage and then project level.
+	GetDashboard(ctx context.Context, project string, stage string, service string, resourceURI string) (string, error)
+
 	// UploadSLOs uploads the SLOs for the specified project, stage and service.
 	UploadSLOs(ctx context.Context, project string, stage string, service string, slos *keptncommon.ServiceLevelObjectives) error
 }
 
-func NewGetSLITriggeredHandler(event GetSLITriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eventSenderClient keptn.EventSenderClientInterface, configClient configClientInterface, secretName string, dashboardProperty string, flags ff.GetSLIFeatureFlags) GetSLIEventHandler {
+func NewGetSLITriggeredHandler(event GetSLITriggeredAdapterInterface, dtClient dynatrace.ClientInterface, eventSenderClient keptn.EventSenderClientInterface, configClient configClientInterface, secretName string, dashboardProperty string, flags ff.GetSLIFeatureFlags, dashboardCache *dashboard.Cache) GetSLIEventHandler {
 	return GetSLIEventHandler{
 		event:             event,
 		dtClient:          dtClient,
@@ -53,6 +57,7 @@ func NewGetSLITriggeredHandler(event GetSLITriggeredAdapterInterface, dtClient d
 		secretName:        secretName,
 		dashboardProperty: dashboardProperty,
 		featureFlags:      flags,
+		dashboardCache:    dashboardCache,
 	}
 }
 
@@ -119,18 +124,29 @@ func (eh *GetSLIEventHandler) getResults(ctx context.Context, timeframe common.T
 
 // getResultsFromDynatraceDashboard will process dynatrace dashboard (if found) and return SLIWithSLO
 func (eh *GetSLIEventHandler) getResultsFromDynatraceDashboard(ctx context.Context, timeframe common.Timeframe) ([]result.SLIWithSLO, error) {
-	d, err := dashboard.NewRetrieval(eh.dtClient, eh.event).Retrieve(ctx, eh.dashboardProperty)
+	d, err := dashboard.NewRetrieval(eh.dtClient, eh.event, eh.configClient, eh.dashboardCache).Retrieve(ctx, eh.dashboardProperty)
 	if err != nil {
 		return nil, dashboard.NewDashboardError(err)
 	}
 
-	eh.event.AddLabel("Dashboard Link", dashboard.NewLink(eh.dtClient.Credentials().GetTenant(), timeframe, d.ID, d.GetFilter()).String())
+	switch {
+	case dashboard.IsNotebookProperty(eh.dashboardProperty):
+		eh.event.AddLabel("Notebook Link", dashboard.NewNotebookLink(eh.dtClient.Credentials().GetTenant(), d.ID).String())
+	case dashboard.IsResourceProperty(eh.dashboardProperty):
+		// dashboards stored as resources do not exist on the tenant, so there is nothing to link to
+	default:
+		eh.event.AddLabel("Dashboard Link", dashboard.NewLink(eh.dtClient.Credentials().GetTenant(), timeframe, d.ID, d.GetFilter()).String())
+	}
 
-	results, err := dashboard.NewProcessing(eh.dtClient, eh.event, eh.event.GetCustomSLIFilters(), timeframe, eh.configClient, eh.featureFlags).Process(ctx, d)
+	processing := dashboard.NewProcessing(eh.dtClient, eh.event, eh.event.GetCustomSLIFilters(), timeframe, eh.configClient, eh.featureFlags)
+	results, err := processing.Process(ctx, d)
 	if err != nil {
 		return nil, dashboard.NewDashboardError(err)
 	}
 
+	// let users know whether slo.yaml was changed, as every upload results in a commit to the Keptn configuration repository
+	eh.event.AddLabel("SLOs Uploaded", strconv.FormatBool(processing.SLOsUploaded()))
+
 	return results, nil
 }
 
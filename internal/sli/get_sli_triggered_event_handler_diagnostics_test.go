@@ -0,0 +1,180 @@
+package sli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const testDiagnosticsEntitySelector = "type(SERVICE),tag(keptn_project:sockshop),tag(keptn_stage:staging)"
+
+// TestDiagnostics_SuccessfulMetricsQueryWithRetry tests that the diagnostics of a successful metrics query include the entity selector, timeframe, unit and the retry with resolution Inf.
+func TestDiagnostics_SuccessfulMetricsQueryWithRetry(t *testing.T) {
+	const testDataFolder = "./testdata/sli_files/basic/used_if_defined"
+
+	handler := test.NewCombinedURLHandler(t)
+	expectedMetricsRequest := addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInf(handler,
+		testDataFolder,
+		newMetricsV2QueryRequestBuilder("builtin:service.response.time:merge(\"dt.entity.service\"):percentile(95)").copyWithEntitySelector(testDiagnosticsEntitySelector),
+	)
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorResponseTimeP95: "metricSelector=builtin:service.response.time:merge(\"dt.entity.service\"):percentile(95)&entitySelector=" + testDiagnosticsEntitySelector,
+		},
+		testSLOsWithResponseTimeP95,
+	)
+
+	getSLIFinishedEventAssertionsFunc := createGetSLIFinishedEventDiagnosticsAssertionsFunc(getSLIFinishedEventSuccessAssertionsFunc,
+		indicatorDiagnostics{
+			Metric:         testIndicatorResponseTimeP95,
+			EntitySelector: testDiagnosticsEntitySelector,
+			Start:          testSLIStart,
+			End:            testSLIEnd,
+			Unit:           "MicroSecond",
+			Retries:        []string{"resolution=Inf"},
+		})
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorResponseTimeP95, getSLIFinishedEventAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorResponseTimeP95, 31846.08512740705, expectedMetricsRequest))
+}
+
+// TestDiagnostics_MetricsQueryReturnsNoResultsAndWarnings tests that the warnings returned by the Metrics API v2 are included in the diagnostics together with the error code.
+func TestDiagnostics_MetricsQueryReturnsNoResultsAndWarnings(t *testing.T) {
+	const testDataFolder = "./testdata/sli_files/metrics/no_results_due_to_entity_type"
+
+	expectedMetricsRequest := newMetricsV2QueryRequestBuilder("builtin:service.response.time:merge(\"dt.entity.services\"):percentile(95)").copyWithEntitySelector(testDiagnosticsEntitySelector).build()
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedMetricsRequest, filepath.Join(testDataFolder, "response_time_p95_200_0_result_warning_entity-type.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorResponseTimeP95: "metricSelector=builtin:service.response.time:merge(\"dt.entity.services\"):percentile(95)&entitySelector=" + testDiagnosticsEntitySelector,
+		},
+		testSLOsWithResponseTimeP95,
+	)
+
+	getSLIFinishedEventAssertionsFunc := createGetSLIFinishedEventDiagnosticsAssertionsFunc(getSLIFinishedEventWarningAssertionsFunc,
+		indicatorDiagnostics{
+			Metric:    testIndicatorResponseTimeP95,
+			ErrorCode: "no_data",
+			Warnings: []string{
+				"The dimension key `dt.entity.services` has been referenced, but `services` is not a known entity type.",
+				"The dimension key `dt.entity.services` has been referenced, but the metric has no such key.",
+			},
+			EntitySelector: testDiagnosticsEntitySelector,
+			Start:          testSLIStart,
+			End:            testSLIEnd,
+		})
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorResponseTimeP95, getSLIFinishedEventAssertionsFunc, createFailedSLIResultWithQueryAssertionsFunc(testIndicatorResponseTimeP95, expectedMetricsRequest, testErrorSubStringZeroMetricSeries))
+}
+
+// TestDiagnostics_InvalidDefinition tests that an SLI definition that cannot be parsed results in the corresponding error code.
+func TestDiagnostics_InvalidDefinition(t *testing.T) {
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorResponseTimeP95: "MV2;metricSelector=builtin:service.response.time",
+		},
+		testSLOsWithResponseTimeP95,
+	)
+
+	getSLIFinishedEventAssertionsFunc := createGetSLIFinishedEventDiagnosticsAssertionsFunc(getSLIFinishedEventFailureAssertionsFunc,
+		indicatorDiagnostics{
+			Metric:    testIndicatorResponseTimeP95,
+			ErrorCode: "invalid_definition",
+			Start:     testSLIStart,
+			End:       testSLIEnd,
+		})
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, test.NewEmptyURLHandler(t), configClient, testIndicatorResponseTimeP95, getSLIFinishedEventAssertionsFunc, createFailedSLIResultAssertionsFunc(testIndicatorResponseTimeP95, "error parsing MV2 query"))
+}
+
+// TestDiagnostics_CalcWithFailedInput tests that a calculated SLI whose input fails results in the dependency error code.
+func TestDiagnostics_CalcWithFailedInput(t *testing.T) {
+	const calcQuery = "CALC;expr=problem_count * 2"
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorCalcProblemCount: "PV2;problemSelector=",
+			testIndicatorCalc:             calcQuery,
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorCalc, "<=200")),
+	)
+
+	getSLIFinishedEventAssertionsFunc := createGetSLIFinishedEventDiagnosticsAssertionsFunc(getSLIFinishedEventFailureAssertionsFunc,
+		indicatorDiagnostics{
+			Metric:    testIndicatorCalc,
+			ErrorCode: "dependency",
+			Start:     testSLIStart,
+			End:       testSLIEnd,
+		})
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, test.NewEmptyURLHandler(t), configClient, testIndicatorCalc, getSLIFinishedEventAssertionsFunc, createFailedSLIResultWithQueryAssertionsFunc(testIndicatorCalc, calcQuery, "input indicators failed", testIndicatorCalcProblemCount))
+}
+
+// TestDiagnostics_ComparisonQueryFails tests that the diagnostics of an MV2 query whose comparison query fails include the warnings of both queries.
+func TestDiagnostics_ComparisonQueryFails(t *testing.T) {
+	const (
+		testDataFolder                  = "./testdata/sli_files/mv2_comparison/"
+		testIndicatorResponseTimeChange = "response_time_change"
+		testPreviousSLIStart            = "2022-09-27T00:00:00.000Z"
+	)
+
+	requestBuilder := newMetricsV2QueryRequestBuilder("builtin:service.response.time:splitBy()").copyWithResolution(resolutionInf)
+	expectedCurrentRequest := requestBuilder.build()
+	expectedPreviousRequest := requestBuilder.copyWithTimeframe(testPreviousSLIStart, testSLIStart).build()
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedCurrentRequest, filepath.Join(testDataFolder, "response_time_current_warning.json"))
+	handler.AddExact(expectedPreviousRequest, filepath.Join(testDataFolder, "response_time_previous_day_no_data_warning.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorResponseTimeChange: "MV2;MicroSecond;metricSelector=builtin:service.response.time:splitBy()&resolution=Inf&compareTo=previousTimeframe",
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorResponseTimeChange, "<=50")),
+	)
+
+	getSLIFinishedEventAssertionsFunc := createGetSLIFinishedEventDiagnosticsAssertionsFunc(getSLIFinishedEventWarningAssertionsFunc,
+		indicatorDiagnostics{
+			Metric:    testIndicatorResponseTimeChange,
+			ErrorCode: "no_data",
+			Warnings: []string{
+				"The dimension key `dt.entity.service` has been referenced, but no entities of that type exist in the timeframe.",
+				"The metric data points are incomplete for the requested timeframe.",
+			},
+			Start: testPreviousSLIStart,
+			End:   testSLIStart,
+		})
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorResponseTimeChange, getSLIFinishedEventAssertionsFunc, createFailedSLIResultWithQueryAssertionsFunc(testIndicatorResponseTimeChange, expectedCurrentRequest+";"+expectedPreviousRequest, testErrorSubStringZeroMetricSeries))
+}
+
+// TestDiagnostics_CustomChartingMetricDefinitionQueryFails tests that a custom charting tile whose metric definition cannot be retrieved results in the query failed rather than the invalid definition error code.
+func TestDiagnostics_CustomChartingMetricDefinitionQueryFails(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/custom_charting/metric_definition_query_fails/"
+
+	handler := createHandlerWithDashboard(t, testDataFolder)
+	handler.AddExactError(buildMetricsV2DefinitionRequestString("builtin:service.response.time"), 403, filepath.Join(testDataFolder, "metrics_get_by_id_missing_scope.json"))
+
+	getSLIFinishedEventAssertionsFunc := createGetSLIFinishedEventDiagnosticsAssertionsFunc(getSLIFinishedEventFailureAssertionsFunc,
+		indicatorDiagnostics{
+			Metric:    "service_response_time",
+			ErrorCode: "query_failed",
+			Start:     testSLIStart,
+			End:       testSLIEnd,
+		})
+
+	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventAssertionsFunc, createFailedSLIResultAssertionsFunc("service_response_time", "error querying Metrics API v2 for metric definition", "missing required scope"))
+}
+
+func createGetSLIFinishedEventDiagnosticsAssertionsFunc(getSLIFinishedEventAssertionsFunc func(*testing.T, *getSLIFinishedEventData), expectedDiagnostics ...indicatorDiagnostics) func(*testing.T, *getSLIFinishedEventData) {
+	return func(t *testing.T, data *getSLIFinishedEventData) {
+		getSLIFinishedEventAssertionsFunc(t, data)
+		assert.EqualValues(t, expectedDiagnostics, data.GetSLI.Diagnostics)
+	}
+}
@@ -332,19 +332,18 @@ func TestRetrieveMetricsFromDashboardCustomChartingTile_UnitTransformMillisecond
 	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, sliResultsAssertionsFuncs...)
 }
 
-// TestRetrieveMetricsFromDashboardCustomChartingTile_IncompatibleUnitTransformIsIgnored tests a custom charting tile that performs and incompatible unit transform performs no conversion and still succeeds due to the underlying API.
+// TestRetrieveMetricsFromDashboardCustomChartingTile_IncompatibleUnitTransformFails tests that a custom charting tile that performs an incompatible unit transform fails rather than the transform being ignored.
 // This is would require a misconfigured Custom charting tile to occur.
-func TestRetrieveMetricsFromDashboardCustomChartingTile_IncompatibleUnitTransformIsIgnored(t *testing.T) {
+func TestRetrieveMetricsFromDashboardCustomChartingTile_IncompatibleUnitTransformFails(t *testing.T) {
 	const testDataFolder = "./testdata/dashboards/custom_charting/unit_transform_error/"
 
 	requestBuilder := newMetricsV2QueryRequestBuilder("builtin:service.response.time:splitBy():avg:names").copyWithEntitySelector("type(SERVICE)")
 
 	handler := createHandlerWithDashboard(t, testDataFolder)
 	addRequestToHandlerForBaseMetricDefinition(handler, testDataFolder, "builtin:service.response.time")
+	handler.AddExactFile(buildMetricsV2DefinitionRequestString(requestBuilder.metricSelector()), filepath.Join(testDataFolder, metricsDefinitionFilename))
 
-	expectedMetricsRequest := addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInfAndUnitsConversionSnippet(handler, testDataFolder, requestBuilder, createToUnitConversionSnippet(microSecondUnitID, byteUnitID))
-
-	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("service_response_time", 54896.485186544574, expectedMetricsRequest))
+	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventFailureAssertionsFunc, createFailedSLIResultWithQueryAssertionsFunc("service_response_time", requestBuilder.build(), "cannot convert from 'MicroSecond' to 'Byte'"))
 }
 
 // TestRetrieveMetricsFromDashboardCustomChartingTile_ManagementZonesWork tests applying management zones to the dashboard and tile work as expected.
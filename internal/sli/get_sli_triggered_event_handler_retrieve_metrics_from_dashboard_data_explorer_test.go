@@ -210,7 +210,7 @@ func createSRTFailedSLIResultsAssertionsFuncsWithErrorSubstrings(expectedQuery s
 		createFailedSLIResultWithQueryAssertionsFunc("srt", expectedQuery, expectedMessageSubStrings...)}
 }
 
-// TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZonesWork tests applying management zones to the dashboard and tile work as expected.
+// TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZonesWork tests applying management zones, tags and entities to the dashboard and tile work as expected.
 func TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZonesWork(t *testing.T) {
 	const testDataFolder = "./testdata/dashboards/data_explorer/management_zones_work/"
 
@@ -244,9 +244,18 @@ func TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZonesWork(t *tes
 		},
 	}
 
+	tileFilterWithEntities := dynatrace.TileFilter{
+		Entities: []string{"SERVICE-FFD81F5CF6E2A4BB"},
+	}
+
+	dashboardFilterWithTags := dynatrace.DashboardFilter{
+		Tags: []string{"env:prod"},
+	}
+
 	requestBuilderWithNoManagementZone := newMetricsV2QueryRequestBuilder("(builtin:service.response.time:splitBy():sort(value(auto,descending)):limit(10)):limit(100):names")
 	requestBuilderWithManagementZone1 := requestBuilderWithNoManagementZone.copyWithMZSelector("mzName(\"ap_mz_1\")")
 	requestBuilderWithManagementZone2 := requestBuilderWithNoManagementZone.copyWithMZSelector("mzName(\"ap_mz_2\")")
+	requestBuilderWithEntities := requestBuilderWithNoManagementZone.copyWithEntitySelector("entityId(\"SERVICE-FFD81F5CF6E2A4BB\")")
 
 	tests := []struct {
 		name             string
@@ -254,6 +263,7 @@ func TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZonesWork(t *tes
 		tileFilter       dynatrace.TileFilter
 		requestBuilder   *metricsV2QueryRequestBuilder
 		expectedSLIValue float64
+		expectedWarning  string
 	}{
 		{
 			name:             "no_dashboard_filter_and_empty_tile_filter",
@@ -318,6 +328,22 @@ func TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZonesWork(t *tes
 			requestBuilder:   requestBuilderWithNoManagementZone,
 			expectedSLIValue: 54896.50455400265,
 		},
+		{
+			name:             "no_dashboard_filter_and_tile_filter_with_entities",
+			dashboardFilter:  nil,
+			tileFilter:       tileFilterWithEntities,
+			requestBuilder:   requestBuilderWithEntities,
+			expectedSLIValue: 54896.50455400265,
+		},
+		{
+			// tags cannot be applied as the entity type of the tile is unknown
+			name:             "dashboard_filter_with_tags_and_empty_tile_filter",
+			dashboardFilter:  &dashboardFilterWithTags,
+			tileFilter:       emptyTileFilter,
+			requestBuilder:   requestBuilderWithNoManagementZone,
+			expectedSLIValue: 54896.50455400265,
+			expectedWarning:  "dashboard tags cannot be applied without an entity type",
+		},
 	}
 
 	for _, tt := range tests {
@@ -336,11 +362,27 @@ func TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZonesWork(t *tes
 
 			testVariantDataFolder := filepath.Join(testDataFolder, tt.name)
 			metricsQueryRequest := addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInf(handler, testVariantDataFolder, tt.requestBuilder)
-			runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("srt", tt.expectedSLIValue, metricsQueryRequest))
+
+			sliResultAssertionsFunc := createSuccessfulSLIResultAssertionsFunc("srt", tt.expectedSLIValue, metricsQueryRequest)
+			if tt.expectedWarning != "" {
+				sliResultAssertionsFunc = createSuccessfulSLIResultWithMessageAssertionsFunc("srt", tt.expectedSLIValue, metricsQueryRequest, tt.expectedWarning)
+			}
+			runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, sliResultAssertionsFunc)
 		})
 	}
 }
 
+// TestRetrieveMetricsFromDashboardDataExplorerTile_TagsWithEntityDimension tests that dashboard tags are applied to data explorer tiles whose metric selector references the dimension of a single entity type.
+func TestRetrieveMetricsFromDashboardDataExplorerTile_TagsWithEntityDimension(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/data_explorer/tags_with_entity_dimension/"
+
+	handler := createHandlerWithDashboard(t, testDataFolder)
+	requestBuilder := newMetricsV2QueryRequestBuilder("(builtin:service.response.time:filter(eq(\"dt.entity.service\",\"SERVICE-FFD81F5CF6E2A4BB\")):splitBy():sort(value(auto,descending)):limit(10)):limit(100):names").copyWithEntitySelector("type(SERVICE),tag(\"env:prod\"),tag(\"team:a\")")
+	metricsQueryRequest := addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInf(handler, testDataFolder, requestBuilder)
+
+	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("srt", 54896.50455400265, metricsQueryRequest))
+}
+
 // TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZoneWithNoEntityType tests that data explorer tiles with a management zone and no obvious entity type work.
 func TestRetrieveMetricsFromDashboardDataExplorerTile_ManagementZoneWithNoEntityType(t *testing.T) {
 	const testDataFolder = "./testdata/dashboards/data_explorer/no_entity_type/"
@@ -520,6 +562,80 @@ func TestRetrieveMetricsFromDashboardDataExplorerTile_TileThresholdsWork(t *test
 	}
 }
 
+// TestRetrieveMetricsFromDashboardDataExplorerTile_RelativeTileThresholdsWork tests that thresholds on the tile are converted to relative pass and warning criteria if a markdown tile specifies KQG.Default.Thresholds=relative.
+func TestRetrieveMetricsFromDashboardDataExplorerTile_RelativeTileThresholdsWork(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/data_explorer/tile_thresholds_relative/"
+
+	requestBuilder := newMetricsV2QueryRequestBuilder("(builtin:service.response.time:splitBy():avg:auto:sort(value(avg,descending)):limit(10)):limit(100):names")
+
+	tests := []struct {
+		name        string
+		tileName    string
+		thresholds  dynatrace.VisualizationThreshold
+		expectedSLO *keptnapi.SLO
+	}{
+		{
+			name:        "Valid pass-warn-fail increasing thresholds and no pass or warning defined in title",
+			tileName:    "Service Response Time; sli=srt",
+			thresholds:  createVisibleThresholds(createPassThresholdRule(0), createWarnThresholdRule(10), createFailThresholdRule(20)),
+			expectedSLO: createExpectedServiceResponseTimeSLO(createRelativeSLOCriteria("<", 10), createRelativeSLOCriteria("<", 20)),
+		},
+		{
+			name:        "Valid fail-warn-pass increasing thresholds and no pass or warning defined in title",
+			tileName:    "Service Response Time; sli=srt",
+			thresholds:  createVisibleThresholds(createFailThresholdRule(-20), createWarnThresholdRule(-10), createPassThresholdRule(0)),
+			expectedSLO: createExpectedServiceResponseTimeSLO(createRelativeSLOCriteria(">=", 0), createRelativeSLOCriteria(">=", -10)),
+		},
+		{
+			name:        "Valid pass-none-fail increasing thresholds and no pass or warning defined in title",
+			tileName:    "Service Response Time; sli=srt",
+			thresholds:  createVisibleThresholds(createPassThresholdRule(0), createWarnThresholdRuleWithPointer(nil), createFailThresholdRule(15)),
+			expectedSLO: createExpectedServiceResponseTimeSLO(createRelativeSLOCriteria("<", 15), nil),
+		},
+		{
+			name:        "Valid fail-none-pass increasing thresholds and no pass or warning defined in title",
+			tileName:    "Service Response Time; sli=srt",
+			thresholds:  createVisibleThresholds(createFailThresholdRule(-15), createWarnThresholdRuleWithPointer(nil), createPassThresholdRule(-5)),
+			expectedSLO: createExpectedServiceResponseTimeSLO(createRelativeSLOCriteria(">=", -5), nil),
+		},
+		{
+			name:       "Pass or warning defined in title take precedence over valid thresholds",
+			tileName:   "Service Response Time; sli=srt; pass=<70000; warning=<71000",
+			thresholds: createVisibleThresholds(createPassThresholdRule(0), createWarnThresholdRule(10), createFailThresholdRule(20)),
+			expectedSLO: createExpectedServiceResponseTimeSLO(
+				[]*keptnapi.SLOCriteria{{Criteria: []string{"<70000"}}},
+				[]*keptnapi.SLOCriteria{{Criteria: []string{"<71000"}}}),
+		},
+	}
+
+	for _, thresholdTest := range tests {
+		t.Run(thresholdTest.name, func(t *testing.T) {
+
+			handler := createHandlerWithTemplatedDashboard(t,
+				filepath.Join(testDataFolder, dashboardTemplateFilename),
+				struct {
+					TileName         string
+					ThresholdsString string
+				}{
+					TileName:         thresholdTest.tileName,
+					ThresholdsString: convertToJSONString(t, thresholdTest.thresholds),
+				})
+
+			metricsRequest := addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInf(handler, testDataFolder, requestBuilder)
+
+			successfulSLIResultAssertionsFunc := createSuccessfulSLIResultAssertionsFunc("srt", 54896.50455400265, metricsRequest)
+
+			uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptn.ServiceLevelObjectives) {
+				if assert.Equal(t, 1, len(actual.Objectives)) {
+					assert.EqualValues(t, thresholdTest.expectedSLO, actual.Objectives[0])
+				}
+			}
+
+			runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, successfulSLIResultAssertionsFunc)
+		})
+	}
+}
+
 func createPassThresholdRule(value float64) dynatrace.VisualizationThresholdRule {
 	return createPassThresholdRuleWithPointer(&value)
 }
@@ -752,6 +868,10 @@ func createLowerBoundSLOCriteria(lowerBoundInclusive float64) []*keptnapi.SLOCri
 	return []*keptnapi.SLOCriteria{{Criteria: []string{createGreaterThanOrEqualSLOCriterion(lowerBoundInclusive)}}}
 }
 
+func createRelativeSLOCriteria(operator string, percentage float64) []*keptnapi.SLOCriteria {
+	return []*keptnapi.SLOCriteria{{Criteria: []string{fmt.Sprintf("%s%+f%%", operator, percentage)}}}
+}
+
 func createGreaterThanOrEqualSLOCriterion(v float64) string {
 	return fmt.Sprintf(">=%f", v)
 }
@@ -774,3 +894,28 @@ func convertToJSONString[T any](t *testing.T, o T) string {
 	}
 	return string(bytes)
 }
+
+// TestRetrieveMetricsFromDashboardDataExplorerTile_Expression tests that the visible expression of a Data Explorer tile is resolved using the hidden queries it references, and that these hidden queries do not produce SLIs of their own.
+func TestRetrieveMetricsFromDashboardDataExplorerTile_Expression(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/data_explorer/expression/"
+
+	requestBuilder := newMetricsV2QueryRequestBuilder("(builtin:service.errors.total.count:splitBy():sum)/(builtin:service.requestCount.total:splitBy():sum)*100")
+
+	handler := createHandlerWithDashboard(t, testDataFolder)
+	metricsRequest := addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInf(handler, testDataFolder, requestBuilder)
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		if !assert.EqualValues(t, 1, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "failure_rate",
+			DisplayName: "Failure rate",
+			Pass:        []*keptnapi.SLOCriteria{{Criteria: []string{"<=5"}}},
+			Weight:      1,
+		}, actual.Objectives[0])
+	}
+
+	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("failure_rate", 2.5, metricsRequest))
+}
@@ -4,6 +4,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/keptn-contrib/dynatrace-service/internal/test"
 )
 
@@ -185,10 +187,10 @@ func TestRetrieveMetricsFromDashboardDataExplorerTile_UnitTransform(t *testing.T
 			resolution:     resolutionNull,
 			metricSelector: serviceResponseTimeMetricSelector,
 			handlerAdditionalSetupFunc: func(handler *test.CombinedURLHandler, testVariantDataFolder string) {
-				addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInfAndUnitsConversionSnippet(handler, testVariantDataFolder, serviceResponseTimeRequestBuilder, createToUnitConversionSnippet(microSecondUnitID, byteUnitID))
+				addRequestToHandlerForMetricDefinition(handler, testVariantDataFolder, serviceResponseTimeRequestBuilder)
 			},
-			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
-			sliResultAssertionsFuncs:          toSlice(createSuccessfulSLIResultAssertionsFunc(sliName, unconvertedServiceResponseTimeValue, serviceResponseTimeRequestBuilder.copyWithResolution(resolutionInf).copyWithMetricSelectorConversionSnippet(createToUnitConversionSnippet(microSecondUnitID, byteUnitID)).build())),
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventFailureAssertionsFunc,
+			sliResultAssertionsFuncs:          toSlice(createFailedSLIResultWithQueryAssertionsFunc(sliName, serviceResponseTimeRequestBuilder.build(), "cannot convert from 'MicroSecond' to 'Byte'")),
 		},
 		{
 			name:           "error_srt_thousand",
@@ -505,6 +507,77 @@ func TestRetrieveMetricsFromDashboardDataExplorerTile_UnitTransform(t *testing.T
 	}
 }
 
+// TestRetrieveMetricsFromDashboardDataExplorerTile_UnitFromTitle tests that a unit specified in the tile title takes precedence over the tile's unit transform and is stated in the SLI result.
+func TestRetrieveMetricsFromDashboardDataExplorerTile_UnitFromTitle(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/data_explorer/title_unit/"
+
+	const serviceResponseTimeMetricSelector = "(builtin:service.response.time:splitBy():avg:auto:sort(value(avg,descending)):limit(10)):limit(100):names"
+	requestBuilder := newMetricsV2QueryRequestBuilder(serviceResponseTimeMetricSelector)
+	expectedMetricsRequest := requestBuilder.copyWithResolution(resolutionInf).copyWithMetricSelectorConversionSnippet(createToUnitConversionSnippet(microSecondUnitID, milliSecondUnitID)).build()
+
+	successfulSLIResultAssertionsFunc := func(t *testing.T, actual sliResult) {
+		createSuccessfulSLIResultAssertionsFunc("sli", 54.896485186544574, expectedMetricsRequest)(t, actual)
+		assert.Equal(t, milliSecondUnitID, actual.Unit)
+	}
+
+	tests := []struct {
+		name                              string
+		title                             string
+		unitTransform                     string
+		handlerRequired                   bool
+		getSLIFinishedEventAssertionsFunc func(t *testing.T, actual *getSLIFinishedEventData)
+		sliResultAssertionsFunc           func(t *testing.T, actual sliResult)
+	}{
+		{
+			name:                              "unit symbol in title with auto unit transform",
+			title:                             "SLI;unit=ms",
+			unitTransform:                     "auto",
+			handlerRequired:                   true,
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
+			sliResultAssertionsFunc:           successfulSLIResultAssertionsFunc,
+		},
+		{
+			name:                              "unit ID in title overrides unit transform",
+			title:                             "SLI;unit=MilliSecond",
+			unitTransform:                     "Day",
+			handlerRequired:                   true,
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
+			sliResultAssertionsFunc:           successfulSLIResultAssertionsFunc,
+		},
+		{
+			name:                              "unknown unit in title",
+			title:                             "SLI;unit=fortnight",
+			unitTransform:                     "auto",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventFailureAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultAssertionsFunc("sli", "unknown unit", "fortnight"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := createHandlerWithTemplatedDashboard(t,
+				filepath.Join(testDataFolder, dashboardTemplateFilename),
+				struct {
+					Title          string
+					Unit           string
+					MetricSelector string
+					Resolution     string
+				}{
+					Title:          tt.title,
+					Unit:           tt.unitTransform,
+					MetricSelector: serviceResponseTimeMetricSelector,
+					Resolution:     "null",
+				})
+
+			if tt.handlerRequired {
+				addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInfAndUnitsConversionSnippet(handler, testDataFolder, requestBuilder, createToUnitConversionSnippet(microSecondUnitID, milliSecondUnitID))
+			}
+
+			runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, tt.getSLIFinishedEventAssertionsFunc, tt.sliResultAssertionsFunc)
+		})
+	}
+}
+
 func addRequestsToHandlerForFailedMetricsQueryWithUnitsConversionSnippet(handler *test.CombinedURLHandler, testDataFolder string, requestBuilder *metricsV2QueryRequestBuilder, metricSelectorConversionSnippet string) string {
 	handler.AddExactFile(buildMetricsV2DefinitionRequestString(requestBuilder.metricSelector()), filepath.Join(testDataFolder, metricsDefinitionFilename))
 
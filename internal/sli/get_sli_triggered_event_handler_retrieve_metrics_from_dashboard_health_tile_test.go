@@ -0,0 +1,52 @@
+package sli
+
+import (
+	"path/filepath"
+	"testing"
+
+	keptnapi "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+// TestRetrieveMetricsFromDashboardHealthTiles_Success tests that host and service health tiles produce SLIs counting the distinct unhealthy entities, respecting the dashboard and tile management zones.
+func TestRetrieveMetricsFromDashboardHealthTiles_Success(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/health_tiles/success/"
+
+	expectedHostsRequest := buildProblemsV2RequestWithEntitySelector("status(\"open\"),managementZones(\"ap_mz_1\")", "type(\"HOST\")")
+	expectedServicesRequest := buildProblemsV2RequestWithEntitySelector("status(\"open\"),managementZones(\"ap_mz_2\")", "type(\"SERVICE\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(expectedHostsRequest, filepath.Join(testDataFolder, "problems_hosts.json"))
+	handler.AddExact(expectedServicesRequest, filepath.Join(testDataFolder, "problems_services.json"))
+
+	sliResultsAssertionsFuncs := []func(t *testing.T, actual sliResult){
+		createSuccessfulSLIResultAssertionsFunc("unhealthy_hosts", 2, expectedHostsRequest),
+		createSuccessfulSLIResultAssertionsFunc("unhealthy_services", 1, expectedServicesRequest),
+	}
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		if !assert.EqualValues(t, 2, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:    "unhealthy_hosts",
+			Pass:   []*keptnapi.SLOCriteria{{Criteria: []string{"<=0"}}},
+			Weight: 1,
+			KeySLI: true,
+		}, actual.Objectives[0])
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:    "unhealthy_services",
+			Pass:   []*keptnapi.SLOCriteria{{Criteria: []string{"<=0"}}},
+			Weight: 1,
+			KeySLI: true,
+		}, actual.Objectives[1])
+	}
+
+	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, sliResultsAssertionsFuncs...)
+}
@@ -238,6 +238,31 @@ func TestRetrieveMetricsFromDashboard_MarkdownParsingErrors(t *testing.T) {
 			markdown:       "KQG.Total.Warning=OneHundred",
 			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, dashboard.TotalWarning, "OneHundred"),
 		},
+		{
+			name:           "invalid value for default weight",
+			markdown:       "KQG.Default.Weight=0",
+			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, dashboard.DefaultWeight, "0"),
+		},
+		{
+			name:           "invalid value for default key",
+			markdown:       "KQG.Default.Key=yes",
+			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, dashboard.DefaultKey, "yes"),
+		},
+		{
+			name:           "duplicate default exclude",
+			markdown:       "KQG.Default.Exclude=true;KQG.Default.Exclude=false",
+			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, dashboard.DefaultExclude, duplicationError),
+		},
+		{
+			name:           "invalid value for default thresholds",
+			markdown:       "KQG.Default.Thresholds=percent",
+			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, dashboard.DefaultThresholds, "percent"),
+		},
+		{
+			name:           "invalid value for tile timeframes",
+			markdown:       "KQG.Tile.Timeframes=yes",
+			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, dashboard.TileTimeframes, "yes"),
+		},
 		{
 			name:     "multiple problems - one for each",
 			markdown: "KQG.Total.Pass=96Pct;KQG.Total.Warning=OneHundred;KQG.Compare.WithScore=passing;KQG.Compare.Results=7.5;KQG.Compare.Function=p97;",
@@ -271,7 +296,7 @@ func TestRetrieveMetricsFromDashboard_MarkdownParsingErrors(t *testing.T) {
 }
 
 // TestRetrieveMetricsFromDashboard_MarkdownMultipleTilesErrors tests multiple markdown tiles per dashboard without errors in the SLO definition.
-// This will result in a failing SLIResult, as it is not allowed to have multiple markdown tiles for configuration.
+// This will result in a failing SLIResult, as only the first markdown tile may configure the total score and comparison.
 func TestRetrieveMetricsFromDashboard_MarkdownMultipleTilesErrors(t *testing.T) {
 	type data struct {
 		MarkdownTileOne string // needs to match template file variable
@@ -280,7 +305,7 @@ func TestRetrieveMetricsFromDashboard_MarkdownMultipleTilesErrors(t *testing.T)
 
 	const templateFile = "./testdata/dashboards/markdown/markdown-tile-parsing-errors-multiple-tiles-template.json"
 
-	const multipleTilesErrorMsg = "only one markdown tile allowed"
+	const multipleTilesErrorMsg = "total score and comparison may only be configured in the first markdown tile"
 
 	tests := []struct {
 		name           string
@@ -300,6 +325,12 @@ func TestRetrieveMetricsFromDashboard_MarkdownMultipleTilesErrors(t *testing.T)
 			secondMarkdown: "KQG.Total.Pass=94%;KQG.Total.Warning=74%;KQG.Compare.WithScore=all;KQG.Compare.Results=5;KQG.Compare.Function=p90;",
 			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, multipleTilesErrorMsg),
 		},
+		{
+			name:           "tile timeframes in second tile",
+			firstMarkdown:  "KQG.Total.Pass=90%",
+			secondMarkdown: "KQG.Tile.Timeframes=true",
+			assertionsFunc: createFailedSLIResultAssertionsFunc(testIndicatorNoMetric, "tile timeframes may only be configured in the first markdown tile"),
+		},
 	}
 	for _, markdownTest := range tests {
 		t.Run(markdownTest.name, func(t *testing.T) {
@@ -317,3 +348,57 @@ func TestRetrieveMetricsFromDashboard_MarkdownMultipleTilesErrors(t *testing.T)
 		})
 	}
 }
+
+// TestRetrieveMetricsFromDashboard_MarkdownGrouping tests that the first markdown tile configures the total score and comparison, while later markdown tiles set the default weight, key SLI and exclude for the tiles below them.
+func TestRetrieveMetricsFromDashboard_MarkdownGrouping(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/markdown/grouping/"
+
+	expectedUSQLRequest := buildUSQLRequest("SELECT AVG(duration) FROM usersession")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, testDataFolder+"dashboard.json")
+	handler.AddExact(expectedUSQLRequest, testDataFolder+"usql_result_table.json")
+
+	sliResultsAssertionsFuncs := []func(t *testing.T, actual sliResult){
+		createSuccessfulSLIResultAssertionsFunc("session_duration_a", 87793.1776896271, expectedUSQLRequest),
+		createSuccessfulSLIResultAssertionsFunc("session_duration_b", 87793.1776896271, expectedUSQLRequest),
+		createSuccessfulSLIResultAssertionsFunc("session_duration_c", 87793.1776896271, expectedUSQLRequest),
+		createSuccessfulSLIResultAssertionsFunc("session_duration_e", 87793.1776896271, expectedUSQLRequest),
+	}
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		assert.EqualValues(t, &keptnapi.SLOScore{Pass: "80%", Warning: "60%"}, actual.TotalScore)
+		if !assert.EqualValues(t, 4, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "session_duration_a",
+			DisplayName: "Session duration A",
+			Weight:      1,
+		}, actual.Objectives[0])
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "session_duration_b",
+			DisplayName: "Session duration B",
+			Pass:        []*keptnapi.SLOCriteria{{Criteria: []string{"<=100"}}},
+			Weight:      2,
+			KeySLI:      true,
+		}, actual.Objectives[1])
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "session_duration_c",
+			DisplayName: "Session duration C",
+			Weight:      2,
+			KeySLI:      false,
+		}, actual.Objectives[2])
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "session_duration_e",
+			DisplayName: "Session duration E",
+			Weight:      1,
+		}, actual.Objectives[3])
+	}
+
+	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, sliResultsAssertionsFuncs...)
+}
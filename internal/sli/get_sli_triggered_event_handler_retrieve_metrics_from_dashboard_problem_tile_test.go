@@ -45,6 +45,32 @@ func TestRetrieveMetricsFromDashboardProblemTile_Success(t *testing.T) {
 	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, sliResultsAssertionsFuncs...)
 }
 
+// TestRetrieveMetricsFromDashboardProblemTile_MarkdownDefaults tests that the default weight and key SLI set by a markdown tile are applied to a problems tile below it.
+func TestRetrieveMetricsFromDashboardProblemTile_MarkdownDefaults(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/problem_tile/markdown_defaults/"
+
+	expectedProblemsRequest := buildProblemsV2Request("status(\"open\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(expectedProblemsRequest, filepath.Join(testDataFolder, "problems_status_open.json"))
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		if !assert.EqualValues(t, 1, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:    "problems",
+			Pass:   []*keptnapi.SLOCriteria{{Criteria: []string{"<=0"}}},
+			Weight: 3,
+			KeySLI: false,
+		}, actual.Objectives[0])
+	}
+
+	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("problems", 42, expectedProblemsRequest))
+}
+
 // TestRetrieveMetricsFromDashboardProblemTile_ManagementZonesWork tests applying management zones to the dashboard and tile work as expected.
 func TestRetrieveMetricsFromDashboardProblemTile_ManagementZonesWork(t *testing.T) {
 	const testDataFolder = "./testdata/dashboards/problem_tile/management_zones_work/"
@@ -176,6 +202,32 @@ func TestRetrieveMetricsFromDashboardProblemTile_ManagementZonesWork(t *testing.
 	}
 }
 
+// TestRetrieveMetricsFromDashboardProblemTile_TagsAndEntitiesWork tests that dashboard tags and tile entities are applied to the problem and entity selectors.
+func TestRetrieveMetricsFromDashboardProblemTile_TagsAndEntitiesWork(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/problem_tile/tags_and_entities/"
+
+	expectedProblemsRequest := buildProblemsV2RequestWithEntitySelector("status(\"open\"),entityTags(\"env:prod\")", "entityId(\"SERVICE-FFD81F5CF6E2A4BB\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(expectedProblemsRequest, filepath.Join(testDataFolder, "problems_status_open.json"))
+
+	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("problems", 42, expectedProblemsRequest))
+}
+
+// TestRetrieveMetricsFromDashboardProblemTile_MultipleTagsAreNotApplied tests that multiple dashboard tags are not applied to the problem selector, as entities must have all tags, and that the SLI result includes a warning.
+func TestRetrieveMetricsFromDashboardProblemTile_MultipleTagsAreNotApplied(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/problem_tile/multiple_tags/"
+
+	expectedProblemsRequest := buildProblemsV2RequestWithEntitySelector("status(\"open\")", "entityId(\"SERVICE-FFD81F5CF6E2A4BB\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(expectedProblemsRequest, filepath.Join(testDataFolder, "problems_status_open.json"))
+
+	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultWithMessageAssertionsFunc("problems", 42, expectedProblemsRequest, "dashboard tags cannot be applied without an entity type"))
+}
+
 // TestRetrieveMetricsFromDashboardProblemTile_MissingScopes tests the failure case for retrieving the problem and security problem count SLIs in response to a problems dashboard tile.
 // Retrieving SLIs fails because the the API token is missing the required scopes.
 func TestRetrieveMetricsFromDashboardProblemTile_MissingScopes(t *testing.T) {
@@ -0,0 +1,76 @@
+package sli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const testDashboardResourceURI = "dynatrace/kqg-dashboard.json"
+
+// TestRetrieveMetricsFromDashboardResource_Success tests that a dashboard stored as a Keptn resource is processed like a tenant dashboard and that no dashboard link label is added.
+func TestRetrieveMetricsFromDashboardResource_Success(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/usql_tiles/single_value_visualization/"
+
+	expectedUSQLRequest := buildUSQLRequest("SELECT AVG(duration) FROM usersession")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedUSQLRequest, filepath.Join(testDataFolder, "usql_result_table.json"))
+
+	dashboardResource, err := os.ReadFile(filepath.Join(testDataFolder, "dashboard.json"))
+	require.NoError(t, err)
+
+	configClient := newConfigClientMockWithDashboardResource(t, testDashboardResourceURI, string(dashboardResource))
+	eventData := createTestGetSLIEventDataWithIndicators([]string{testIndicatorResponseTimeP95})
+
+	runGetSLIsFromDashboardTestWithConfigClientAndDashboardParameterAndCheckSLIs(t, handler, configClient, eventData, "resource:"+testDashboardResourceURI, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("usql_metric", 87793.1776896271, expectedUSQLRequest))
+
+	assert.True(t, configClient.slosUploaded)
+	assert.NotContains(t, eventData.GetLabels(), "Dashboard Link")
+}
+
+// TestRetrieveMetricsFromDashboardResource_NotFound tests that a missing dashboard resource results in a failure.
+func TestRetrieveMetricsFromDashboardResource_NotFound(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	configClient := newConfigClientMockWithDashboardResource(t, testDashboardResourceURI, "{}")
+
+	runGetSLIsFromDashboardTestWithConfigClientAndDashboardParameterAndCheckSLIs(t, handler, configClient, testGetSLIEventData, "resource:dynatrace/other-dashboard.json", createGetSLIFinishedEventFailureAssertionsFuncWithMessageSubstrings("could not retrieve dashboard", "dynatrace/other-dashboard.json"), createFailedSLIResultAssertionsFunc(testIndicatorNoMetric))
+}
+
+// TestRetrieveMetricsFromDashboardResource_InvalidJSON tests that a dashboard resource that is not valid JSON results in a failure.
+func TestRetrieveMetricsFromDashboardResource_InvalidJSON(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	configClient := newConfigClientMockWithDashboardResource(t, testDashboardResourceURI, "{ invalid")
+
+	runGetSLIsFromDashboardTestWithConfigClientAndDashboardParameterAndCheckSLIs(t, handler, configClient, testGetSLIEventData, "resource:"+testDashboardResourceURI, createGetSLIFinishedEventFailureAssertionsFuncWithMessageSubstrings("could not retrieve dashboard", "could not unmarshal Dynatrace dashboard"), createFailedSLIResultAssertionsFunc(testIndicatorNoMetric))
+}
+
+// getDashboardAndUploadSLOsConfigClientMock is a mock implementation of configClientInterface which provides a single dashboard resource and allows SLOs to be uploaded.
+type getDashboardAndUploadSLOsConfigClientMock struct {
+	uploadSLOsConfigClientMock
+	resourceURI string
+	dashboard   string
+}
+
+func newConfigClientMockWithDashboardResource(t *testing.T, resourceURI string, dashboard string) *getDashboardAndUploadSLOsConfigClientMock {
+	return &getDashboardAndUploadSLOsConfigClientMock{
+		uploadSLOsConfigClientMock: uploadSLOsConfigClientMock{t: t},
+		resourceURI:                resourceURI,
+		dashboard:                  dashboard,
+	}
+}
+
+func (m *getDashboardAndUploadSLOsConfigClientMock) GetDashboard(_ context.Context, project string, stage string, service string, resourceURI string) (string, error) {
+	if resourceURI != m.resourceURI {
+		return "", keptn.NewResourceNotFoundError(resourceURI, project, stage, service)
+	}
+
+	return m.dashboard, nil
+}
@@ -43,6 +43,37 @@ func TestRetrieveMetricsFromDashboardSLOTile_SLOFound(t *testing.T) {
 	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, sliResultsAssertionsFuncs...)
 }
 
+// TestRetrieveMetricsFromDashboardSLOTile_ErrorBudgetField tests that the SLO field and criteria can be specified in the tile title.
+func TestRetrieveMetricsFromDashboardSLOTile_ErrorBudgetField(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/slo_tiles/error_budget_field/"
+	const sliName = testIndicatorStaticSLOPass + "_errorbudget"
+
+	expectedSLORequest := buildSLORequest("7d07efde-b714-3e6e-ad95-08490e2540c4")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(expectedSLORequest, filepath.Join(testDataFolder, "slo_7d07efde-b714-3e6e-ad95-08490e2540c4.json"))
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		if !assert.NotNil(t, actual) {
+			return
+		}
+
+		if !assert.EqualValues(t, 1, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:     sliName,
+			Pass:    []*keptnapi.SLOCriteria{{Criteria: []string{">0"}}},
+			Warning: []*keptnapi.SLOCriteria{{Criteria: []string{">=50"}}},
+			Weight:  1,
+		}, actual.Objectives[0])
+	}
+
+	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(sliName, 80, expectedSLORequest))
+}
+
 // TestRetrieveMetricsFromDashboardSLOTile_TileWithNoIDs tests that an unsuccessful tile result is produced for SLO tiles reference no SLOs.
 func TestRetrieveMetricsFromDashboardSLOTile_TileWithNoIDs(t *testing.T) {
 	const testDataFolder = "./testdata/dashboards/slo_tiles/tile_no_slo_ids/"
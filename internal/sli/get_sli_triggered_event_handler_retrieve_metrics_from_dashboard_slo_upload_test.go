@@ -0,0 +1,72 @@
+package sli
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	keptnapi "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+// TestRetrieveMetricsFromDashboard_SLOUploadOnlyIfChanged tests that SLOs are only uploaded if they differ from the stored SLOs and that the outcome is recorded in a label.
+func TestRetrieveMetricsFromDashboard_SLOUploadOnlyIfChanged(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/problem_tile/problem_tile_success/"
+
+	problemsSLO := &keptnapi.SLO{
+		SLI:    "problems",
+		Pass:   []*keptnapi.SLOCriteria{{Criteria: []string{"<=0"}}},
+		Weight: 1,
+		KeySLI: true,
+	}
+
+	tests := []struct {
+		name                 string
+		storedSLOs           *keptnapi.ServiceLevelObjectives
+		expectedSLOsUploaded bool
+	}{
+		{
+			name:                 "no stored SLOs",
+			expectedSLOsUploaded: true,
+		},
+		{
+			name:                 "identical stored SLOs",
+			storedSLOs:           createTestSLOs(problemsSLO),
+			expectedSLOsUploaded: false,
+		},
+		{
+			name:                 "stored SLOs with different criteria",
+			storedSLOs:           createTestSLOs(createTestSLOWithPassCriterion("problems", "<=1")),
+			expectedSLOsUploaded: true,
+		},
+		{
+			name: "stored SLOs with different total score",
+			storedSLOs: &keptnapi.ServiceLevelObjectives{
+				Objectives: []*keptnapi.SLO{problemsSLO},
+				TotalScore: &keptnapi.SLOScore{Pass: "80%", Warning: "60%"},
+				Comparison: createTestSLOs().Comparison,
+			},
+			expectedSLOsUploaded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectedProblemsRequest := buildProblemsV2Request("status(\"open\")")
+
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+			handler.AddExact(expectedProblemsRequest, filepath.Join(testDataFolder, "problems_status_open.json"))
+
+			configClient := &uploadSLOsConfigClientMock{t: t, storedSLOs: tt.storedSLOs}
+			eventData := createTestGetSLIEventDataWithIndicators([]string{testIndicatorResponseTimeP95})
+
+			runGetSLIsFromDashboardTestWithConfigClientAndDashboardParameterAndCheckSLIs(t, handler, configClient, eventData, testDashboardID, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("problems", 42, expectedProblemsRequest))
+
+			assert.Equal(t, tt.expectedSLOsUploaded, configClient.slosUploaded)
+			assert.Equal(t, strconv.FormatBool(tt.expectedSLOsUploaded), eventData.GetLabels()["SLOs Uploaded"])
+		})
+	}
+}
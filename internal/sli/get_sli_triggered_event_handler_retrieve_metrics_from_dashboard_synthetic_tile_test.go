@@ -0,0 +1,120 @@
+package sli
+
+import (
+	"path/filepath"
+	"testing"
+
+	keptnapi "github.com/keptn/go-utils/pkg/lib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+// TestRetrieveMetricsFromDashboardSyntheticTile_Success tests the success case for retrieving the availability and duration SLIs in response to a synthetic monitor dashboard tile with an assigned monitor.
+// The SLI names are derived from the tile's title and the availability SLI uses the default pass criteria.
+func TestRetrieveMetricsFromDashboardSyntheticTile_Success(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/synthetic_tile/synthetic_tile_success/"
+
+	expectedAvailabilityRequest := buildSyntheticMetricsRequest("builtin:synthetic.browser.availability.location.total", "SYNTHETIC_TEST-7A3B9E0F1C2D4E5F")
+	expectedDurationRequest := buildSyntheticMetricsRequest("builtin:synthetic.browser.totalDuration", "SYNTHETIC_TEST-7A3B9E0F1C2D4E5F")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(expectedAvailabilityRequest, filepath.Join(testDataFolder, "metrics_availability.json"))
+	handler.AddExact(expectedDurationRequest, filepath.Join(testDataFolder, "metrics_duration.json"))
+
+	sliResultsAssertionsFuncs := []func(t *testing.T, actual sliResult){
+		createSuccessfulSLIResultAssertionsFunc("browser_monitor_availability_synthetic_test-7a3b9e0f1c2d4e5f", 97.91666666666667, expectedAvailabilityRequest),
+		createSuccessfulSLIResultAssertionsFunc("browser_monitor_duration_synthetic_test-7a3b9e0f1c2d4e5f", 2804.5, expectedDurationRequest),
+	}
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		if !assert.NotNil(t, actual) {
+			return
+		}
+
+		if !assert.EqualValues(t, 2, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "browser_monitor_availability_synthetic_test-7a3b9e0f1c2d4e5f",
+			DisplayName: "Browser monitor availability (SYNTHETIC_TEST-7A3B9E0F1C2D4E5F)",
+			Pass:        []*keptnapi.SLOCriteria{{Criteria: []string{">=100"}}},
+			Weight:      1,
+		}, actual.Objectives[0])
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "browser_monitor_duration_synthetic_test-7a3b9e0f1c2d4e5f",
+			DisplayName: "Browser monitor duration (SYNTHETIC_TEST-7A3B9E0F1C2D4E5F)",
+			Weight:      1,
+		}, actual.Objectives[1])
+	}
+
+	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, sliResultsAssertionsFuncs...)
+}
+
+// TestRetrieveMetricsFromDashboardSyntheticTile_AllMonitorsMatchingFilter tests that all browser and HTTP monitors matching the dashboard filter are queried for a synthetic tests tile without assigned monitors.
+// The SLI name, criteria and weight are taken from the tile's title.
+func TestRetrieveMetricsFromDashboardSyntheticTile_AllMonitorsMatchingFilter(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/synthetic_tile/synthetic_tests_tile_success/"
+
+	expectedBrowserAvailabilityRequest := buildSyntheticMetricsRequest("builtin:synthetic.browser.availability.location.total", "SYNTHETIC_TEST-7A3B9E0F1C2D4E5F")
+	expectedBrowserDurationRequest := buildSyntheticMetricsRequest("builtin:synthetic.browser.totalDuration", "SYNTHETIC_TEST-7A3B9E0F1C2D4E5F")
+	expectedHTTPAvailabilityRequest := buildSyntheticMetricsRequest("builtin:synthetic.http.availability.location.total", "HTTP_CHECK-1C2D3E4F5A6B7C8D")
+	expectedHTTPDurationRequest := buildSyntheticMetricsRequest("builtin:synthetic.http.duration.geo", "HTTP_CHECK-1C2D3E4F5A6B7C8D")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(buildEntitiesListRequest("type(\"SYNTHETIC_TEST\"),mzName(\"ap_mz_1\")"), filepath.Join(testDataFolder, "entities_browser_monitors.json"))
+	handler.AddExact(buildEntitiesListRequest("type(\"HTTP_CHECK\"),mzName(\"ap_mz_1\")"), filepath.Join(testDataFolder, "entities_http_monitors.json"))
+	handler.AddExact(expectedBrowserAvailabilityRequest, filepath.Join(testDataFolder, "metrics_browser_availability.json"))
+	handler.AddExact(expectedBrowserDurationRequest, filepath.Join(testDataFolder, "metrics_browser_duration.json"))
+	handler.AddExact(expectedHTTPAvailabilityRequest, filepath.Join(testDataFolder, "metrics_http_availability.json"))
+	handler.AddExact(expectedHTTPDurationRequest, filepath.Join(testDataFolder, "metrics_http_duration.json"))
+
+	sliResultsAssertionsFuncs := []func(t *testing.T, actual sliResult){
+		createSuccessfulSLIResultAssertionsFunc("monitor_availability_synthetic_test-7a3b9e0f1c2d4e5f", 97.91666666666667, expectedBrowserAvailabilityRequest),
+		createSuccessfulSLIResultAssertionsFunc("monitor_duration_synthetic_test-7a3b9e0f1c2d4e5f", 2804.5, expectedBrowserDurationRequest),
+		createSuccessfulSLIResultAssertionsFunc("monitor_availability_http_check-1c2d3e4f5a6b7c8d", 100, expectedHTTPAvailabilityRequest),
+		createSuccessfulSLIResultAssertionsFunc("monitor_duration_http_check-1c2d3e4f5a6b7c8d", 312.25, expectedHTTPDurationRequest),
+	}
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		if !assert.NotNil(t, actual) {
+			return
+		}
+
+		if !assert.EqualValues(t, 4, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "monitor_availability_synthetic_test-7a3b9e0f1c2d4e5f",
+			DisplayName: "Synthetic monitors availability (SYNTHETIC_TEST-7A3B9E0F1C2D4E5F)",
+			Pass:        []*keptnapi.SLOCriteria{{Criteria: []string{">=99"}}},
+			Warning:     []*keptnapi.SLOCriteria{{Criteria: []string{">=95"}}},
+			Weight:      2,
+		}, actual.Objectives[0])
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "monitor_duration_synthetic_test-7a3b9e0f1c2d4e5f",
+			DisplayName: "Synthetic monitors duration (SYNTHETIC_TEST-7A3B9E0F1C2D4E5F)",
+			Weight:      2,
+		}, actual.Objectives[1])
+	}
+
+	runGetSLIsFromDashboardTestAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, sliResultsAssertionsFuncs...)
+}
+
+// TestRetrieveMetricsFromDashboardSyntheticTile_NoMonitorsMatchFilter tests that a synthetic HTTP monitor tile without assigned monitors fails if no HTTP monitors match the dashboard filter.
+func TestRetrieveMetricsFromDashboardSyntheticTile_NoMonitorsMatchFilter(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/synthetic_tile/synthetic_tests_tile_no_monitors/"
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(buildEntitiesListRequest("type(\"HTTP_CHECK\"),mzName(\"ap_mz_1\")"), filepath.Join(testDataFolder, "entities_http_monitors.json"))
+
+	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventFailureAssertionsFunc, createFailedSLIResultAssertionsFunc("synthetic_monitors", "no synthetic monitors match the dashboard and tile filter"))
+}
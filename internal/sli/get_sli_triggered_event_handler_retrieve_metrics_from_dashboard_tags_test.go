@@ -0,0 +1,39 @@
+package sli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const testDashboardsByTagRequest = dynatrace.DashboardsPath + "?tags=keptn_project%3Asockshop"
+
+// TestRetrieveMetricsFromDashboardDiscoveredByTags_Success tests that the dashboard tagged with the event's service takes precedence over the project-level dashboard and that dashboards for other services are ignored.
+func TestRetrieveMetricsFromDashboardDiscoveredByTags_Success(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/tags/success/"
+
+	expectedProblemsRequest := buildProblemsV2Request("status(\"open\"),managementZones(\"ap_mz_1\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(testDashboardsByTagRequest, filepath.Join(testDataFolder, "dashboards_tags.json"))
+	handler.AddExact(dynatrace.DashboardsPath+"/12345678-1111-4444-8888-123456789012", filepath.Join(testDataFolder, "dashboard_project.json"))
+	handler.AddExact(dynatrace.DashboardsPath+"/12345678-2222-4444-8888-123456789012", filepath.Join(testDataFolder, "dashboard_carts.json"))
+	handler.AddExact(dynatrace.DashboardsPath+"/12345678-3333-4444-8888-123456789012", filepath.Join(testDataFolder, "dashboard_orders.json"))
+	handler.AddExact(expectedProblemsRequest, filepath.Join(testDataFolder, "problems.json"))
+
+	runGetSLIsFromDashboardTestWithDashboardParameterAndCheckSLIs(t, handler, testGetSLIEventData, "tags", getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("problems", 42, expectedProblemsRequest))
+}
+
+// TestRetrieveMetricsFromDashboardDiscoveredByTags_MultipleMatchesFails tests that discovery fails if several dashboards match with the same precedence.
+func TestRetrieveMetricsFromDashboardDiscoveredByTags_MultipleMatchesFails(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/tags/ambiguous/"
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(testDashboardsByTagRequest, filepath.Join(testDataFolder, "dashboards_tags.json"))
+	handler.AddExact(dynatrace.DashboardsPath+"/12345678-1111-4444-8888-123456789012", filepath.Join(testDataFolder, "dashboard_1.json"))
+	handler.AddExact(dynatrace.DashboardsPath+"/12345678-2222-4444-8888-123456789012", filepath.Join(testDataFolder, "dashboard_2.json"))
+
+	runGetSLIsFromDashboardTestWithDashboardParameterAndCheckSLIs(t, handler, testGetSLIEventData, "tags", createGetSLIFinishedEventFailureAssertionsFuncWithMessageSubstrings("2 dashboards match the tag criteria"), createFailedSLIResultAssertionsFunc(testIndicatorNoMetric))
+}
@@ -139,19 +139,23 @@ func TestQueryDynatraceDashboardForSLIs(t *testing.T) {
 
 	expectedSLORequest := buildSLORequest("7d07efde-b714-3e6e-ad95-08490e2540c4")
 	expectedProblemsV2Request := buildProblemsV2Request("status(\"open\"),managementZones(\"Keptn: keptn07project\")")
+	expectedHostsProblemsV2Request := buildProblemsV2RequestWithEntitySelector("status(\"open\")", "type(\"HOST\")")
+	expectedServicesProblemsV2Request := buildProblemsV2RequestWithEntitySelector("status(\"open\")", "type(\"SERVICE\")")
 
 	handler := test.NewFileBasedURLHandler(t)
 	handler.AddExact(dynatrace.DashboardsPath, filepath.Join(testDataFolder, "dashboards_query.json"))
 	handler.AddExact(dynatrace.DashboardsPath+"/12345678-1111-4444-8888-123456789012", filepath.Join(testDataFolder, "dashboard.json"))
 	handler.AddExact(expectedSLORequest, filepath.Join(testDataFolder, "slo_7d07efde-b714-3e6e-ad95-08490e2540c4.json"))
 	handler.AddExact(expectedProblemsV2Request, filepath.Join(testDataFolder, "problems.json"))
+	handler.AddExact(expectedHostsProblemsV2Request, filepath.Join(testDataFolder, "problems.json"))
+	handler.AddExact(expectedServicesProblemsV2Request, filepath.Join(testDataFolder, "problems.json"))
 
 	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
 		if !assert.NotNil(t, actual) {
 			return
 		}
 
-		assert.Equal(t, 2, len(actual.Objectives))
+		assert.Equal(t, 4, len(actual.Objectives))
 		assert.EqualValues(t, &keptnapi.SLOScore{Pass: "90%", Warning: "70%"}, actual.TotalScore)
 		assert.EqualValues(
 			t,
@@ -167,6 +171,8 @@ func TestQueryDynatraceDashboardForSLIs(t *testing.T) {
 	sliResultsAssertionsFuncs := []func(t *testing.T, actual sliResult){
 		createSuccessfulSLIResultAssertionsFunc(testIndicatorStaticSLOPass, 95, expectedSLORequest),
 		createSuccessfulSLIResultAssertionsFunc("problems", 0, expectedProblemsV2Request),
+		createSuccessfulSLIResultAssertionsFunc("unhealthy_services", 0, expectedServicesProblemsV2Request),
+		createSuccessfulSLIResultAssertionsFunc("unhealthy_hosts", 0, expectedHostsProblemsV2Request),
 	}
 
 	runGetSLIsFromDashboardTestWithDashboardParameterAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, testDashboardQuery, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, sliResultsAssertionsFuncs...)
@@ -248,6 +254,11 @@ func (m *uploadSLOsWillFailConfigClientMock) GetSLOs(_ context.Context, _ string
 	return nil, nil
 }
 
+func (m *uploadSLOsWillFailConfigClientMock) GetDashboard(_ context.Context, _ string, _ string, _ string, _ string) (string, error) {
+	m.t.Fatalf("GetDashboard() should not be needed in this mock!")
+	return "", nil
+}
+
 func (m *uploadSLOsWillFailConfigClientMock) UploadSLOs(_ context.Context, _ string, _ string, _ string, _ *keptnapi.ServiceLevelObjectives) error {
 	m.t.Fatalf("UploadSLOs() should not be needed in this mock!")
 	return nil
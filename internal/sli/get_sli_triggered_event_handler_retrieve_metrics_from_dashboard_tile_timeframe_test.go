@@ -0,0 +1,53 @@
+package sli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+// TestRetrieveMetricsFromDashboard_TileTimeframes tests that tiles are evaluated against their own timeframe and timeshift only if this is enabled using the markdown tile.
+func TestRetrieveMetricsFromDashboard_TileTimeframes(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/tile_timeframe/"
+
+	tests := []struct {
+		name                    string
+		expectedProblemsRequest string
+	}{
+		{
+			name:                    "enabled",
+			expectedProblemsRequest: buildProblemsV2RequestWithTimeframe("status(\"open\")", "2022-09-21T22:00:00.000Z", "2022-09-22T00:00:00.000Z"),
+		},
+		{
+			name:                    "disabled",
+			expectedProblemsRequest: buildProblemsV2Request("status(\"open\")"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, tt.name, "dashboard.json"))
+			handler.AddExact(tt.expectedProblemsRequest, filepath.Join(testDataFolder, "problems_status_open.json"))
+
+			runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("problems", 42, tt.expectedProblemsRequest))
+		})
+	}
+}
+
+// TestRetrieveMetricsFromDashboard_TileTimeframesUnsupported tests that an unsupported tile timeframe only results in a failure for the affected tile if tile timeframes are enabled.
+func TestRetrieveMetricsFromDashboard_TileTimeframesUnsupported(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/tile_timeframe/unsupported/"
+
+	expectedHostsRequest := buildProblemsV2RequestWithEntitySelector("status(\"open\")", "type(\"HOST\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DashboardsPath+"/"+testDashboardID, filepath.Join(testDataFolder, "dashboard.json"))
+	handler.AddExact(expectedHostsRequest, filepath.Join(testDataFolder, "problems_hosts.json"))
+
+	runGetSLIsFromDashboardTestAndCheckSLIs(t, handler, testGetSLIEventData, getSLIFinishedEventFailureAssertionsFunc,
+		createFailedSLIResultAssertionsFunc("problems", "could not determine timeframe of tile 'Problems'", "today"),
+		createSuccessfulSLIResultAssertionsFunc("unhealthy_hosts", 2, expectedHostsRequest))
+}
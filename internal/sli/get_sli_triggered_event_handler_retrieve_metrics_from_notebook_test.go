@@ -0,0 +1,95 @@
+package sli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	keptnapi "github.com/keptn/go-utils/pkg/lib"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const testNotebookID = "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f"
+
+// TestRetrieveMetricsFromNotebook_Success tests that DQL sections of a notebook with an SLO definition title produce SLIs and SLOs, while the markdown section configures the total score and comparison.
+func TestRetrieveMetricsFromNotebook_Success(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/notebook/success/"
+
+	expectedDQLRequest := buildDQLRequest("fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DocumentsPath+"/"+testNotebookID+"/content", filepath.Join(testDataFolder, "notebook.json"))
+	handler.AddExact(dynatrace.DQLExecutePath, filepath.Join(testDataFolder, "dql_execute_succeeded.json"))
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		assert.EqualValues(t, createSLO("90%", "70%", "single_result", "pass", 1, "avg", &keptnapi.SLO{
+			SLI:         "error_count",
+			DisplayName: "Error log records",
+			Pass:        []*keptnapi.SLOCriteria{{Criteria: []string{"<=20"}}},
+			Warning:     []*keptnapi.SLOCriteria{{Criteria: []string{"<=50"}}},
+			Weight:      1,
+			KeySLI:      true,
+		}), actual)
+	}
+
+	runGetSLIsFromDashboardTestWithDashboardParameterAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, "notebook:"+testNotebookID, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc, createSuccessfulSLIResultAssertionsFunc("error_count", 12, expectedDQLRequest))
+}
+
+// TestRetrieveMetricsFromNotebook_MarkdownGrouping tests that markdown sections of a notebook set the defaults for the sections following them, even though all tiles converted from a notebook have the same bounds.
+func TestRetrieveMetricsFromNotebook_MarkdownGrouping(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/notebook/grouping/"
+
+	expectedDQLRequest := buildDQLRequest("fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DocumentsPath+"/"+testNotebookID+"/content", filepath.Join(testDataFolder, "notebook.json"))
+	handler.AddExact(dynatrace.DQLExecutePath, filepath.Join(testDataFolder, "dql_execute_succeeded.json"))
+
+	uploadedSLOsAssertionsFunc := func(t *testing.T, actual *keptnapi.ServiceLevelObjectives) {
+		assert.EqualValues(t, &keptnapi.SLOScore{Pass: "90%", Warning: "70%"}, actual.TotalScore)
+		if !assert.EqualValues(t, 2, len(actual.Objectives)) {
+			return
+		}
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "error_count_a",
+			DisplayName: "Error log records A",
+			Weight:      1,
+		}, actual.Objectives[0])
+
+		assert.EqualValues(t, &keptnapi.SLO{
+			SLI:         "error_count_b",
+			DisplayName: "Error log records B",
+			Pass:        []*keptnapi.SLOCriteria{{Criteria: []string{"<=20"}}},
+			Weight:      2,
+			KeySLI:      true,
+		}, actual.Objectives[1])
+	}
+
+	runGetSLIsFromDashboardTestWithDashboardParameterAndCheckSLIsAndSLOs(t, handler, testGetSLIEventData, "notebook:"+testNotebookID, getSLIFinishedEventSuccessAssertionsFunc, uploadedSLOsAssertionsFunc,
+		createSuccessfulSLIResultAssertionsFunc("error_count_a", 12, expectedDQLRequest),
+		createSuccessfulSLIResultAssertionsFunc("error_count_b", 12, expectedDQLRequest))
+}
+
+// TestRetrieveMetricsFromNotebook_MultipleRecords tests that a DQL section returning more than one record produces a warning.
+func TestRetrieveMetricsFromNotebook_MultipleRecords(t *testing.T) {
+	const testDataFolder = "./testdata/dashboards/notebook/multiple_records/"
+
+	expectedDQLRequest := buildDQLRequest("fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count(), by: {dt.entity.host}")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DocumentsPath+"/"+testNotebookID+"/content", filepath.Join(testDataFolder, "notebook.json"))
+	handler.AddExact(dynatrace.DQLExecutePath, filepath.Join(testDataFolder, "dql_execute_succeeded.json"))
+
+	runGetSLIsFromDashboardTestWithDashboardParameterAndCheckSLIs(t, handler, testGetSLIEventData, "notebook:"+testNotebookID, getSLIFinishedEventWarningAssertionsFunc, createFailedSLIResultWithQueryAssertionsFunc("error_count", expectedDQLRequest, "DQL query should return exactly one record but returned 2"))
+}
+
+// TestRetrieveMetricsFromNotebook_EmptyNotebookID tests that an empty notebook ID results in an error.
+func TestRetrieveMetricsFromNotebook_EmptyNotebookID(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+
+	runGetSLIsFromDashboardTestWithDashboardParameterAndCheckSLIs(t, handler, testGetSLIEventData, "notebook:", createGetSLIFinishedEventFailureAssertionsFuncWithMessageSubstrings("notebook ID should not be empty"), createFailedSLIResultAssertionsFunc(testIndicatorNoMetric))
+}
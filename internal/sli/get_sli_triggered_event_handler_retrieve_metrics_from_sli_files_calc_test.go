@@ -0,0 +1,137 @@
+package sli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+)
+
+const (
+	testIndicatorCalcProblemCount         = "problem_count"
+	testIndicatorCalcSecurityProblemCount = "security_problem_count"
+	testIndicatorCalc                     = "calculated_sli"
+
+	testCalcProblemsQuery         = "PV2;problemSelector=status(\"open\")"
+	testCalcSecurityProblemsQuery = "SECPV2;securityProblemSelector=status(\"open\")"
+)
+
+func createHandlerForCalcInputs(t *testing.T) *test.FileBasedURLHandler {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(buildProblemsV2Request("status(\"open\")"), filepath.Join("./testdata/sli_files/pv2_success/", "problems_status_open.json"))
+	handler.AddExact(buildSecurityProblemsRequest("status(\"open\")"), filepath.Join("./testdata/sli_files/secpv2_success/", "security_problems_status_open.json"))
+	return handler
+}
+
+// TestRetrieveMetricsFromFile_Calc tests the success case for file-based calculated SLIs whose inputs are not requested themselves.
+func TestRetrieveMetricsFromFile_Calc(t *testing.T) {
+	const calcQuery = "CALC;expr=(problem_count + security_problem_count) / 4"
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorCalcProblemCount:         testCalcProblemsQuery,
+			testIndicatorCalcSecurityProblemCount: testCalcSecurityProblemsQuery,
+			testIndicatorCalc:                     calcQuery,
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorCalc, "<=200")),
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, createHandlerForCalcInputs(t), configClient, testIndicatorCalc, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorCalc, 107, calcQuery))
+}
+
+// TestRetrieveMetricsFromFile_CalcRequestedBeforeInputs tests that inputs are processed first even if the calculated SLI is requested before them.
+func TestRetrieveMetricsFromFile_CalcRequestedBeforeInputs(t *testing.T) {
+	const calcQuery = "CALC;expr=security_problem_count - problem_count"
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorCalcProblemCount:         testCalcProblemsQuery,
+			testIndicatorCalcSecurityProblemCount: testCalcSecurityProblemsQuery,
+			testIndicatorCalc:                     calcQuery,
+		},
+		createTestSLOs(
+			createTestSLOWithPassCriterion(testIndicatorCalc, "<=400"),
+			createTestSLOWithPassCriterion(testIndicatorCalcProblemCount, "<=100"),
+			createTestSLOWithPassCriterion(testIndicatorCalcSecurityProblemCount, "<=400"),
+		),
+	)
+
+	runGetSLIsFromFilesTestAndCheckSLIs(t, createHandlerForCalcInputs(t), configClient,
+		[]string{testIndicatorCalc, testIndicatorCalcProblemCount, testIndicatorCalcSecurityProblemCount},
+		getSLIFinishedEventSuccessAssertionsFunc,
+		createSuccessfulSLIResultAssertionsFunc(testIndicatorCalc, 368, calcQuery),
+		createSuccessfulSLIResultAssertionsFunc(testIndicatorCalcProblemCount, 30, buildProblemsV2Request("status(\"open\")")),
+		createSuccessfulSLIResultAssertionsFunc(testIndicatorCalcSecurityProblemCount, 398, buildSecurityProblemsRequest("status(\"open\")")),
+	)
+}
+
+// TestRetrieveMetricsFromFile_CalcErrors tests that calculated SLIs fail or warn if they are invalid, cyclic or their inputs fail.
+func TestRetrieveMetricsFromFile_CalcErrors(t *testing.T) {
+	tests := []struct {
+		name                              string
+		slis                              map[string]string
+		getSLIFinishedEventAssertionsFunc func(t *testing.T, data *getSLIFinishedEventData)
+		sliResultAssertionsFunc           func(t *testing.T, actual sliResult)
+	}{
+		{
+			name: "invalid expression",
+			slis: map[string]string{
+				testIndicatorCalc: "CALC;expr=problem_count / ",
+			},
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventFailureAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultAssertionsFunc(testIndicatorCalc, "error parsing CALC query", "unexpected end of expression"),
+		},
+		{
+			name: "undefined input indicator",
+			slis: map[string]string{
+				testIndicatorCalcProblemCount: testCalcProblemsQuery,
+				testIndicatorCalc:             "CALC;expr=problem_count / request_count",
+			},
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventFailureAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorCalc, "CALC;expr=problem_count / request_count", "input indicators failed: request_count"),
+		},
+		{
+			name: "cyclic dependency",
+			slis: map[string]string{
+				testIndicatorCalc:      "CALC;expr=other_calculated_sli + 1",
+				"other_calculated_sli": "CALC;expr=calculated_sli * 2",
+			},
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventFailureAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultAssertionsFunc(testIndicatorCalc, "cyclic dependency between indicators: calculated_sli -> other_calculated_sli -> calculated_sli"),
+		},
+		{
+			name: "self reference",
+			slis: map[string]string{
+				testIndicatorCalc: "CALC;expr=calculated_sli + 1",
+			},
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventFailureAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultAssertionsFunc(testIndicatorCalc, "cyclic dependency between indicators: calculated_sli -> calculated_sli"),
+		},
+		{
+			name: "input indicator part of a cycle",
+			slis: map[string]string{
+				testIndicatorCalc: "CALC;expr=cyclic_a + 1",
+				"cyclic_a":        "CALC;expr=cyclic_b + 1",
+				"cyclic_b":        "CALC;expr=cyclic_a + 1",
+			},
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventFailureAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorCalc, "CALC;expr=cyclic_a + 1", "input indicators failed: cyclic_a"),
+		},
+		{
+			name: "division by zero",
+			slis: map[string]string{
+				testIndicatorCalcProblemCount: testCalcProblemsQuery,
+				testIndicatorCalc:             "CALC;expr=1 / (problem_count - 30)",
+			},
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventWarningAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorCalc, "CALC;expr=1 / (problem_count - 30)", "error evaluating CALC expression", "division by zero"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configClient := newConfigClientMockWithSLIsAndSLOs(t, tt.slis, createTestSLOs(createTestSLOWithPassCriterion(testIndicatorCalc, "<=200")))
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, createHandlerForCalcInputs(t), configClient, testIndicatorCalc, tt.getSLIFinishedEventAssertionsFunc, tt.sliResultAssertionsFunc)
+		})
+	}
+}
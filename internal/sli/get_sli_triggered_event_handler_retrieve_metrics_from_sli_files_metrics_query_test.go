@@ -343,3 +343,201 @@ func TestGetSLIValueFromInvalidCustomQueriesWithLegacyQueryFormatFailAsExpected(
 		})
 	}
 }
+
+// TestRetrieveMetricsFromFile_MV2Comparison tests MV2 queries that are compared against an earlier timeframe.
+func TestRetrieveMetricsFromFile_MV2Comparison(t *testing.T) {
+	const (
+		testDataFolder                  = "./testdata/sli_files/mv2_comparison/"
+		testIndicatorResponseTimeChange = "response_time_change"
+		testPreviousSLIStart            = "2022-09-27T00:00:00.000Z"
+	)
+
+	requestBuilder := newMetricsV2QueryRequestBuilder("builtin:service.response.time:splitBy()").copyWithResolution(resolutionInf)
+	expectedCurrentRequest := requestBuilder.build()
+	expectedPreviousRequest := requestBuilder.copyWithTimeframe(testPreviousSLIStart, testSLIStart).build()
+	expectedQuery := expectedCurrentRequest + ";" + expectedPreviousRequest
+
+	tests := []struct {
+		name                              string
+		options                           string
+		previousDataFile                  string
+		getSLIFinishedEventAssertionsFunc func(t *testing.T, data *getSLIFinishedEventData)
+		sliResultAssertionsFunc           func(t *testing.T, actual sliResult)
+	}{
+		{
+			name:                              "absolute difference to previous timeframe",
+			options:                           "&compareTo=previousTimeframe",
+			previousDataFile:                  "response_time_previous_day.json",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
+			sliResultAssertionsFunc:           createSuccessfulSLIResultAssertionsFunc(testIndicatorResponseTimeChange, 12, expectedQuery),
+		},
+		{
+			name:                              "relative difference to shifted timeframe",
+			options:                           "&compareTo=shift(-1d)&mode=relative",
+			previousDataFile:                  "response_time_previous_day.json",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
+			sliResultAssertionsFunc:           createSuccessfulSLIResultAssertionsFunc(testIndicatorResponseTimeChange, 25, expectedQuery),
+		},
+		{
+			name:                              "relative difference to zero",
+			options:                           "&compareTo=previousTimeframe&mode=relative",
+			previousDataFile:                  "response_time_previous_day_zero.json",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventWarningAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorResponseTimeChange, expectedQuery, "value of the comparison timeframe is 0"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(expectedCurrentRequest, filepath.Join(testDataFolder, "response_time_current.json"))
+			handler.AddExact(expectedPreviousRequest, filepath.Join(testDataFolder, tt.previousDataFile))
+
+			configClient := newConfigClientMockWithSLIsAndSLOs(t,
+				map[string]string{
+					testIndicatorResponseTimeChange: "MV2;MicroSecond;metricSelector=builtin:service.response.time:splitBy()&resolution=Inf" + tt.options,
+				},
+				createTestSLOs(createTestSLOWithPassCriterion(testIndicatorResponseTimeChange, "<=50")),
+			)
+
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorResponseTimeChange, tt.getSLIFinishedEventAssertionsFunc, tt.sliResultAssertionsFunc)
+		})
+	}
+}
+
+// TestGetSLIValueMetricsQuery_TargetUnit tests that metrics queries specifying a target unit are converted using the Metrics API v2 and that the SLI result states the unit.
+func TestGetSLIValueMetricsQuery_TargetUnit(t *testing.T) {
+	const testDataFolder = "./testdata/sli_files/metrics/target_unit/"
+
+	tests := []struct {
+		name       string
+		targetUnit string
+	}{
+		{
+			name:       "unit ID",
+			targetUnit: "MilliSecond",
+		},
+		{
+			name:       "unit symbol",
+			targetUnit: "ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewCombinedURLHandler(t)
+			expectedMetricsRequest := addRequestsToHandlerForSuccessfulMetricsQueryWithResolutionInfAndUnitsConversionSnippet(
+				handler,
+				testDataFolder,
+				newMetricsV2QueryRequestBuilder("builtin:service.response.time:splitBy():avg:names").copyWithEntitySelector("type(SERVICE)"),
+				createToUnitConversionSnippet(microSecondUnitID, milliSecondUnitID))
+
+			configClient := newConfigClientMockWithSLIsAndSLOs(t,
+				map[string]string{
+					testIndicatorResponseTimeP95: "metricSelector=builtin:service.response.time:splitBy():avg:names&entitySelector=type(SERVICE)&unit=" + tt.targetUnit,
+				},
+				testSLOsWithResponseTimeP95,
+			)
+
+			sliResultAssertionsFunc := func(t *testing.T, actual sliResult) {
+				createSuccessfulSLIResultAssertionsFunc(testIndicatorResponseTimeP95, 54.896485186544574, expectedMetricsRequest)(t, actual)
+				assert.Equal(t, milliSecondUnitID, actual.Unit)
+			}
+
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorResponseTimeP95, getSLIFinishedEventSuccessAssertionsFunc, sliResultAssertionsFunc)
+		})
+	}
+}
+
+// TestGetSLIValueMetricsQuery_UnknownTargetUnit tests that metrics queries specifying an unknown target unit fail.
+func TestGetSLIValueMetricsQuery_UnknownTargetUnit(t *testing.T) {
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorResponseTimeP95: "metricSelector=builtin:service.response.time:splitBy():avg:names&unit=fortnight",
+		},
+		testSLOsWithResponseTimeP95,
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, test.NewEmptyURLHandler(t), configClient, testIndicatorResponseTimeP95, getSLIFinishedEventFailureAssertionsFunc, createFailedSLIResultAssertionsFunc(testIndicatorResponseTimeP95, "error parsing Metrics v2 query", "invalid unit: fortnight"))
+}
+
+// TestRetrieveMetricsFromFile_MV2TargetUnit tests that MV2 queries are scaled or converted to the target unit and that the SLI result states the unit.
+func TestRetrieveMetricsFromFile_MV2TargetUnit(t *testing.T) {
+	const (
+		testDataFolder                = "./testdata/sli_files/mv2_comparison/"
+		testIndicatorResponseTime     = "response_time"
+		testPreviousSLIStart          = "2022-09-27T00:00:00.000Z"
+		testMV2QueryWithResolutionInf = "MV2;MicroSecond;metricSelector=builtin:service.response.time:splitBy()&resolution=Inf"
+	)
+
+	requestBuilder := newMetricsV2QueryRequestBuilder("builtin:service.response.time:splitBy()").copyWithResolution(resolutionInf)
+	expectedCurrentRequest := requestBuilder.build()
+	expectedPreviousRequest := requestBuilder.copyWithTimeframe(testPreviousSLIStart, testSLIStart).build()
+
+	tests := []struct {
+		name          string
+		options       string
+		expectedValue float64
+		expectedQuery string
+		expectedUnit  string
+	}{
+		{
+			name:          "default scaling to milliseconds",
+			expectedValue: 60,
+			expectedQuery: expectedCurrentRequest,
+			expectedUnit:  "MilliSecond",
+		},
+		{
+			name:          "conversion to seconds",
+			options:       "&unit=s",
+			expectedValue: 0.06,
+			expectedQuery: expectedCurrentRequest,
+			expectedUnit:  "Second",
+		},
+		{
+			name:          "conversion to nanoseconds",
+			options:       "&unit=NanoSecond",
+			expectedValue: 60000000,
+			expectedQuery: expectedCurrentRequest,
+			expectedUnit:  "NanoSecond",
+		},
+		{
+			name:          "absolute difference in target unit",
+			options:       "&unit=s&compareTo=shift(-1d)",
+			expectedValue: 0.012,
+			expectedQuery: expectedCurrentRequest + ";" + expectedPreviousRequest,
+			expectedUnit:  "Second",
+		},
+		{
+			name:          "relative difference in percent",
+			options:       "&unit=s&compareTo=shift(-1d)&mode=relative",
+			expectedValue: 25,
+			expectedQuery: expectedCurrentRequest + ";" + expectedPreviousRequest,
+			expectedUnit:  "Percent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(expectedCurrentRequest, filepath.Join(testDataFolder, "response_time_current.json"))
+			handler.AddExact(expectedPreviousRequest, filepath.Join(testDataFolder, "response_time_previous_day.json"))
+
+			configClient := newConfigClientMockWithSLIsAndSLOs(t,
+				map[string]string{
+					testIndicatorResponseTime: testMV2QueryWithResolutionInf + tt.options,
+				},
+				createTestSLOs(createTestSLOWithPassCriterion(testIndicatorResponseTime, "<=100")),
+			)
+
+			sliResultAssertionsFunc := func(t *testing.T, actual sliResult) {
+				assert.True(t, actual.Success, "Indicator success should be true")
+				assert.InDelta(t, tt.expectedValue, actual.Value, 0.000001)
+				assert.Equal(t, tt.expectedQuery, actual.Query)
+				assert.Equal(t, tt.expectedUnit, actual.Unit)
+			}
+
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorResponseTime, getSLIFinishedEventSuccessAssertionsFunc, sliResultAssertionsFunc)
+		})
+	}
+}
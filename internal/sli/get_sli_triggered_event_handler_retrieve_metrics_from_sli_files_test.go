@@ -3,11 +3,13 @@ package sli
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	"github.com/keptn-contrib/dynatrace-service/internal/test"
 	keptncommon "github.com/keptn/go-utils/pkg/lib"
@@ -94,6 +96,60 @@ func TestRetrieveMetricsFromFile_SecurityProblemsV2(t *testing.T) {
 	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorSecurityProblemCount, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorSecurityProblemCount, 398, expectedSecurityProblemsRequest))
 }
 
+// TestRetrieveMetricsFromFile_SecurityProblemsV2Aggregations tests file-based Security Problems V2 SLIs using risk score aggregations and minimum risk levels.
+func TestRetrieveMetricsFromFile_SecurityProblemsV2Aggregations(t *testing.T) {
+	const (
+		testDataFolder                = "./testdata/sli_files/secpv2_aggregation/"
+		testIndicatorSecurityProblems = "security_problems"
+	)
+
+	expectedSecurityProblemsRequest := buildSecurityProblemsListRequest("status(\"open\")")
+
+	tests := []struct {
+		name          string
+		parameters    string
+		expectedValue float64
+	}{
+		{
+			name:          "count above risk level",
+			parameters:    "&minRiskLevel=HIGH",
+			expectedValue: 2,
+		},
+		{
+			name:          "maximum risk score",
+			parameters:    "&aggregate=maxRiskScore",
+			expectedValue: 9.8,
+		},
+		{
+			name:          "sum of risk scores",
+			parameters:    "&aggregate=sumRiskScore",
+			expectedValue: 22.6,
+		},
+		{
+			name:          "sum of risk scores above risk level",
+			parameters:    "&aggregate=sumRiskScore&minRiskLevel=CRITICAL",
+			expectedValue: 9.8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(expectedSecurityProblemsRequest, filepath.Join(testDataFolder, "security_problems_page1.json"))
+			handler.AddExact(dynatrace.SecurityProblemsPath+"?nextPageKey=AQAAABQBAAAABQ%3D%3D", filepath.Join(testDataFolder, "security_problems_page2.json"))
+
+			configClient := newConfigClientMockWithSLIsAndSLOs(t,
+				map[string]string{
+					testIndicatorSecurityProblems: "SECPV2;securityProblemSelector=status(\"open\")" + tt.parameters,
+				},
+				createTestSLOs(createTestSLOWithPassCriterion(testIndicatorSecurityProblems, "<=100")),
+			)
+
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorSecurityProblems, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorSecurityProblems, tt.expectedValue, expectedSecurityProblemsRequest))
+		})
+	}
+}
+
 // TestRetrieveMetricsFromFile_ProblemsV2 tests the success case for file-based ProblemsV2 SLIs.
 func TestRetrieveMetricsFromFile_ProblemsV2(t *testing.T) {
 	const (
@@ -116,6 +172,157 @@ func TestRetrieveMetricsFromFile_ProblemsV2(t *testing.T) {
 	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorProblemCount, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorProblemCount, 30, expectedProblemsRequest))
 }
 
+// TestRetrieveMetricsFromFile_ProblemsV2Aggregations tests file-based Problems V2 SLIs using aggregations that require all pages of problems.
+func TestRetrieveMetricsFromFile_ProblemsV2Aggregations(t *testing.T) {
+	const (
+		testDataFolder        = "./testdata/sli_files/pv2_aggregation/"
+		testIndicatorProblems = "problems"
+	)
+
+	expectedProblemsRequest := buildProblemsV2Request("status(\"open\")")
+
+	tests := []struct {
+		name          string
+		aggregation   string
+		expectedValue float64
+	}{
+		{
+			name:          "sum of durations clipped to timeframe",
+			aggregation:   "sumDurationMinutes",
+			expectedValue: 55,
+		},
+		{
+			name:          "maximum duration clipped to timeframe",
+			aggregation:   "maxDurationMinutes",
+			expectedValue: 30,
+		},
+		{
+			name:          "distinct impacted entities",
+			aggregation:   "impactedEntities",
+			expectedValue: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(expectedProblemsRequest, filepath.Join(testDataFolder, "problems_page1.json"))
+			handler.AddExact(dynatrace.ProblemsV2Path+"?nextPageKey=AQAAABQBAAAABQ%3D%3D", filepath.Join(testDataFolder, "problems_page2.json"))
+
+			configClient := newConfigClientMockWithSLIsAndSLOs(t,
+				map[string]string{
+					testIndicatorProblems: "PV2;problemSelector=status(\"open\")&aggregate=" + tt.aggregation,
+				},
+				createTestSLOs(createTestSLOWithPassCriterion(testIndicatorProblems, "<=60")),
+			)
+
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorProblems, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorProblems, tt.expectedValue, expectedProblemsRequest))
+		})
+	}
+}
+
+// TestRetrieveMetricsFromFile_Audit tests the success case for file-based Audit logs SLIs.
+func TestRetrieveMetricsFromFile_Audit(t *testing.T) {
+	const (
+		testDataFolder                    = "./testdata/sli_files/audit_success/"
+		testIndicatorConfigurationChanges = "configuration_changes"
+	)
+
+	expectedAuditLogsRequest := buildAuditLogsRequest("category(\"CONFIG\"),eventType(\"UPDATE\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedAuditLogsRequest, filepath.Join(testDataFolder, "audit_logs_config_updates.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorConfigurationChanges: "AUDIT;filter=category(\"CONFIG\"),eventType(\"UPDATE\")",
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorConfigurationChanges, "<=5")),
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorConfigurationChanges, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorConfigurationChanges, 2, expectedAuditLogsRequest))
+}
+
+// TestRetrieveMetricsFromFile_Entities tests the success case for file-based Monitored entities SLIs.
+func TestRetrieveMetricsFromFile_Entities(t *testing.T) {
+	const (
+		testDataFolder           = "./testdata/sli_files/entities_success/"
+		testIndicatorEntityCount = "unhealthy_pgi_count"
+	)
+
+	expectedEntitiesRequest := buildEntitiesRequest("type(PROCESS_GROUP_INSTANCE),healthState(\"UNHEALTHY\")")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedEntitiesRequest, filepath.Join(testDataFolder, "entities_first_page.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorEntityCount: "ENTITIES;entitySelector=type(PROCESS_GROUP_INSTANCE),healthState(\"UNHEALTHY\")",
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorEntityCount, "<=5")),
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorEntityCount, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorEntityCount, 3, expectedEntitiesRequest))
+}
+
+// TestRetrieveMetricsFromFile_JSON tests file-based JSON API SLIs including placeholders in the path.
+func TestRetrieveMetricsFromFile_JSON(t *testing.T) {
+	const (
+		testDataFolder    = "./testdata/sli_files/json_success/"
+		testIndicatorJSON = "release_count"
+	)
+
+	expectedJSONRequest := fmt.Sprintf("/api/v2/releases?from=%s&releasesSelector=%s&to=%s", convertTimeStringToUnixMillisecondsString(testSLIStart), url.QueryEscape("product(\"sockshop\"),stage(\"staging\")"), convertTimeStringToUnixMillisecondsString(testSLIEnd))
+
+	tests := []struct {
+		name                              string
+		selector                          string
+		getSLIFinishedEventAssertionsFunc func(t *testing.T, data *getSLIFinishedEventData)
+		sliResultAssertionsFunc           func(t *testing.T, actual sliResult)
+	}{
+		{
+			name:                              "single number",
+			selector:                          "$.totalCount",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
+			sliResultAssertionsFunc:           createSuccessfulSLIResultAssertionsFunc(testIndicatorJSON, 2, expectedJSONRequest),
+		},
+		{
+			name:                              "multiple values",
+			selector:                          "$.releases[*].version",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventWarningAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorJSON, expectedJSONRequest, "should select exactly one value but selected 2"),
+		},
+		{
+			name:                              "no value",
+			selector:                          "$.releases[2].version",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventWarningAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorJSON, expectedJSONRequest, "should select exactly one value but selected 0"),
+		},
+		{
+			name:                              "not a number",
+			selector:                          "$.releases[0].version",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventWarningAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorJSON, expectedJSONRequest, "should be a number"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(expectedJSONRequest, filepath.Join(testDataFolder, "releases.json"))
+
+			configClient := newConfigClientMockWithSLIsAndSLOs(t,
+				map[string]string{
+					testIndicatorJSON: "JSON;path=/api/v2/releases?releasesSelector=product(\"$PROJECT\"),stage(\"$STAGE\")&select=" + tt.selector,
+				},
+				createTestSLOs(createTestSLOWithPassCriterion(testIndicatorJSON, ">=1")),
+			)
+
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorJSON, tt.getSLIFinishedEventAssertionsFunc, tt.sliResultAssertionsFunc)
+		})
+	}
+}
+
 // TestRetrieveMetricsFromFile_SLO tests the success case for file-based SLO SLIs.
 func TestRetrieveMetricsFromFile_SLO(t *testing.T) {
 	const (
@@ -138,6 +345,164 @@ func TestRetrieveMetricsFromFile_SLO(t *testing.T) {
 	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorSLOValue, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorSLOValue, 95, expectedSLORequest))
 }
 
+// TestRetrieveMetricsFromFile_SLOByNameOrSelector tests file-based SLO SLIs where the SLO is resolved by its name or an SLO selector.
+func TestRetrieveMetricsFromFile_SLOByNameOrSelector(t *testing.T) {
+	const (
+		testDataFolder        = "./testdata/sli_files/slo_success/"
+		testIndicatorSLOValue = "slo_value"
+	)
+
+	expectedSLORequest := buildSLORequest("7d07efde-b714-3e6e-ad95-08490e2540c4")
+
+	tests := []struct {
+		name                              string
+		query                             string
+		expectedFindRequest               string
+		findDataFile                      string
+		getSLIFinishedEventAssertionsFunc func(t *testing.T, data *getSLIFinishedEventData)
+		sliResultAssertionsFunc           func(t *testing.T, actual sliResult)
+	}{
+		{
+			name:                              "name",
+			query:                             "SLO;name=carts availability",
+			expectedFindRequest:               buildSLOFindRequest("name(\"carts availability\")"),
+			findDataFile:                      "slos_name_carts_availability.json",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
+			sliResultAssertionsFunc:           createSuccessfulSLIResultAssertionsFunc(testIndicatorSLOValue, 95, expectedSLORequest),
+		},
+		{
+			name:                              "selector",
+			query:                             "SLO;selector=text(\"carts\"),managementZone(\"sockshop\")",
+			expectedFindRequest:               buildSLOFindRequest("text(\"carts\"),managementZone(\"sockshop\")"),
+			findDataFile:                      "slos_name_carts_availability.json",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventSuccessAssertionsFunc,
+			sliResultAssertionsFunc:           createSuccessfulSLIResultAssertionsFunc(testIndicatorSLOValue, 95, expectedSLORequest),
+		},
+		{
+			name:                              "no matching SLO",
+			query:                             "SLO;name=carts latency",
+			expectedFindRequest:               buildSLOFindRequest("name(\"carts latency\")"),
+			findDataFile:                      "slos_no_match.json",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventWarningAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorSLOValue, buildSLOFindRequest("name(\"carts latency\")"), "no SLO matches selector"),
+		},
+		{
+			name:                              "several matching SLOs",
+			query:                             "SLO;selector=text(\"carts\")",
+			expectedFindRequest:               buildSLOFindRequest("text(\"carts\")"),
+			findDataFile:                      "slos_two_matches.json",
+			getSLIFinishedEventAssertionsFunc: getSLIFinishedEventWarningAssertionsFunc,
+			sliResultAssertionsFunc:           createFailedSLIResultWithQueryAssertionsFunc(testIndicatorSLOValue, buildSLOFindRequest("text(\"carts\")"), "should match exactly one SLO but matches 2"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := test.NewFileBasedURLHandler(t)
+			handler.AddExact(tt.expectedFindRequest, filepath.Join(testDataFolder, tt.findDataFile))
+			handler.AddExact(expectedSLORequest, filepath.Join(testDataFolder, "slo_7d07efde-b714-3e6e-ad95-08490e2540c4.json"))
+
+			configClient := newConfigClientMockWithSLIsAndSLOs(t,
+				map[string]string{
+					testIndicatorSLOValue: tt.query,
+				},
+				createTestSLOs(createTestSLOWithPassCriterion(testIndicatorSLOValue, ">=90")),
+			)
+
+			runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorSLOValue, tt.getSLIFinishedEventAssertionsFunc, tt.sliResultAssertionsFunc)
+		})
+	}
+}
+
+// TestRetrieveMetricsFromFile_SLOErrorBudget tests the success case for file-based SLO SLIs using the error budget field.
+func TestRetrieveMetricsFromFile_SLOErrorBudget(t *testing.T) {
+	const (
+		testDataFolder              = "./testdata/sli_files/slo_success/"
+		testIndicatorSLOErrorBudget = "slo_error_budget"
+	)
+
+	expectedSLORequest := buildSLORequest("7d07efde-b714-3e6e-ad95-08490e2540c4")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedSLORequest, filepath.Join(testDataFolder, "slo_7d07efde-b714-3e6e-ad95-08490e2540c4.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorSLOErrorBudget: "SLO;id=7d07efde-b714-3e6e-ad95-08490e2540c4&field=errorBudget",
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorSLOErrorBudget, ">0")),
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorSLOErrorBudget, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorSLOErrorBudget, 80, expectedSLORequest))
+}
+
+// TestRetrieveMetricsFromFile_SLOBurnRateNotAvailable tests that a warning is produced if the error budget burn rate of an SLO is not available.
+func TestRetrieveMetricsFromFile_SLOBurnRateNotAvailable(t *testing.T) {
+	const (
+		testDataFolder           = "./testdata/sli_files/slo_success/"
+		testIndicatorSLOBurnRate = "slo_burn_rate"
+	)
+
+	expectedSLORequest := buildSLORequest("7d07efde-b714-3e6e-ad95-08490e2540c4")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedSLORequest, filepath.Join(testDataFolder, "slo_7d07efde-b714-3e6e-ad95-08490e2540c4.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorSLOBurnRate: "SLO;id=7d07efde-b714-3e6e-ad95-08490e2540c4&field=errorBudgetBurnRate",
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorSLOBurnRate, "<1")),
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorSLOBurnRate, getSLIFinishedEventWarningAssertionsFunc, createFailedSLIResultWithQueryAssertionsFunc(testIndicatorSLOBurnRate, expectedSLORequest, "error budget burn rate is not available"))
+}
+
+// TestRetrieveMetricsFromFile_DQL tests the success case for file-based DQL SLIs.
+func TestRetrieveMetricsFromFile_DQL(t *testing.T) {
+	const (
+		testDataFolder          = "./testdata/sli_files/dql_success/"
+		testIndicatorErrorCount = "error_count"
+	)
+
+	const dqlQuery = "fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()"
+	expectedDQLRequest := buildDQLRequest(dqlQuery)
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(dynatrace.DQLExecutePath, filepath.Join(testDataFolder, "dql_execute_succeeded.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorErrorCount: "DQL;field=error_count&query=" + dqlQuery,
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorErrorCount, "<=20")),
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorErrorCount, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorErrorCount, 12, expectedDQLRequest))
+}
+
+// TestRetrieveMetricsFromFile_Synthetic tests the success case for file-based synthetic monitor SLIs.
+func TestRetrieveMetricsFromFile_Synthetic(t *testing.T) {
+	const (
+		testDataFolder            = "./testdata/sli_files/synthetic_success/"
+		testIndicatorAvailability = "availability"
+	)
+
+	expectedMetricsRequest := buildSyntheticMetricsRequest("builtin:synthetic.http.availability.location.total", "HTTP_CHECK-0E1F2A3B4C5D6E7F")
+
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(expectedMetricsRequest, filepath.Join(testDataFolder, "metrics_availability.json"))
+
+	configClient := newConfigClientMockWithSLIsAndSLOs(t,
+		map[string]string{
+			testIndicatorAvailability: "SYNTH;monitorId=HTTP_CHECK-0E1F2A3B4C5D6E7F&metric=availability",
+		},
+		createTestSLOs(createTestSLOWithPassCriterion(testIndicatorAvailability, ">=99")),
+	)
+
+	runGetSLIsFromFilesTestWithOneIndicatorRequestedAndCheckSLIs(t, handler, configClient, testIndicatorAvailability, getSLIFinishedEventSuccessAssertionsFunc, createSuccessfulSLIResultAssertionsFunc(testIndicatorAvailability, 100, expectedMetricsRequest))
+}
+
 // TestResultPassWhenNoSLIsAreRequested tests that no SLIs requested leads to a overall pass result with no SLI results.
 func TestResultPassWhenNoSLIsAreRequested(t *testing.T) {
 	tests := []struct {
@@ -411,6 +776,14 @@ func TestGetSLIValueSupportsPlaceholders(t *testing.T) {
 			responseFilename: filepath.Join(testDataFolder, "problems_query_result.json"),
 			expectedSLIValue: 12,
 		},
+		{
+			name:             "LOGS",
+			indicator:        "error_logs",
+			query:            "LOGS;query=loglevel=\"$LABEL.log_level\" AND k8s.deployment.name=\"$SERVICE\"&aggregate=count",
+			expectedRequest:  buildLogsRequest("loglevel=\"ERROR\" AND k8s.deployment.name=\"carts\""),
+			responseFilename: filepath.Join(testDataFolder, "logs_query_result.json"),
+			expectedSLIValue: 2,
+		},
 		{
 			name:             "SECPV2",
 			indicator:        "security_problems",
@@ -456,6 +829,7 @@ func TestGetSLIValueSupportsPlaceholders(t *testing.T) {
 					"slo_id":         "7d07efde-b714-3e6e-ad95-08490e2540c4",
 					"problem_status": "open",
 					"country":        "Austria",
+					"log_level":      "ERROR",
 				},
 
 				indicators: []string{tt.indicator},
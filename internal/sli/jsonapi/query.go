@@ -0,0 +1,108 @@
+package jsonapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// apiPathPrefix is the prefix all paths of JSON API queries must start with.
+const apiPathPrefix = "/api/"
+
+// pathParameterDelimiter separates the query parameters included in the path, as '&' already separates the keys of the SLI query itself.
+const pathParameterDelimiter = ";"
+
+// PathParameter is a query parameter included in the path of a Query.
+type PathParameter struct {
+	Key   string
+	Value string
+}
+
+// Query encapsulates a JSON API query.
+type Query struct {
+	path       string
+	basePath   string
+	parameters []PathParameter
+	selector   Selector
+}
+
+// NewQuery creates a new Query based on the provided API path and selector expression or returns an error.
+func NewQuery(path string, selector string) (*Query, error) {
+	if path == "" {
+		return nil, errors.New("path should not be empty")
+	}
+
+	if !strings.HasPrefix(path, apiPathPrefix) {
+		return nil, fmt.Errorf("path should start with %s", apiPathPrefix)
+	}
+
+	if strings.Contains(path, "..") {
+		return nil, errors.New("path should not contain '..'")
+	}
+
+	if selector == "" {
+		return nil, errors.New("selector should not be empty")
+	}
+
+	basePath, rawParameters, _ := strings.Cut(path, "?")
+	parameters, err := parsePathParameters(rawParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedSelector, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		path:       path,
+		basePath:   basePath,
+		parameters: parameters,
+		selector:   *parsedSelector,
+	}, nil
+}
+
+// parsePathParameters parses the parameters of a path, separated by ';', and decodes any percent-encoded values or returns an error.
+func parsePathParameters(rawParameters string) ([]PathParameter, error) {
+	var parameters []PathParameter
+	for _, rawParameter := range strings.Split(rawParameters, pathParameterDelimiter) {
+		if rawParameter == "" {
+			continue
+		}
+
+		key, rawValue, _ := strings.Cut(rawParameter, "=")
+		if key == "" {
+			return nil, fmt.Errorf("path parameter should have a key: %s", rawParameter)
+		}
+
+		value, err := url.PathUnescape(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode value of path parameter '%s': %w", key, err)
+		}
+
+		parameters = append(parameters, PathParameter{Key: key, Value: value})
+	}
+	return parameters, nil
+}
+
+// GetPath returns the API path as specified, including any parameters.
+func (q Query) GetPath() string {
+	return q.path
+}
+
+// GetBasePath returns the API path without any parameters.
+func (q Query) GetBasePath() string {
+	return q.basePath
+}
+
+// GetPathParameters returns the decoded parameters included in the path.
+func (q Query) GetPathParameters() []PathParameter {
+	return q.parameters
+}
+
+// GetSelector returns the selector.
+func (q Query) GetSelector() Selector {
+	return q.selector
+}
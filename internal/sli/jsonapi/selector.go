@@ -0,0 +1,170 @@
+package jsonapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Selector is a parsed JSONPath-like expression used to select values from a decoded JSON document.
+// Supported are the root `$`, child members `.name` or `['name']`, array indices `[0]` (negative indices count from the end) and wildcards `.*` or `[*]`.
+type Selector struct {
+	expression string
+	steps      []selectorStep
+}
+
+// ParseSelector parses the specified expression into a Selector or returns an error.
+func ParseSelector(expression string) (*Selector, error) {
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, "$") {
+		return nil, errors.New("selector should start with $")
+	}
+
+	var steps []selectorStep
+	rest := expression[1:]
+	for rest != "" {
+		step, remainder, err := parseStep(rest)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+		rest = remainder
+	}
+
+	return &Selector{
+		expression: expression,
+		steps:      steps,
+	}, nil
+}
+
+// String returns the selector expression.
+func (s Selector) String() string {
+	return s.expression
+}
+
+// Select applies the selector to a JSON document decoded into interface{} values and returns all matching values.
+func (s Selector) Select(document interface{}) []interface{} {
+	current := []interface{}{document}
+	for _, step := range s.steps {
+		var next []interface{}
+		for _, value := range current {
+			next = append(next, step.apply(value)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func parseStep(rest string) (selectorStep, string, error) {
+	switch {
+	case strings.HasPrefix(rest, ".*"):
+		return wildcardStep{}, rest[2:], nil
+
+	case strings.HasPrefix(rest, "."):
+		end := strings.IndexAny(rest[1:], ".[")
+		if end == -1 {
+			end = len(rest) - 1
+		}
+		name := rest[1 : end+1]
+		if name == "" {
+			return nil, "", errors.New("selector contains an empty member name")
+		}
+		return memberStep{name: name}, rest[end+1:], nil
+
+	case strings.HasPrefix(rest, "["):
+		end := strings.Index(rest, "]")
+		if end == -1 {
+			return nil, "", errors.New("selector contains '[' without matching ']'")
+		}
+		step, err := parseBracketStep(strings.TrimSpace(rest[1:end]))
+		if err != nil {
+			return nil, "", err
+		}
+		return step, rest[end+1:], nil
+
+	default:
+		return nil, "", fmt.Errorf("unexpected selector part: %s", rest)
+	}
+}
+
+func parseBracketStep(content string) (selectorStep, error) {
+	if content == "*" {
+		return wildcardStep{}, nil
+	}
+
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		name := content[1 : len(content)-1]
+		if name == "" {
+			return nil, errors.New("selector contains an empty member name")
+		}
+		return memberStep{name: name}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid array index: %s", content)
+	}
+	return indexStep{index: index}, nil
+}
+
+type selectorStep interface {
+	apply(value interface{}) []interface{}
+}
+
+// memberStep selects the member with the specified name of an object.
+type memberStep struct {
+	name string
+}
+
+func (s memberStep) apply(value interface{}) []interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	member, ok := object[s.name]
+	if !ok {
+		return nil
+	}
+	return []interface{}{member}
+}
+
+// indexStep selects the element with the specified index of an array.
+type indexStep struct {
+	index int
+}
+
+func (s indexStep) apply(value interface{}) []interface{} {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	index := s.index
+	if index < 0 {
+		index = len(array) + index
+	}
+	if index < 0 || index >= len(array) {
+		return nil
+	}
+	return []interface{}{array[index]}
+}
+
+// wildcardStep selects all elements of an array or all member values of an object.
+type wildcardStep struct{}
+
+func (s wildcardStep) apply(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, member := range v {
+			values = append(values, member)
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDocument = `{
+  "totalCount": 2,
+  "items": [
+    {"name": "carts", "value": {"hostUnits": 4.5}},
+    {"name": "orders", "value": {"hostUnits": 2}}
+  ],
+  "settings": {"retention-days": 35}
+}`
+
+// TestSelector_Select tests selecting values from a JSON document.
+func TestSelector_Select(t *testing.T) {
+	var document interface{}
+	assert.NoError(t, json.Unmarshal([]byte(testDocument), &document))
+
+	tests := []struct {
+		name           string
+		selector       string
+		expectedValues []interface{}
+	}{
+		{
+			name:           "root",
+			selector:       "$",
+			expectedValues: []interface{}{document},
+		},
+		{
+			name:           "member",
+			selector:       "$.totalCount",
+			expectedValues: []interface{}{2.0},
+		},
+		{
+			name:           "nested member with index",
+			selector:       "$.items[0].value.hostUnits",
+			expectedValues: []interface{}{4.5},
+		},
+		{
+			name:           "negative index",
+			selector:       "$.items[-1].name",
+			expectedValues: []interface{}{"orders"},
+		},
+		{
+			name:           "bracket member",
+			selector:       "$.settings['retention-days']",
+			expectedValues: []interface{}{35.0},
+		},
+		{
+			name:           "wildcard",
+			selector:       "$.items[*].value.hostUnits",
+			expectedValues: []interface{}{4.5, 2.0},
+		},
+		{
+			name:           "index out of range",
+			selector:       "$.items[2].name",
+			expectedValues: nil,
+		},
+		{
+			name:           "missing member",
+			selector:       "$.missing.value",
+			expectedValues: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := ParseSelector(tc.selector)
+			if assert.NoError(t, err) {
+				assert.EqualValues(t, tc.expectedValues, selector.Select(document))
+			}
+		})
+	}
+}
+
+// TestParseSelector_Errors tests that invalid selectors are rejected.
+func TestParseSelector_Errors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		selector             string
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "missing root",
+			selector:             "items[0]",
+			expectedErrorMessage: "selector should start with $",
+		},
+		{
+			name:                 "empty member name",
+			selector:             "$..name",
+			expectedErrorMessage: "empty member name",
+		},
+		{
+			name:                 "unclosed bracket",
+			selector:             "$.items[0",
+			expectedErrorMessage: "without matching ']'",
+		},
+		{
+			name:                 "invalid index",
+			selector:             "$.items[first]",
+			expectedErrorMessage: "invalid array index: first",
+		},
+		{
+			name:                 "unexpected part",
+			selector:             "$items",
+			expectedErrorMessage: "unexpected selector part: items",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := ParseSelector(tc.selector)
+			assert.Nil(t, selector)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+			}
+		})
+	}
+}
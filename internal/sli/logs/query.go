@@ -0,0 +1,23 @@
+package logs
+
+import "errors"
+
+// Query encapsulates a Logs v2 query.
+type Query struct {
+	query string
+}
+
+// NewQuery creates a new Query based on the provided log query or returns an error.
+func NewQuery(query string) (*Query, error) {
+	if query == "" {
+		return nil, errors.New("log query should not be empty")
+	}
+	return &Query{
+		query: query,
+	}, nil
+}
+
+// GetQuery returns the log query.
+func (m Query) GetQuery() string {
+	return m.query
+}
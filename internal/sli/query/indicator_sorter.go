@@ -0,0 +1,73 @@
+package query
+
+// sortedIndicators contains indicators in dependency order as well as the dependency cycles any indicators are part of.
+type sortedIndicators struct {
+	indicators []string
+	cycles     map[string][]string
+}
+
+// indicatorSorter sorts indicators topologically, i.e. so that each indicator follows the indicators it depends on.
+type indicatorSorter struct {
+	getDependencies func(indicator string) []string
+	visited         map[string]bool
+	path            []string
+	result          sortedIndicators
+}
+
+// newIndicatorSorter creates a new indicatorSorter using the specified function to get the dependencies of an indicator.
+func newIndicatorSorter(getDependencies func(indicator string) []string) *indicatorSorter {
+	return &indicatorSorter{
+		getDependencies: getDependencies,
+		visited:         make(map[string]bool),
+		result: sortedIndicators{
+			cycles: make(map[string][]string),
+		},
+	}
+}
+
+// sort returns the specified indicators and all their transitive dependencies in dependency order.
+// Indicators that are part of a dependency cycle are included together with the cycle.
+func (s *indicatorSorter) sort(indicators []string) sortedIndicators {
+	for _, indicator := range indicators {
+		s.visit(indicator)
+	}
+	return s.result
+}
+
+func (s *indicatorSorter) visit(indicator string) {
+	if index := s.indexInPath(indicator); index >= 0 {
+		s.addCycle(append(append([]string{}, s.path[index:]...), indicator))
+		return
+	}
+
+	if s.visited[indicator] {
+		return
+	}
+
+	s.path = append(s.path, indicator)
+	for _, dependency := range s.getDependencies(indicator) {
+		s.visit(dependency)
+	}
+	s.path = s.path[:len(s.path)-1]
+
+	s.visited[indicator] = true
+	s.result.indicators = append(s.result.indicators, indicator)
+}
+
+func (s *indicatorSorter) indexInPath(indicator string) int {
+	for i, p := range s.path {
+		if p == indicator {
+			return i
+		}
+	}
+	return -1
+}
+
+// addCycle records the cycle for each indicator that is part of it, unless a cycle has already been recorded for that indicator.
+func (s *indicatorSorter) addCycle(cycle []string) {
+	for _, indicator := range cycle[:len(cycle)-1] {
+		if _, exists := s.result.cycles[indicator]; !exists {
+			s.result.cycles[indicator] = cycle
+		}
+	}
+}
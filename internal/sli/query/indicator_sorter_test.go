@@ -0,0 +1,65 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIndicatorSorter_Sort tests that indicators are sorted into dependency order and cycles are detected.
+func TestIndicatorSorter_Sort(t *testing.T) {
+	tests := []struct {
+		name               string
+		dependencies       map[string][]string
+		indicators         []string
+		expectedIndicators []string
+		expectedCycles     map[string][]string
+	}{
+		{
+			name:               "no dependencies",
+			indicators:         []string{"a", "b"},
+			expectedIndicators: []string{"a", "b"},
+			expectedCycles:     map[string][]string{},
+		},
+		{
+			name: "dependencies first",
+			dependencies: map[string][]string{
+				"ratio": {"errors", "requests"},
+			},
+			indicators:         []string{"ratio", "errors"},
+			expectedIndicators: []string{"errors", "requests", "ratio"},
+			expectedCycles:     map[string][]string{},
+		},
+		{
+			name: "transitive dependencies",
+			dependencies: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+			},
+			indicators:         []string{"a"},
+			expectedIndicators: []string{"c", "b", "a"},
+			expectedCycles:     map[string][]string{},
+		},
+		{
+			name: "cycle",
+			dependencies: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"b"},
+			},
+			indicators:         []string{"a"},
+			expectedIndicators: []string{"c", "b", "a"},
+			expectedCycles: map[string][]string{
+				"b": {"b", "c", "b"},
+				"c": {"b", "c", "b"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted := newIndicatorSorter(func(indicator string) []string { return tc.dependencies[indicator] }).sort(tc.indicators)
+			assert.EqualValues(t, tc.expectedIndicators, sorted.indicators)
+			assert.EqualValues(t, tc.expectedCycles, sorted.cycles)
+		})
+	}
+}
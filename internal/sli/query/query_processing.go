@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	keptncommon "github.com/keptn/go-utils/pkg/lib"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
@@ -14,12 +15,20 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/result"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/synthetic"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+	v1audit "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/audit"
+	v1calc "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/calc"
+	v1dql "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/dql"
+	v1entities "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/entities"
+	v1jsonapi "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/jsonapi"
+	v1logs "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/logs"
 	v1metrics "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/metrics"
 	v1mv2 "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/mv2"
 	v1problems "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/problemsv2"
 	v1secpv2 "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/secpv2"
 	v1slo "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/slo"
+	v1synthetic "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/synthetic"
 	v1usql "github.com/keptn-contrib/dynatrace-service/internal/sli/v1/usql"
 )
 
@@ -58,21 +67,77 @@ func (p *Processing) Process(ctx context.Context, indicators []string) ([]result
 		return nil, err
 	}
 
+	slos := make([]*result.SLO, len(indicators))
+	var requestedIndicators []string
+	for i, indicator := range indicators {
+		slos[i], objectives = objectives.GetAndRemoveFirstSLOWithName(indicator)
+		if slos[i] != nil {
+			requestedIndicators = append(requestedIndicators, indicator)
+		}
+	}
+
+	sliResults := p.getSLIResultsFromIndicators(ctx, requestedIndicators)
+
 	results := make([]result.SLIWithSLO, len(indicators))
-	var slo *result.SLO
 	for i, indicator := range indicators {
-		slo, objectives = objectives.GetAndRemoveFirstSLOWithName(indicator)
-		if slo == nil {
-			results[i] = result.NewFailedSLIWithSLO(result.CreateInformationalSLO(indicator), "missing SLO objective")
+		if slos[i] == nil {
+			results[i] = result.NewFailedSLIWithSLO(result.CreateInformationalSLO(indicator), "missing SLO objective", result.ErrorCodeInvalidDefinition)
 			continue
 		}
 
-		results[i] = result.NewSLIWithSLO(p.getSLIResultFromIndicator(ctx, indicator), *slo)
+		results[i] = result.NewSLIWithSLO(sliResults[indicator].WithDefaultTimeframe(p.timeframe), *slos[i])
 	}
 
 	return results, nil
 }
 
+// getSLIResultsFromIndicators queries the specified indicators as well as any indicators these depend on and returns the SLIResults by indicator name.
+// Indicators are processed in dependency order, so that the inputs of calculated indicators are available when these are evaluated.
+func (p *Processing) getSLIResultsFromIndicators(ctx context.Context, indicators []string) map[string]result.SLIResult {
+	sortedIndicators := newIndicatorSorter(p.getIndicatorDependencies).sort(indicators)
+
+	sliResults := make(map[string]result.SLIResult, len(sortedIndicators.indicators))
+	for _, indicator := range sortedIndicators.indicators {
+		cycle, isCyclic := sortedIndicators.cycles[indicator]
+		if isCyclic {
+			sliResults[indicator] = result.NewFailedSLIResult(indicator, "cyclic dependency between indicators: "+strings.Join(cycle, " -> "), result.ErrorCodeDependency)
+			continue
+		}
+
+		sliResults[indicator] = p.getSLIResultFromIndicator(ctx, indicator, sliResults)
+	}
+
+	return sliResults
+}
+
+// getIndicatorDependencies returns the names of the indicators the specified indicator depends on.
+// Only calculated indicators have dependencies; errors are ignored here and are reported when the indicator itself is processed.
+func (p *Processing) getIndicatorDependencies(name string) []string {
+	sliQuery, err := p.getSLIQueryFromIndicator(name)
+	if err != nil || !strings.HasPrefix(sliQuery, v1calc.CalcPrefix) {
+		return nil
+	}
+
+	query, err := v1calc.NewQueryParser(sliQuery).Parse()
+	if err != nil {
+		return nil
+	}
+
+	return query.GetIndicators()
+}
+
+// getSLIQueryFromIndicator returns the query for the specified indicator with all placeholders replaced.
+func (p *Processing) getSLIQueryFromIndicator(name string) (string, error) {
+	// first we get the query from the SLI configuration based on its logical name
+	// no default values here anymore if indicator could not be matched (e.g. due to a misspelling) and custom SLIs were defined
+	rawQuery, err := p.customQueries.GetQueryByNameOrDefaultIfEmpty(name)
+	if err != nil {
+		return "", err
+	}
+
+	return common.ReplaceQueryParameters(rawQuery, p.customFilters, p.eventData), nil
+}
+
 func (p *Processing) getSLOObjectives(ctx context.Context) (result.SLOs, error) {
 	slos, err := p.sloGetter.GetSLOs(ctx, p.eventData.GetProject(), p.eventData.GetStage(), p.eventData.GetService())
 	if err != nil {
@@ -83,18 +148,14 @@ func (p *Processing) getSLOObjectives(ctx context.Context) (result.SLOs, error)
 }
 
 // getSLIResultFromIndicator queries a single SLI value ultimately from the Dynatrace API and returns an SLIResult.
+// Calculated SLIs are evaluated using the previously retrieved results of the indicators they depend on.
 // TODO: 2022-01-28: Refactoring needed: this is currently SLI v1 format processing, it should moved to the v1 package, separating it from the general logic.
-func (p *Processing) getSLIResultFromIndicator(ctx context.Context, name string) result.SLIResult {
-
-	// first we get the query from the SLI configuration based on its logical name
-	// no default values here anymore if indicator could not be matched (e.g. due to a misspelling) and custom SLIs were defined
-	rawQuery, err := p.customQueries.GetQueryByNameOrDefaultIfEmpty(name)
+func (p *Processing) getSLIResultFromIndicator(ctx context.Context, name string, previousResults map[string]result.SLIResult) result.SLIResult {
+	sliQuery, err := p.getSLIQueryFromIndicator(name)
 	if err != nil {
-		return result.NewFailedSLIResult(name, err.Error())
+		return result.NewFailedSLIResult(name, err.Error(), result.ErrorCodeInvalidDefinition)
 	}
 
-	sliQuery := common.ReplaceQueryParameters(rawQuery, p.customFilters, p.eventData)
-
 	switch {
 	case strings.HasPrefix(sliQuery, v1usql.USQLPrefix):
 		return p.executeUSQLQuery(ctx, name, sliQuery)
@@ -106,6 +167,20 @@ func (p *Processing) getSLIResultFromIndicator(ctx context.Context, name string)
 		return p.executeSecurityProblemQuery(ctx, name, sliQuery)
 	case strings.HasPrefix(sliQuery, v1mv2.MV2Prefix):
 		return p.executeMetricsV2Query(ctx, name, sliQuery)
+	case strings.HasPrefix(sliQuery, v1dql.DQLPrefix):
+		return p.executeDQLQuery(ctx, name, sliQuery)
+	case strings.HasPrefix(sliQuery, v1logs.LogsPrefix):
+		return p.executeLogsQuery(ctx, name, sliQuery)
+	case strings.HasPrefix(sliQuery, v1synthetic.SyntheticPrefix):
+		return p.executeSyntheticQuery(ctx, name, sliQuery)
+	case strings.HasPrefix(sliQuery, v1entities.EntitiesPrefix):
+		return p.executeEntitiesQuery(ctx, name, sliQuery)
+	case strings.HasPrefix(sliQuery, v1jsonapi.JSONPrefix):
+		return p.executeJSONQuery(ctx, name, sliQuery)
+	case strings.HasPrefix(sliQuery, v1audit.AuditPrefix):
+		return p.executeAuditQuery(ctx, name, sliQuery)
+	case strings.HasPrefix(sliQuery, v1calc.CalcPrefix):
+		return p.executeCalculatedQuery(name, sliQuery, previousResults)
 	default:
 		return p.executeMetricsQuery(ctx, name, sliQuery)
 	}
@@ -115,29 +190,29 @@ func (p *Processing) executeUSQLQuery(ctx context.Context, name string, usqlQuer
 
 	query, err := v1usql.NewQueryParser(usqlQuery).Parse()
 	if err != nil {
-		return result.NewFailedSLIResult(name, "error parsing USQL query: "+err.Error())
+		return result.NewFailedSLIResult(name, "error parsing USQL query: "+err.Error(), result.ErrorCodeInvalidDefinition)
 	}
 
 	request := dynatrace.NewUSQLClientQueryRequest(query.GetQuery(), p.timeframe)
 	usqlResult, err := dynatrace.NewUSQLClient(p.client).GetByQuery(ctx, request)
 	if err != nil {
-		return result.NewFailedSLIResultWithQuery(name, "error querying User sessions API: "+err.Error(), request.RequestString())
+		return result.NewFailedSLIResultWithQuery(name, "error querying User sessions API: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
 	}
 
 	if query.GetResultType() == v1usql.SingleValueResultType {
 		if len(usqlResult.ColumnNames) != 1 || len(usqlResult.Values) != 1 {
-			return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("USQL result type %s should only return a single result", v1usql.SingleValueResultType), request.RequestString())
+			return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("USQL result type %s should only return a single result", v1usql.SingleValueResultType), request.RequestString(), result.ErrorCodeUnexpectedResult)
 		}
 		value, err := tryCastDimensionValueToNumeric(usqlResult.Values[0][0])
 		if err != nil {
-			return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString())
+			return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString(), result.ErrorCodeUnexpectedResult)
 		}
-		return result.NewSuccessfulSLIResultWithQuery(name, value, request.RequestString())
+		return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, request.RequestString(), unit.Unspecified)
 	}
 
 	// all other types must at least have 2 columns to work properly
 	if len(usqlResult.ColumnNames) < 2 {
-		return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("USQL result type %s should at least have two columns", query.GetResultType()), request.RequestString())
+		return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("USQL result type %s should at least have two columns", query.GetResultType()), request.RequestString(), result.ErrorCodeUnexpectedResult)
 	}
 
 	for _, rowValue := range usqlResult.Values {
@@ -153,24 +228,24 @@ func (p *Processing) executeUSQLQuery(ctx context.Context, name string, usqlQuer
 			dimensionValue = rowValue[len(rowValue)-1]
 		default:
 			// this is unlikely to be reached as it should be handled by the query parser, but a failed result is generated because it is unsupported
-			return result.NewFailedSLIResultWithQuery(name, fmt.Sprintf("unknown USQL result type: %s", query.GetResultType()), request.RequestString())
+			return result.NewFailedSLIResultWithQuery(name, fmt.Sprintf("unknown USQL result type: %s", query.GetResultType()), request.RequestString(), result.ErrorCodeInvalidDefinition)
 		}
 
 		dimensionNameString, err := tryCastDimensionNameToString(dimensionName)
 		if err != nil {
-			return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString())
+			return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString(), result.ErrorCodeUnexpectedResult)
 		}
 
 		if dimensionNameString == query.GetDimension() {
 			value, err := tryCastDimensionValueToNumeric(dimensionValue)
 			if err != nil {
-				return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString())
+				return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString(), result.ErrorCodeUnexpectedResult)
 			}
-			return result.NewSuccessfulSLIResultWithQuery(name, value, request.RequestString())
+			return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, request.RequestString(), unit.Unspecified)
 		}
 	}
 
-	return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("could not find dimension name '%s' in result", query.GetDimension()), request.RequestString())
+	return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("could not find dimension name '%s' in result", query.GetDimension()), request.RequestString(), result.ErrorCodeNoData)
 }
 
 func tryCastDimensionValueToNumeric(dimensionValue interface{}) (float64, error) {
@@ -191,94 +266,446 @@ func tryCastDimensionNameToString(dimensionName interface{}) (string, error) {
 	return "", errors.New("dimension name should be a string")
 }
 
+func (p *Processing) executeCalculatedQuery(name string, calcQuery string, previousResults map[string]result.SLIResult) result.SLIResult {
+	query, err := v1calc.NewQueryParser(calcQuery).Parse()
+	if err != nil {
+		return result.NewFailedSLIResult(name, "error parsing CALC query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	values := make(map[string]float64, len(query.GetIndicators()))
+	var failedIndicators []string
+	var warningIndicators []string
+	for _, indicator := range query.GetIndicators() {
+		sliResult, ok := previousResults[indicator]
+		if !ok {
+			// this is unlikely to be reached as all dependencies are processed first, but a failed result is generated to be safe
+			failedIndicators = append(failedIndicators, indicator)
+			continue
+		}
+
+		switch sliResult.IndicatorResult {
+		case result.IndicatorResultFailed:
+			failedIndicators = append(failedIndicators, indicator)
+		case result.IndicatorResultWarning:
+			warningIndicators = append(warningIndicators, indicator)
+		default:
+			values[indicator] = sliResult.Value
+		}
+	}
+
+	if len(failedIndicators) > 0 {
+		return result.NewFailedSLIResultWithQuery(name, "input indicators failed: "+strings.Join(failedIndicators, ", "), calcQuery, result.ErrorCodeDependency)
+	}
+
+	if len(warningIndicators) > 0 {
+		return result.NewWarningSLIResultWithQuery(name, "input indicators returned warnings: "+strings.Join(warningIndicators, ", "), calcQuery, result.ErrorCodeDependency)
+	}
+
+	value, err := query.Evaluate(values)
+	if err != nil {
+		return result.NewWarningSLIResultWithQuery(name, "error evaluating CALC expression: "+err.Error(), calcQuery, result.ErrorCodeUnexpectedResult)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, calcQuery, unit.Unspecified)
+}
+
 func (p *Processing) executeSLOQuery(ctx context.Context, name string, sloQuery string) result.SLIResult {
 	query, err := v1slo.NewQueryParser(sloQuery).Parse()
 	if err != nil {
-		return result.NewFailedSLIResult(name, "error parsing SLO query: "+err.Error())
+		return result.NewFailedSLIResult(name, "error parsing SLO query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	sloClient := dynatrace.NewSLOClient(p.client)
+	sloID := query.GetSLOID()
+	if sloID == "" {
+		findRequest := dynatrace.NewSLOClientFindRequest(query.GetSLOSelector())
+		sloID, err = sloClient.GetIDBySelector(ctx, findRequest)
+		if err != nil {
+			var matchError *dynatrace.SLOSelectorMatchError
+			if errors.As(err, &matchError) {
+				return result.NewWarningSLIResultWithQuery(name, err.Error(), findRequest.RequestString(), getErrorCodeFromSLOSelectorMatchError(matchError))
+			}
+			return result.NewFailedSLIResultWithQuery(name, "error querying Service level objectives API: "+err.Error(), findRequest.RequestString(), result.ErrorCodeQueryFailed)
+		}
+	}
+
+	request := dynatrace.NewSLOClientGetRequest(sloID, p.timeframe)
+	sloResult, err := sloClient.Get(ctx, request)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error querying Service level objectives API: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
 	}
 
-	request := dynatrace.NewSLOClientGetRequest(query.GetSLOID(), p.timeframe)
-	sloResult, err := dynatrace.NewSLOClient(p.client).Get(ctx, request)
+	value, err := sloResult.GetFieldValue(query.GetField())
 	if err != nil {
-		return result.NewFailedSLIResultWithQuery(name, "error querying Service level objectives API: "+err.Error(), request.RequestString())
+		return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString(), result.ErrorCodeUnexpectedResult)
 	}
 
-	return result.NewSuccessfulSLIResultWithQuery(name, sloResult.EvaluatedPercentage, request.RequestString())
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, request.RequestString(), query.GetUnitID())
+}
+
+// getErrorCodeFromSLOSelectorMatchError returns the error code for an SLO selector that matched either no SLO or multiple SLOs.
+func getErrorCodeFromSLOSelectorMatchError(err *dynatrace.SLOSelectorMatchError) result.ErrorCode {
+	if err.TotalCount() == 0 {
+		return result.ErrorCodeNoData
+	}
+	return result.ErrorCodeMultipleResults
 }
 
 func (p *Processing) executeProblemQuery(ctx context.Context, name string, problemsQuery string) result.SLIResult {
 	query, err := v1problems.NewQueryParser(problemsQuery).Parse()
 	if err != nil {
-		return result.NewFailedSLIResult(name, "error parsing Problems v2 query: "+err.Error())
+		return result.NewFailedSLIResult(name, "error parsing Problems v2 query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewProblemsV2ClientQueryRequest(query.GetQuery(), p.timeframe)
+	if query.GetAggregation() == v1problems.CountAggregation {
+		totalProblemCount, err := dynatrace.NewProblemsV2Client(p.client).GetTotalCountByQuery(ctx, request)
+		if err != nil {
+			return result.NewFailedSLIResultWithQuery(name, "error querying Problems API v2: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
+		}
+
+		return result.NewSuccessfulSLIResultWithQueryAndUnit(name, float64(totalProblemCount), request.RequestString(), query.GetUnitID())
+	}
+
+	matchingProblems, err := dynatrace.NewProblemsV2Client(p.client).GetByQuery(ctx, request)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error querying Problems API v2: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, aggregateProblems(query.GetAggregation(), matchingProblems, p.timeframe), request.RequestString(), query.GetUnitID())
+}
+
+// aggregateProblems aggregates the problems using the specified aggregation, considering only their durations within the timeframe.
+func aggregateProblems(aggregation string, problems []dynatrace.ProblemV2, timeframe common.Timeframe) float64 {
+	switch aggregation {
+	case v1problems.SumDurationMinutesAggregation:
+		var sum time.Duration
+		for _, problem := range problems {
+			sum += problem.GetDurationWithin(timeframe)
+		}
+		return sum.Minutes()
+
+	case v1problems.MaxDurationMinutesAggregation:
+		var maxDuration time.Duration
+		for _, problem := range problems {
+			if duration := problem.GetDurationWithin(timeframe); duration > maxDuration {
+				maxDuration = duration
+			}
+		}
+		return maxDuration.Minutes()
+
+	case v1problems.ImpactedEntitiesAggregation:
+		impactedEntities := make(map[string]struct{})
+		for _, problem := range problems {
+			for _, entity := range problem.ImpactedEntities {
+				impactedEntities[entity.EntityID.ID] = struct{}{}
+			}
+		}
+		return float64(len(impactedEntities))
+
+	default:
+		return float64(len(problems))
+	}
+}
+
+func (p *Processing) executeLogsQuery(ctx context.Context, name string, queryString string) result.SLIResult {
+	query, err := v1logs.NewQueryParser(queryString).Parse()
+	if err != nil {
+		return result.NewFailedSLIResult(name, "error parsing Logs query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewLogsClientQueryRequest(query.GetQuery(), p.timeframe)
+	logCount, err := dynatrace.NewLogsClient(p.client).GetCountByQuery(ctx, request)
+	if err != nil {
+		var limitReachedError *dynatrace.LogsSearchLimitReachedError
+		if errors.As(err, &limitReachedError) {
+			return result.NewFailedSLIResultWithQuery(name, err.Error(), request.RequestString(), result.ErrorCodeUnexpectedResult)
+		}
+		return result.NewFailedSLIResultWithQuery(name, "error querying Logs API v2: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, float64(logCount), request.RequestString(), unit.Count)
+}
+
+func (p *Processing) executeAuditQuery(ctx context.Context, name string, queryString string) result.SLIResult {
+	query, err := v1audit.NewQueryParser(queryString).Parse()
+	if err != nil {
+		return result.NewFailedSLIResult(name, "error parsing Audit logs query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewAuditLogsClientQueryRequest(*query, p.timeframe)
+	auditLogCount, err := dynatrace.NewAuditLogsClient(p.client).GetTotalCountByQuery(ctx, request)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error querying Audit logs API v2: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, float64(auditLogCount), request.RequestString(), unit.Count)
+}
+
+func (p *Processing) executeEntitiesQuery(ctx context.Context, name string, queryString string) result.SLIResult {
+	query, err := v1entities.NewQueryParser(queryString).Parse()
+	if err != nil {
+		return result.NewFailedSLIResult(name, "error parsing Monitored entities query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewEntitiesClientQueryRequest(*query, p.timeframe)
+	entityCount, err := dynatrace.NewEntitiesClient(p.client).GetTotalCountByQuery(ctx, request)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error querying Monitored entities API v2: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, float64(entityCount), request.RequestString(), unit.Count)
+}
+
+func (p *Processing) executeJSONQuery(ctx context.Context, name string, queryString string) result.SLIResult {
+	query, err := v1jsonapi.NewQueryParser(queryString).Parse()
+	if err != nil {
+		return result.NewFailedSLIResult(name, "error parsing JSON query: "+err.Error(), result.ErrorCodeInvalidDefinition)
 	}
 
-	request := dynatrace.NewProblemsV2ClientQueryRequest(*query, p.timeframe)
-	totalProblemCount, err := dynatrace.NewProblemsV2Client(p.client).GetTotalCountByQuery(ctx, request)
+	request := dynatrace.NewJSONAPIClientGetRequest(*query, p.timeframe)
+	document, err := dynatrace.NewJSONAPIClient(p.client).Get(ctx, request)
 	if err != nil {
-		return result.NewFailedSLIResultWithQuery(name, "error querying Problems API v2: "+err.Error(), request.RequestString())
+		return result.NewFailedSLIResultWithQuery(name, "error querying Dynatrace API: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
 	}
 
-	return result.NewSuccessfulSLIResultWithQuery(name, float64(totalProblemCount), request.RequestString())
+	values := query.GetSelector().Select(document)
+	if len(values) != 1 {
+		return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("selector %s should select exactly one value but selected %d", query.GetSelector(), len(values)), request.RequestString(), result.ErrorCodeUnexpectedResult)
+	}
+
+	value, ok := values[0].(float64)
+	if !ok {
+		return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("value selected by %s should be a number", query.GetSelector()), request.RequestString(), result.ErrorCodeUnexpectedResult)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, request.RequestString(), unit.Unspecified)
 }
 
 func (p *Processing) executeSecurityProblemQuery(ctx context.Context, name string, queryString string) result.SLIResult {
 	query, err := v1secpv2.NewQueryParser(queryString).Parse()
 	if err != nil {
-		return result.NewFailedSLIResult(name, "error parsing Security Problems v2 query: "+err.Error())
+		return result.NewFailedSLIResult(name, "error parsing Security Problems v2 query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewSecurityProblemsClientQueryRequest(query.GetQuery(), p.timeframe)
+	if query.GetAggregation() == v1secpv2.CountAggregation && query.GetMinRiskLevel() == "" {
+		totalSecurityProblemCount, err := dynatrace.NewSecurityProblemsClient(p.client).GetTotalCountByQuery(ctx, request)
+		if err != nil {
+			return result.NewFailedSLIResultWithQuery(name, "error querying Security problems API: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
+		}
+
+		return result.NewSuccessfulSLIResultWithQueryAndUnit(name, float64(totalSecurityProblemCount), request.RequestString(), query.GetUnitID())
 	}
 
-	request := dynatrace.NewSecurityProblemsClientQueryRequest(*query, p.timeframe)
-	totalSecurityProblemCount, err := dynatrace.NewSecurityProblemsClient(p.client).GetTotalCountByQuery(ctx, request)
+	securityProblems, err := dynatrace.NewSecurityProblemsClient(p.client).GetByQuery(ctx, request)
 	if err != nil {
-		return result.NewFailedSLIResultWithQuery(name, "error querying Security problems API: "+err.Error(), request.RequestString())
+		return result.NewFailedSLIResultWithQuery(name, "error querying Security problems API: "+err.Error(), request.ListRequestString(), result.ErrorCodeQueryFailed)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, aggregateSecurityProblems(*query, securityProblems), request.ListRequestString(), query.GetUnitID())
+}
+
+// aggregateSecurityProblems aggregates the security problems with a sufficient risk level using the aggregation of the query.
+func aggregateSecurityProblems(query v1secpv2.Query, securityProblems []dynatrace.SecurityProblem) float64 {
+	var count int
+	var sumRiskScore, maxRiskScore float64
+	for _, securityProblem := range securityProblems {
+		if !query.IncludesRiskLevel(securityProblem.RiskAssessment.RiskLevel) {
+			continue
+		}
+
+		count++
+		sumRiskScore += securityProblem.RiskAssessment.RiskScore
+		if securityProblem.RiskAssessment.RiskScore > maxRiskScore {
+			maxRiskScore = securityProblem.RiskAssessment.RiskScore
+		}
 	}
 
-	return result.NewSuccessfulSLIResultWithQuery(name, float64(totalSecurityProblemCount), request.RequestString())
+	switch query.GetAggregation() {
+	case v1secpv2.MaxRiskScoreAggregation:
+		return maxRiskScore
+	case v1secpv2.SumRiskScoreAggregation:
+		return sumRiskScore
+	default:
+		return float64(count)
+	}
+}
+
+func (p *Processing) executeDQLQuery(ctx context.Context, name string, queryString string) result.SLIResult {
+	query, err := v1dql.NewQueryParser(queryString).Parse()
+	if err != nil {
+		return result.NewFailedSLIResult(name, "error parsing DQL query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewDQLClientQueryRequest(query.GetQuery(), p.timeframe)
+	dqlResult, err := dynatrace.NewDQLClient(p.client).GetByQuery(ctx, request)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error querying Grail query API: "+err.Error(), request.RequestString(), result.ErrorCodeQueryFailed)
+	}
+
+	if len(dqlResult.Records) != 1 {
+		return result.NewWarningSLIResultWithQuery(name, fmt.Sprintf("DQL query should return exactly one record but returned %d", len(dqlResult.Records)), request.RequestString(), result.ErrorCodeUnexpectedResult)
+	}
+
+	value, err := dqlResult.Records[0].GetNumericFieldValue(query.GetField())
+	if err != nil {
+		return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString(), result.ErrorCodeUnexpectedResult)
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, request.RequestString(), unit.Unspecified)
+}
+
+func (p *Processing) executeSyntheticQuery(ctx context.Context, name string, queryString string) result.SLIResult {
+	query, err := v1synthetic.NewQueryParser(queryString).Parse()
+	if err != nil {
+		return result.NewFailedSLIResult(name, "error parsing synthetic monitor query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	request := dynatrace.NewSyntheticClientQueryRequest(*query, p.timeframe)
+	value, err := dynatrace.NewSyntheticClient(p.client).GetByQuery(ctx, request)
+	if err != nil {
+		return createSLIResultFromErrorFromMetricsProcessing(err, name, request.RequestString())
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, request.RequestString(), synthetic.GetUnitID(query.GetMetric()))
 }
 
 func (p *Processing) executeMetricsV2Query(ctx context.Context, name string, queryString string) result.SLIResult {
 	query, err := v1mv2.NewQueryParser(queryString).Parse()
 	if err != nil {
-		return result.NewFailedSLIResult(name, "error parsing MV2 query: "+err.Error())
+		return result.NewFailedSLIResult(name, "error parsing MV2 query: "+err.Error(), result.ErrorCodeInvalidDefinition)
+	}
+
+	// MV2 queries are converted on the client side, as the unit is specified by the query rather than the metric definition
+	convertValue := func(value float64, _ string) (float64, string, error) {
+		if query.GetTargetUnit() == "" {
+			scaledValue, scaledUnitID := unit.Scale(query.GetUnit(), value)
+			return scaledValue, scaledUnitID, nil
+		}
+		return unit.Convert(value, query.GetUnit(), query.GetTargetUnit())
 	}
 
-	return p.processMetricsQueryAndMakeSLIResult(ctx, name, query.GetQuery(), query.GetUnit())
+	return p.processMetricsQueryAndMakeSLIResult(ctx, name, query.GetQuery(), "", convertValue, query.GetComparison())
 }
 
 func (p *Processing) executeMetricsQuery(ctx context.Context, name string, queryString string) result.SLIResult {
-	query, err := v1metrics.NewQueryParser(queryString).Parse()
+	query, targetUnitID, err := v1metrics.NewQueryParser(queryString).ParseWithTargetUnit()
 	if err == nil {
-		return p.processMetricsQueryAndMakeSLIResult(ctx, name, *query, "")
+		return p.processMetricsQueryAndMakeSLIResult(ctx, name, *query, targetUnitID, keepMetricsValue, nil)
 	}
 
 	query, legacyErr := v1metrics.NewLegacyQueryParser(queryString).Parse()
 	if legacyErr != nil {
-		return result.NewFailedSLIResult(name, "error parsing Metrics v2 query: "+err.Error())
+		return result.NewFailedSLIResult(name, "error parsing Metrics v2 query: "+err.Error(), result.ErrorCodeInvalidDefinition)
 	}
-	return p.processMetricsQueryAndMakeSLIResult(ctx, name, *query, "")
+	return p.processMetricsQueryAndMakeSLIResult(ctx, name, *query, "", keepMetricsValue, nil)
+}
+
+// metricsValueConverter converts a value returned by a metrics query in the specified unit, which may be empty if unknown, and returns the converted value and its unit or an error.
+type metricsValueConverter func(value float64, unitID string) (float64, string, error)
+
+// keepMetricsValue is a metricsValueConverter that returns values unchanged.
+func keepMetricsValue(value float64, unitID string) (float64, string, error) {
+	return value, unitID, nil
 }
 
-func (p *Processing) processMetricsQueryAndMakeSLIResult(ctx context.Context, name string, query metrics.Query, metricUnit string) result.SLIResult {
-	request := dynatrace.NewMetricsClientQueryRequest(query, p.timeframe)
+// processMetricsQueryAndMakeSLIResult runs the metrics query for the timeframe, converting the values server-side to the target unit, if any, and client-side using the converter, and returns the value as SLIResult.
+// If a comparison is specified, the query is also run for the comparison timeframe and the difference between the values is returned.
+func (p *Processing) processMetricsQueryAndMakeSLIResult(ctx context.Context, name string, query metrics.Query, targetUnitID string, convertValue metricsValueConverter, comparison *v1mv2.Comparison) result.SLIResult {
+	v, err := p.processMetricsQuery(ctx, query, targetUnitID, p.timeframe)
+	if err != nil {
+		return createSLIResultFromErrorFromMetricsProcessing(err, name, v.requestString).WithDiagnostics(v.diagnostics)
+	}
+
+	value, valueUnitID, err := convertValue(v.value, v.unitID)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error converting value: "+err.Error(), v.requestString, result.ErrorCodeUnitConversion).WithDiagnostics(v.diagnostics)
+	}
+
+	if comparison == nil {
+		return result.NewSuccessfulSLIResultWithQueryAndUnit(name, value, v.requestString, valueUnitID).WithDiagnostics(v.diagnostics)
+	}
+
+	comparisonTimeframe, err := comparison.GetComparisonTimeframe(p.timeframe)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error creating comparison timeframe: "+err.Error(), v.requestString, result.ErrorCodeInvalidDefinition).WithDiagnostics(v.diagnostics)
+	}
+
+	c, err := p.processMetricsQuery(ctx, query, targetUnitID, *comparisonTimeframe)
+	requestStrings := v.requestString + ";" + c.requestString
+	if err != nil {
+		return createSLIResultFromErrorFromMetricsProcessing(err, name, requestStrings).WithDiagnostics(combineDiagnostics(c.diagnostics, v.diagnostics))
+	}
+
+	comparisonValue, _, err := convertValue(c.value, c.unitID)
+	if err != nil {
+		return result.NewFailedSLIResultWithQuery(name, "error converting value: "+err.Error(), requestStrings, result.ErrorCodeUnitConversion).WithDiagnostics(combineDiagnostics(c.diagnostics, v.diagnostics))
+	}
+
+	diagnostics := combineDiagnostics(v.diagnostics, c.diagnostics)
+	difference, err := comparison.Compare(value, comparisonValue)
+	if err != nil {
+		return result.NewWarningSLIResultWithQuery(name, err.Error(), requestStrings, result.ErrorCodeUnexpectedResult).WithDiagnostics(diagnostics)
+	}
+
+	// a relative difference is a percentage regardless of the unit of the values
+	if comparison.GetMode() == v1mv2.RelativeMode {
+		valueUnitID = unit.Percent
+	}
+
+	return result.NewSuccessfulSLIResultWithQueryAndUnit(name, difference, requestStrings, valueUnitID).WithDiagnostics(diagnostics)
+}
+
+// combineDiagnostics returns the base diagnostics with the warnings and retries of the other diagnostics appended.
+func combineDiagnostics(base result.Diagnostics, other result.Diagnostics) result.Diagnostics {
+	base.Warnings = append(append([]string{}, base.Warnings...), other.Warnings...)
+	base.Retries = append(append([]string{}, base.Retries...), other.Retries...)
+	return base
+}
+
+// metricsQueryValue is the single value returned by a metrics query together with its unit, if known, the request string used and diagnostics.
+type metricsQueryValue struct {
+	value         float64
+	unitID        string
+	requestString string
+	diagnostics   result.Diagnostics
+}
+
+// processMetricsQuery runs the metrics query for the specified timeframe, converting it to the target unit if specified, and returns the single resulting value or an error.
+// Even if an error is returned, the request string and diagnostics of the returned metricsQueryValue are set.
+func (p *Processing) processMetricsQuery(ctx context.Context, query metrics.Query, targetUnitID string, timeframe common.Timeframe) (metricsQueryValue, error) {
+	request := dynatrace.NewMetricsClientQueryRequest(query, timeframe)
 	metricsClient := dynatrace.NewMetricsClient(p.client)
-	results, err := dynatrace.NewRetryForSingleValueMetricsProcessingDecorator(metricsClient, dynatrace.NewMetricsProcessingThatAllowsOnlyOneResult(metricsClient)).ProcessRequest(ctx, request)
+
+	decorator := dynatrace.NewRetryForSingleValueMetricsProcessingDecorator(metricsClient, dynatrace.NewMetricsProcessingThatAllowsOnlyOneResult(metricsClient))
+	if targetUnitID != "" {
+		decorator = dynatrace.NewConvertUnitsAndRetryForSingleValueMetricsProcessingDecorator(metricsClient, targetUnitID, dynatrace.NewMetricsProcessingThatAllowsOnlyOneResult(metricsClient))
+	}
+
+	results, err := decorator.ProcessRequest(ctx, request)
 	if err != nil {
-		return createSLIResultFromErrorFromMetricsProcessing(err, name, request)
+		return metricsQueryValue{requestString: request.RequestString(), diagnostics: dynatrace.NewMetricsDiagnosticsFromError(request, err)}, err
 	}
 
 	r, err := results.FirstResultOrError()
 	if err != nil {
-		return createSLIResultFromErrorFromMetricsProcessing(err, name, request)
+		return metricsQueryValue{requestString: request.RequestString(), diagnostics: dynatrace.NewMetricsDiagnosticsFromError(request, err)}, err
 	}
 
 	resultsRequest := results.Request()
-	return result.NewSuccessfulSLIResultWithQuery(name, unit.ScaleData(metricUnit, r.Value()), resultsRequest.RequestString())
+	return metricsQueryValue{
+		value:         r.Value(),
+		unitID:        results.UnitID(),
+		requestString: resultsRequest.RequestString(),
+		diagnostics:   dynatrace.NewMetricsDiagnostics(results),
+	}, nil
 }
 
-func createSLIResultFromErrorFromMetricsProcessing(err error, name string, request dynatrace.MetricsClientQueryRequest) result.SLIResult {
+func createSLIResultFromErrorFromMetricsProcessing(err error, name string, requestString string) result.SLIResult {
 	var qpErrorType *dynatrace.MetricsQueryProcessingError
 	if errors.As(err, &qpErrorType) {
-		return result.NewWarningSLIResultWithQuery(name, err.Error(), request.RequestString())
+		return result.NewWarningSLIResultWithQuery(name, err.Error(), requestString, dynatrace.GetErrorCodeFromMetricsError(err)).WithDiagnostics(dynatrace.NewDiagnosticsFromMetricsError(err))
 	}
-	return result.NewFailedSLIResultWithQuery(name, err.Error(), request.RequestString())
+	return result.NewFailedSLIResultWithQuery(name, err.Error(), requestString, dynatrace.GetErrorCodeFromMetricsError(err)).WithDiagnostics(dynatrace.NewDiagnosticsFromMetricsError(err))
 }
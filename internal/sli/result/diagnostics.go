@@ -0,0 +1,50 @@
+package result
+
+import "github.com/keptn-contrib/dynatrace-service/internal/common"
+
+// ErrorCode is a machine-readable classification of why an SLIResult is not successful.
+type ErrorCode string
+
+const (
+	// ErrorCodeInvalidDefinition represents an SLI definition that could not be found or parsed.
+	ErrorCodeInvalidDefinition ErrorCode = "invalid_definition"
+
+	// ErrorCodeQueryFailed represents a query that could not be executed successfully.
+	ErrorCodeQueryFailed ErrorCode = "query_failed"
+
+	// ErrorCodeUnexpectedResult represents a query that returned a result that could not be turned into a single value.
+	ErrorCodeUnexpectedResult ErrorCode = "unexpected_result"
+
+	// ErrorCodeNoData represents a query that returned no data or a 'null' value.
+	ErrorCodeNoData ErrorCode = "no_data"
+
+	// ErrorCodeMultipleResults represents a query that returned multiple series or values where only one is supported.
+	ErrorCodeMultipleResults ErrorCode = "multiple_results"
+
+	// ErrorCodeUnitConversion represents a value that could not be converted to the requested unit.
+	ErrorCodeUnitConversion ErrorCode = "unit_conversion"
+
+	// ErrorCodeDependency represents an indicator that could not be calculated because of the indicators it depends on.
+	ErrorCodeDependency ErrorCode = "dependency"
+
+	// ErrorCodeProcessing represents an error that prevented all indicators from being processed.
+	ErrorCodeProcessing ErrorCode = "processing"
+)
+
+// Diagnostics provides structured details on how an SLIResult was obtained.
+type Diagnostics struct {
+	// ErrorCode classifies the failure or warning, or is empty for a successful result.
+	ErrorCode ErrorCode
+
+	// Warnings are any warnings returned by the Dynatrace API.
+	Warnings []string
+
+	// EntitySelector is the resolved entity selector used by the query, if any.
+	EntitySelector string
+
+	// Timeframe is the effective timeframe of the query or nil if it is not known.
+	Timeframe *common.Timeframe
+
+	// Retries are any modifications applied to the query before retrying it, e.g. "resolution=Inf" or "fold".
+	Retries []string
+}
@@ -0,0 +1,39 @@
+package result
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+// TestSLIResult_WithDiagnostics tests that diagnostics without an error code keep the error code of the SLIResult.
+func TestSLIResult_WithDiagnostics(t *testing.T) {
+	r := NewFailedSLIResultWithQuery(metricAName, errorQueryingAPIMessage, "query", ErrorCodeQueryFailed).WithDiagnostics(Diagnostics{EntitySelector: "type(SERVICE)"})
+	assert.EqualValues(t, ErrorCodeQueryFailed, r.Diagnostics.ErrorCode)
+	assert.EqualValues(t, "type(SERVICE)", r.Diagnostics.EntitySelector)
+
+	r = r.WithDiagnostics(Diagnostics{ErrorCode: ErrorCodeNoData})
+	assert.EqualValues(t, ErrorCodeNoData, r.Diagnostics.ErrorCode)
+	assert.Empty(t, r.Diagnostics.EntitySelector)
+}
+
+// TestSLIResult_WithDefaultTimeframe tests that the default timeframe is only set if the diagnostics do not already include one.
+func TestSLIResult_WithDefaultTimeframe(t *testing.T) {
+	start := time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC)
+	timeframe, err := common.NewTimeframe(start, start.Add(time.Hour))
+	assert.NoError(t, err)
+	otherTimeframe, err := common.NewTimeframe(start.Add(-time.Hour), start)
+	assert.NoError(t, err)
+
+	r := NewSuccessfulSLIResultWithQueryAndUnit(metricAName, 1, "query", unit.Count).WithDefaultTimeframe(*timeframe)
+	if assert.NotNil(t, r.Diagnostics.Timeframe) {
+		assert.EqualValues(t, *timeframe, *r.Diagnostics.Timeframe)
+	}
+
+	r = r.WithDefaultTimeframe(*otherTimeframe)
+	assert.EqualValues(t, *timeframe, *r.Diagnostics.Timeframe)
+}
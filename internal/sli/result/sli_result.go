@@ -1,5 +1,7 @@
 package result
 
+import "github.com/keptn-contrib/dynatrace-service/internal/common"
+
 // IndicatorResultType represents the type of indicator result, i.e. success, warning or fail.
 type IndicatorResultType string
 
@@ -22,42 +24,85 @@ type SLIResult struct {
 	Message         string
 	Query           string
 	IndicatorResult IndicatorResultType
+
+	// Unit is the unit of the value, e.g. MilliSecond, or empty if it is not known.
+	Unit string
+
+	// Diagnostics provides structured details on how the result was obtained.
+	Diagnostics Diagnostics
 }
 
-// NewSuccessfulSLIResultWithQuery creates a new SLIResult with a query and a result of success.
-func NewSuccessfulSLIResultWithQuery(metric string, value float64, query string) SLIResult {
+// NewSuccessfulSLIResultWithQueryAndUnit creates a new SLIResult with a query, the unit of the value and a result of success.
+func NewSuccessfulSLIResultWithQueryAndUnit(metric string, value float64, query string, unitID string) SLIResult {
 	return SLIResult{
 		Metric:          metric,
 		Success:         true,
 		Value:           value,
 		Query:           query,
 		IndicatorResult: IndicatorResultSuccessful,
+		Unit:            unitID,
 	}
 }
 
-// NewWarningSLIResultWithQuery creates a new SLIResult with a query and a result of warning.
-func NewWarningSLIResultWithQuery(metric string, message string, query string) SLIResult {
+// NewWarningSLIResultWithQuery creates a new SLIResult with a query, a result of warning and the error code classifying its cause.
+func NewWarningSLIResultWithQuery(metric string, message string, query string, errorCode ErrorCode) SLIResult {
 	return SLIResult{
 		Metric:          metric,
 		Success:         false,
 		Message:         message,
 		Query:           query,
 		IndicatorResult: IndicatorResultWarning,
+		Diagnostics:     Diagnostics{ErrorCode: errorCode},
 	}
 }
 
-// NewFailedSLIResult creates a new SLIResult with result of fail.
-func NewFailedSLIResult(metric string, message string) SLIResult {
-	return NewFailedSLIResultWithQuery(metric, message, "")
+// NewFailedSLIResult creates a new SLIResult with a result of fail and the error code classifying its cause.
+func NewFailedSLIResult(metric string, message string, errorCode ErrorCode) SLIResult {
+	return NewFailedSLIResultWithQuery(metric, message, "", errorCode)
 }
 
-// NewFailedSLIResultWithQuery creates a new SLIResult with a query and a result of fail.
-func NewFailedSLIResultWithQuery(metric string, message string, query string) SLIResult {
+// NewFailedSLIResultWithQuery creates a new SLIResult with a query, a result of fail and the error code classifying its cause.
+func NewFailedSLIResultWithQuery(metric string, message string, query string, errorCode ErrorCode) SLIResult {
 	return SLIResult{
 		Metric:          metric,
 		Success:         false,
 		Message:         message,
 		Query:           query,
 		IndicatorResult: IndicatorResultFailed,
+		Diagnostics:     Diagnostics{ErrorCode: errorCode},
+	}
+}
+
+// WithErrorCode returns a copy of the SLIResult with the specified error code.
+func (r SLIResult) WithErrorCode(errorCode ErrorCode) SLIResult {
+	r.Diagnostics.ErrorCode = errorCode
+	return r
+}
+
+// WithDiagnostics returns a copy of the SLIResult with the specified diagnostics.
+// The existing error code is kept if the diagnostics do not specify one.
+func (r SLIResult) WithDiagnostics(diagnostics Diagnostics) SLIResult {
+	if diagnostics.ErrorCode == "" {
+		diagnostics.ErrorCode = r.Diagnostics.ErrorCode
+	}
+	r.Diagnostics = diagnostics
+	return r
+}
+
+// WithAdditionalMessage returns a copy of the SLIResult with the specified message appended to its message.
+func (r SLIResult) WithAdditionalMessage(message string) SLIResult {
+	if r.Message == "" {
+		r.Message = message
+	} else {
+		r.Message = r.Message + "; " + message
+	}
+	return r
+}
+
+// WithDefaultTimeframe returns a copy of the SLIResult with the specified timeframe if its diagnostics do not already include one.
+func (r SLIResult) WithDefaultTimeframe(timeframe common.Timeframe) SLIResult {
+	if r.Diagnostics.Timeframe == nil {
+		r.Diagnostics.Timeframe = &timeframe
 	}
+	return r
 }
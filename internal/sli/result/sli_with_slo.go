@@ -13,30 +13,30 @@ func NewSLIWithSLO(sliResult SLIResult, sloDefinition SLO) SLIWithSLO {
 	}
 }
 
-func NewFailedSLIWithSLO(sloDefinition SLO, message string) SLIWithSLO {
+func NewFailedSLIWithSLO(sloDefinition SLO, message string, errorCode ErrorCode) SLIWithSLO {
 	return SLIWithSLO{
-		sliResult:     NewFailedSLIResult(sloDefinition.SLI, message),
+		sliResult:     NewFailedSLIResult(sloDefinition.SLI, message, errorCode),
 		sloDefinition: sloDefinition,
 	}
 }
 
-func NewSuccessfulSLIWithSLOAndQuery(sloDefinition SLO, value float64, query string) SLIWithSLO {
+func NewSuccessfulSLIWithSLOQueryAndUnit(sloDefinition SLO, value float64, query string, unitID string) SLIWithSLO {
 	return SLIWithSLO{
-		sliResult:     NewSuccessfulSLIResultWithQuery(sloDefinition.SLI, value, query),
+		sliResult:     NewSuccessfulSLIResultWithQueryAndUnit(sloDefinition.SLI, value, query, unitID),
 		sloDefinition: sloDefinition,
 	}
 }
 
-func NewFailedSLIWithSLOAndQuery(sloDefinition SLO, sliQuery string, message string) SLIWithSLO {
+func NewFailedSLIWithSLOAndQuery(sloDefinition SLO, sliQuery string, message string, errorCode ErrorCode) SLIWithSLO {
 	return SLIWithSLO{
-		sliResult:     NewFailedSLIResultWithQuery(sloDefinition.SLI, message, sliQuery),
+		sliResult:     NewFailedSLIResultWithQuery(sloDefinition.SLI, message, sliQuery, errorCode),
 		sloDefinition: sloDefinition,
 	}
 }
 
-func NewWarningSLIWithSLOAndQuery(sloDefinition SLO, sliQuery string, message string) SLIWithSLO {
+func NewWarningSLIWithSLOAndQuery(sloDefinition SLO, sliQuery string, message string, errorCode ErrorCode) SLIWithSLO {
 	return SLIWithSLO{
-		sliResult:     NewWarningSLIResultWithQuery(sloDefinition.SLI, message, sliQuery),
+		sliResult:     NewWarningSLIResultWithQuery(sloDefinition.SLI, message, sliQuery, errorCode),
 		sloDefinition: sloDefinition,
 	}
 }
@@ -7,6 +7,8 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 )
 
 const (
@@ -43,7 +45,7 @@ func TestSummarizer_SummaryMessage(t *testing.T) {
 		newSuccessfulSLIWithSLO(metricDSLO, 100),
 		newWarningSLIWithSLO(metricESLO, tooManyPointsMessage),
 		newWarningSLIWithSLO(metricFSLO, noDataPointsMessage),
-		NewFailedSLIWithSLO(metricGSLO, errorQueryingAPIMessage),
+		NewFailedSLIWithSLO(metricGSLO, errorQueryingAPIMessage, ErrorCodeQueryFailed),
 	}
 
 	message := NewSummarizer(results).SummaryMessage()
@@ -89,7 +91,7 @@ func TestSummarizer_OverallResult(t *testing.T) {
 			results: []SLIWithSLO{
 				newSuccessfulSLIWithSLO(metricASLO, 100),
 				newWarningSLIWithSLO(metricBSLO, noDataPointsMessage),
-				NewFailedSLIWithSLO(metricCSLO, errorQueryingAPIMessage),
+				NewFailedSLIWithSLO(metricCSLO, errorQueryingAPIMessage, ErrorCodeQueryFailed),
 			},
 			expectedOverallResult: keptnv2.ResultFailed,
 		},
@@ -105,7 +107,7 @@ func TestSummarizer_OverallResult(t *testing.T) {
 			name: "informational failure has precedence",
 			results: []SLIWithSLO{
 				newSuccessfulSLIWithSLO(metricASLO, 100),
-				NewFailedSLIWithSLO(CreateInformationalSLO(metricBName), errorQueryingAPIMessage),
+				NewFailedSLIWithSLO(CreateInformationalSLO(metricBName), errorQueryingAPIMessage, ErrorCodeQueryFailed),
 			},
 			expectedOverallResult: keptnv2.ResultFailed,
 		},
@@ -229,7 +231,7 @@ func newSuccessfulSLIWithSLO(sloDefinition SLO, value float64) SLIWithSLO {
 }
 
 func newSuccessfulSLIResult(metric string, value float64) SLIResult {
-	return NewSuccessfulSLIResultWithQuery(metric, value, "")
+	return NewSuccessfulSLIResultWithQueryAndUnit(metric, value, "", unit.Unspecified)
 }
 
 func newWarningSLIWithSLO(sloDefinition SLO, message string) SLIWithSLO {
@@ -240,5 +242,5 @@ func newWarningSLIWithSLO(sloDefinition SLO, message string) SLIWithSLO {
 }
 
 func newWarningSLIResult(metric string, message string) SLIResult {
-	return NewWarningSLIResultWithQuery(metric, message, "")
+	return NewWarningSLIResultWithQuery(metric, message, "", ErrorCodeUnexpectedResult)
 }
@@ -0,0 +1,28 @@
+package slo
+
+// SLO result field names that can be retrieved for an SLO.
+const (
+	// EvaluatedPercentageField is the field of the evaluated percentage of the SLO.
+	EvaluatedPercentageField = "evaluatedPercentage"
+
+	// ErrorBudgetField is the field of the remaining error budget of the SLO.
+	ErrorBudgetField = "errorBudget"
+
+	// ErrorBudgetBurnRateField is the field of the error budget burn rate of the SLO.
+	ErrorBudgetBurnRateField = "errorBudgetBurnRate"
+
+	// TargetField is the field of the target of the SLO.
+	TargetField = "target"
+
+	// WarningField is the field of the warning threshold of the SLO.
+	WarningField = "warning"
+)
+
+// IsValidField returns true if the specified field is a supported SLO field.
+func IsValidField(field string) bool {
+	switch field {
+	case EvaluatedPercentageField, ErrorBudgetField, ErrorBudgetBurnRateField, TargetField, WarningField:
+		return true
+	}
+	return false
+}
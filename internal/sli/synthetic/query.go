@@ -0,0 +1,102 @@
+package synthetic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+const (
+	// AvailabilityMetric is the metric for the availability of a synthetic monitor in percent.
+	AvailabilityMetric = "availability"
+
+	// DurationMetric is the metric for the duration of the executions of a synthetic monitor in milliseconds.
+	DurationMetric = "duration"
+)
+
+const (
+	// BrowserMonitorIDPrefix is the prefix of the entity IDs of browser monitors.
+	BrowserMonitorIDPrefix = "SYNTHETIC_TEST-"
+
+	// HTTPMonitorIDPrefix is the prefix of the entity IDs of HTTP monitors.
+	HTTPMonitorIDPrefix = "HTTP_CHECK-"
+)
+
+const (
+	// BrowserMonitorEntityType is the entity type of browser monitors.
+	BrowserMonitorEntityType = "SYNTHETIC_TEST"
+
+	// HTTPMonitorEntityType is the entity type of HTTP monitors.
+	HTTPMonitorEntityType = "HTTP_CHECK"
+)
+
+// Query encapsulates a synthetic monitor query.
+type Query struct {
+	monitorID string
+	metric    string
+}
+
+// NewQuery creates a new Query based on the provided monitor ID and metric or returns an error.
+func NewQuery(monitorID string, metric string) (*Query, error) {
+	if monitorID == "" {
+		return nil, errors.New("monitor ID should not be empty")
+	}
+
+	if !IsBrowserMonitor(monitorID) && !IsHTTPMonitor(monitorID) {
+		return nil, fmt.Errorf("monitor ID should start with %s or %s: %s", BrowserMonitorIDPrefix, HTTPMonitorIDPrefix, monitorID)
+	}
+
+	if metric == "" {
+		return nil, errors.New("metric should not be empty")
+	}
+
+	if !isValidMetric(metric) {
+		return nil, fmt.Errorf("unknown metric: %s", metric)
+	}
+
+	return &Query{
+		monitorID: monitorID,
+		metric:    metric,
+	}, nil
+}
+
+// GetMonitorID returns the monitor ID.
+func (q Query) GetMonitorID() string {
+	return q.monitorID
+}
+
+// GetMetric returns the metric.
+func (q Query) GetMetric() string {
+	return q.metric
+}
+
+// IsBrowserMonitor returns true if the specified monitor ID refers to a browser monitor.
+func IsBrowserMonitor(monitorID string) bool {
+	return strings.HasPrefix(monitorID, BrowserMonitorIDPrefix)
+}
+
+// IsHTTPMonitor returns true if the specified monitor ID refers to a HTTP monitor.
+func IsHTTPMonitor(monitorID string) bool {
+	return strings.HasPrefix(monitorID, HTTPMonitorIDPrefix)
+}
+
+// GetUnitID returns the unit of the values of the specified metric, i.e. Percent for availability and MilliSecond for duration, or an empty string if the metric is unknown.
+func GetUnitID(metric string) string {
+	switch metric {
+	case AvailabilityMetric:
+		return unit.Percent
+	case DurationMetric:
+		return unit.MilliSecond
+	}
+	return ""
+}
+
+func isValidMetric(metric string) bool {
+	switch metric {
+	case AvailabilityMetric, DurationMetric:
+		return true
+	}
+	return false
+}
@@ -125,9 +125,44 @@ func buildMetricsV2DefinitionRequestString(metricID string) string {
 	return fmt.Sprintf("%s/%s", dynatrace.MetricsPath, url.PathEscape(metricID))
 }
 
+// buildAuditLogsRequest builds an Audit logs request string with the specified filter for use in testing.
+func buildAuditLogsRequest(filter string) string {
+	return fmt.Sprintf("%s?filter=%s&from=%s&to=%s", dynatrace.AuditLogsPath, url.QueryEscape(filter), convertTimeStringToUnixMillisecondsString(testSLIStart), convertTimeStringToUnixMillisecondsString(testSLIEnd))
+}
+
+// buildEntitiesRequest builds a Monitored entities request string with the specified entity selector for use in testing.
+func buildEntitiesRequest(entitySelector string) string {
+	return fmt.Sprintf("%s?entitySelector=%s&from=%s&pageSize=1&to=%s", dynatrace.EntitiesPath, url.QueryEscape(entitySelector), convertTimeStringToUnixMillisecondsString(testSLIStart), convertTimeStringToUnixMillisecondsString(testSLIEnd))
+}
+
+// buildEntitiesListRequest builds a Monitored entities request string for listing all entities matching the specified entity selector for use in testing.
+func buildEntitiesListRequest(entitySelector string) string {
+	return fmt.Sprintf("%s?entitySelector=%s&from=%s&pageSize=500&to=%s", dynatrace.EntitiesPath, url.QueryEscape(entitySelector), convertTimeStringToUnixMillisecondsString(testSLIStart), convertTimeStringToUnixMillisecondsString(testSLIEnd))
+}
+
+// buildLogsRequest builds a Logs request string with the specified query for use in testing.
+func buildLogsRequest(query string) string {
+	return fmt.Sprintf("%s?from=%s&limit=10000&query=%s&to=%s", dynatrace.LogsSearchPath, convertTimeStringToUnixMillisecondsString(testSLIStart), url.QueryEscape(query), convertTimeStringToUnixMillisecondsString(testSLIEnd))
+}
+
 // buildProblemsV2Request builds a Problems V2 request string with the specified problem selector for use in testing.
 func buildProblemsV2Request(problemSelector string) string {
-	return fmt.Sprintf("%s?from=%s&problemSelector=%s&to=%s", dynatrace.ProblemsV2Path, convertTimeStringToUnixMillisecondsString(testSLIStart), url.QueryEscape(problemSelector), convertTimeStringToUnixMillisecondsString(testSLIEnd))
+	return buildProblemsV2RequestWithTimeframe(problemSelector, testSLIStart, testSLIEnd)
+}
+
+// buildProblemsV2RequestWithTimeframe builds a Problems V2 request string with the specified problem selector and timeframe for use in testing.
+func buildProblemsV2RequestWithTimeframe(problemSelector string, start string, end string) string {
+	return fmt.Sprintf("%s?from=%s&problemSelector=%s&to=%s", dynatrace.ProblemsV2Path, convertTimeStringToUnixMillisecondsString(start), url.QueryEscape(problemSelector), convertTimeStringToUnixMillisecondsString(end))
+}
+
+// buildProblemsV2RequestWithEntitySelector builds a Problems V2 request string with the specified problem and entity selector for use in testing.
+func buildProblemsV2RequestWithEntitySelector(problemSelector string, entitySelector string) string {
+	return fmt.Sprintf("%s?entitySelector=%s&from=%s&problemSelector=%s&to=%s", dynatrace.ProblemsV2Path, url.QueryEscape(entitySelector), convertTimeStringToUnixMillisecondsString(testSLIStart), url.QueryEscape(problemSelector), convertTimeStringToUnixMillisecondsString(testSLIEnd))
+}
+
+// buildSecurityProblemsListRequest builds a Security Problems request string including risk assessments with the specified security problem selector for use in testing.
+func buildSecurityProblemsListRequest(securityProblemSelector string) string {
+	return fmt.Sprintf("%s?fields=%%2BriskAssessment&from=%s&securityProblemSelector=%s&to=%s", dynatrace.SecurityProblemsPath, convertTimeStringToUnixMillisecondsString(testSLIStart), url.QueryEscape(securityProblemSelector), convertTimeStringToUnixMillisecondsString(testSLIEnd))
 }
 
 // buildSecurityProblemsRequest builds a Security Problems request string with the specified security problem selector for use in testing.
@@ -135,11 +170,26 @@ func buildSecurityProblemsRequest(securityProblemSelector string) string {
 	return fmt.Sprintf("%s?from=%s&securityProblemSelector=%s&to=%s", dynatrace.SecurityProblemsPath, convertTimeStringToUnixMillisecondsString(testSLIStart), url.QueryEscape(securityProblemSelector), convertTimeStringToUnixMillisecondsString(testSLIEnd))
 }
 
+// buildSLOFindRequest builds a SLO request string to find SLOs with the specified SLO selector for use in testing.
+func buildSLOFindRequest(sloSelector string) string {
+	return fmt.Sprintf("%s?evaluate=false&sloSelector=%s", dynatrace.SLOPath, url.QueryEscape(sloSelector))
+}
+
 // buildSLORequest builds a SLO request string with the specified SLO ID for use in testing.
 func buildSLORequest(sloID string) string {
 	return fmt.Sprintf("%s/%s?from=%s&timeFrame=GTF&to=%s", dynatrace.SLOPath, url.PathEscape(sloID), convertTimeStringToUnixMillisecondsString(testSLIStart), convertTimeStringToUnixMillisecondsString(testSLIEnd))
 }
 
+// buildDQLRequest builds a DQL request string with the specified query for use in testing.
+func buildDQLRequest(query string) string {
+	return fmt.Sprintf("%s?defaultTimeframeEnd=%s&defaultTimeframeStart=%s&query=%s", dynatrace.DQLExecutePath, url.QueryEscape(testSLIEnd), url.QueryEscape(testSLIStart), url.QueryEscape(query))
+}
+
+// buildSyntheticMetricsRequest builds a Metrics v2 request string for the specified synthetic metric and monitor ID for use in testing.
+func buildSyntheticMetricsRequest(metricID string, monitorID string) string {
+	return newMetricsV2QueryRequestBuilder(metricID + ":splitBy():avg").copyWithEntitySelector(fmt.Sprintf("entityId(%q)", monitorID)).copyWithResolution(resolutionInf).build()
+}
+
 // buildUSQLRequest builds a USQL request string with the specified query for use in testing.
 func buildUSQLRequest(query string) string {
 	return fmt.Sprintf("%s?addDeepLinkFields=false&endTimestamp=%s&explain=false&query=%s&startTimestamp=%s", dynatrace.USQLPath, convertTimeStringToUnixMillisecondsString(testSLIEnd), url.QueryEscape(query), convertTimeStringToUnixMillisecondsString(testSLIStart))
@@ -255,6 +305,16 @@ func createSuccessfulSLIResultAssertionsFunc(expectedMetric string, expectedValu
 	}
 }
 
+func createSuccessfulSLIResultWithMessageAssertionsFunc(expectedMetric string, expectedValue float64, expectedQuery string, expectedMessageSubstrings ...string) func(t *testing.T, actual sliResult) {
+	return func(t *testing.T, actual sliResult) {
+		assert.EqualValues(t, expectedMetric, actual.Metric, "Indicator metric should match")
+		assert.EqualValues(t, expectedValue, actual.Value, "Indicator values should match")
+		assert.EqualValues(t, expectedQuery, actual.Query, "Indicator query should match")
+		assertMessageContainsSubstrings(t, actual.Message, expectedMessageSubstrings...)
+		assert.True(t, actual.Success, "Indicator success should be true")
+	}
+}
+
 func createFailedSLIResultAssertionsFunc(expectedMetric string, expectedMessageSubstrings ...string) func(*testing.T, sliResult) {
 	return func(t *testing.T, actual sliResult) {
 		assert.False(t, actual.Success, "Indicator success should be false")
@@ -441,6 +501,11 @@ func (m *getSLIsAndGetSLOsConfigClientMock) GetSLOs(_ context.Context, _ string,
 	return m.slos, nil
 }
 
+func (m *getSLIsAndGetSLOsConfigClientMock) GetDashboard(_ context.Context, _ string, _ string, _ string, _ string) (string, error) {
+	m.t.Fatalf("GetDashboard() should not be needed in this mock!")
+	return "", nil
+}
+
 func (m *getSLIsAndGetSLOsConfigClientMock) UploadSLOs(_ context.Context, _ string, _ string, _ string, _ *keptnapi.ServiceLevelObjectives) error {
 	m.t.Fatalf("UploadSLOs() should not be needed in this mock!")
 	return nil
@@ -492,8 +557,10 @@ func (m *eventSenderClientMock) SendCloudEvent(factory adapter.CloudEventFactory
 }
 
 // uploadSLOsConfigClientMock is a mock implementation of configClientInterface which provides a mock implementation of UploadSLOs which optionally returns an error.
+// GetSLOs returns the optional stored SLOs or a keptn.ResourceNotFoundError.
 type uploadSLOsConfigClientMock struct {
 	t               *testing.T
+	storedSLOs      *keptnapi.ServiceLevelObjectives
 	uploadSLOsError error
 	slosUploaded    bool
 	uploadedSLOs    *keptnapi.ServiceLevelObjectives
@@ -515,9 +582,17 @@ func (m *uploadSLOsConfigClientMock) GetSLIs(_ context.Context, _ string, _ stri
 	return nil, nil
 }
 
-func (m *uploadSLOsConfigClientMock) GetSLOs(_ context.Context, _ string, _ string, _ string) (*keptnapi.ServiceLevelObjectives, error) {
-	m.t.Fatalf("GetSLOs() should not be needed in this mock!")
-	return nil, nil
+func (m *uploadSLOsConfigClientMock) GetSLOs(_ context.Context, project string, stage string, service string) (*keptnapi.ServiceLevelObjectives, error) {
+	if m.storedSLOs == nil {
+		return nil, keptn.NewResourceNotFoundError("slo.yaml", project, stage, service)
+	}
+
+	return m.storedSLOs, nil
+}
+
+func (m *uploadSLOsConfigClientMock) GetDashboard(_ context.Context, _ string, _ string, _ string, _ string) (string, error) {
+	m.t.Fatalf("GetDashboard() should not be needed in this mock!")
+	return "", nil
 }
 
 func (m *uploadSLOsConfigClientMock) UploadSLOs(_ context.Context, _ string, _ string, _ string, slos *keptnapi.ServiceLevelObjectives) error {
@@ -577,6 +652,13 @@ func (b *metricsV2QueryRequestBuilder) copyWithMZSelector(mzSelector string) *me
 	return &metricsV2QueryRequestBuilder{values: values}
 }
 
+func (b *metricsV2QueryRequestBuilder) copyWithTimeframe(start string, end string) *metricsV2QueryRequestBuilder {
+	values := cloneURLValues(b.values)
+	values.Set("from", convertTimeStringToUnixMillisecondsString(start))
+	values.Set("to", convertTimeStringToUnixMillisecondsString(end))
+	return &metricsV2QueryRequestBuilder{values: values}
+}
+
 func (b *metricsV2QueryRequestBuilder) build() string {
 	return fmt.Sprintf("%s?%s", dynatrace.MetricsQueryPath, b.values.Encode())
 }
@@ -1,35 +1,210 @@
 package unit
 
 import (
-	"regexp"
+	"fmt"
+	"strings"
 )
 
-var microSecondPattern = regexp.MustCompile(`^[Mm]icro[Ss]econd$`)
-var bytePattern = regexp.MustCompile(`^[Bb]yte$`)
+// Dimension is the physical dimension of a unit. Only units of the same dimension can be converted into each other.
+type Dimension string
 
-// ScaleData scales data based on the unit, i.e converts microseconds to milliseconds and bytes to Kilobytes.
-func ScaleData(unit string, value float64) float64 {
-	if isMicroSecondUnit(unit) {
-		// scale from microseconds to milliseconds
-		return value / 1000.0
+const (
+	// TimeDimension is the dimension of time units, e.g. MilliSecond.
+	TimeDimension Dimension = "time"
+
+	// DataDimension is the dimension of data units, e.g. Byte or Bit.
+	DataDimension Dimension = "data"
+
+	// RatioDimension is the dimension of dimensionless fractions, i.e. Percent and Ratio.
+	RatioDimension Dimension = "ratio"
+)
+
+// Unit IDs as used by Dynatrace.
+const (
+	NanoSecond  = "NanoSecond"
+	MicroSecond = "MicroSecond"
+	MilliSecond = "MilliSecond"
+	Second      = "Second"
+	Minute      = "Minute"
+	Hour        = "Hour"
+
+	Bit     = "Bit"
+	KiloBit = "KiloBit"
+	MegaBit = "MegaBit"
+	GigaBit = "GigaBit"
+	TeraBit = "TeraBit"
+	KibiBit = "KibiBit"
+	MebiBit = "MebiBit"
+	GibiBit = "GibiBit"
+	TebiBit = "TebiBit"
+
+	Byte     = "Byte"
+	KiloByte = "KiloByte"
+	MegaByte = "MegaByte"
+	GigaByte = "GigaByte"
+	TeraByte = "TeraByte"
+	KibiByte = "KibiByte"
+	MebiByte = "MebiByte"
+	GibiByte = "GibiByte"
+	TebiByte = "TebiByte"
+
+	Percent = "Percent"
+	Ratio   = "Ratio"
+
+	// Count is the unit of counts, e.g. of problems. It is not part of the catalog as it cannot be converted.
+	Count = "Count"
+
+	// Unspecified is the unit of values without a known unit, e.g. of USQL or DQL results. It is not part of the catalog as it cannot be converted.
+	Unspecified = "Unspecified"
+)
+
+// Unit is a unit of the catalog, defined by its ID, symbol, dimension and factor relative to the other units of its dimension.
+type Unit struct {
+	id        string
+	symbol    string
+	dimension Dimension
+	factor    float64
+}
+
+// ID returns the Dynatrace ID of the unit, e.g. MilliSecond.
+func (u Unit) ID() string {
+	return u.id
+}
+
+// Dimension returns the dimension of the unit.
+func (u Unit) Dimension() Dimension {
+	return u.dimension
+}
+
+// IsConvertibleTo returns true if values of this unit can be converted to the specified unit, i.e. if both have the same dimension.
+func (u Unit) IsConvertibleTo(other Unit) bool {
+	return u.dimension == other.dimension
+}
+
+const (
+	kilo = 1000.0
+	kibi = 1024.0
+)
+
+// catalog lists all known units. Factors are relative to the smallest unit of each dimension, i.e. NanoSecond, Bit and Percent, so that they are integers and conversions are exact wherever possible.
+var catalog = []Unit{
+	{id: NanoSecond, symbol: "ns", dimension: TimeDimension, factor: 1},
+	{id: MicroSecond, symbol: "us", dimension: TimeDimension, factor: kilo},
+	{id: MilliSecond, symbol: "ms", dimension: TimeDimension, factor: kilo * kilo},
+	{id: Second, symbol: "s", dimension: TimeDimension, factor: kilo * kilo * kilo},
+	{id: Minute, symbol: "min", dimension: TimeDimension, factor: 60 * kilo * kilo * kilo},
+	{id: Hour, symbol: "h", dimension: TimeDimension, factor: 3600 * kilo * kilo * kilo},
+
+	{id: Bit, symbol: "bit", dimension: DataDimension, factor: 1},
+	{id: KiloBit, symbol: "kbit", dimension: DataDimension, factor: kilo},
+	{id: MegaBit, symbol: "Mbit", dimension: DataDimension, factor: kilo * kilo},
+	{id: GigaBit, symbol: "Gbit", dimension: DataDimension, factor: kilo * kilo * kilo},
+	{id: TeraBit, symbol: "Tbit", dimension: DataDimension, factor: kilo * kilo * kilo * kilo},
+	{id: KibiBit, symbol: "Kibit", dimension: DataDimension, factor: kibi},
+	{id: MebiBit, symbol: "Mibit", dimension: DataDimension, factor: kibi * kibi},
+	{id: GibiBit, symbol: "Gibit", dimension: DataDimension, factor: kibi * kibi * kibi},
+	{id: TebiBit, symbol: "Tibit", dimension: DataDimension, factor: kibi * kibi * kibi * kibi},
+
+	{id: Byte, symbol: "B", dimension: DataDimension, factor: 8},
+	{id: KiloByte, symbol: "kB", dimension: DataDimension, factor: 8 * kilo},
+	{id: MegaByte, symbol: "MB", dimension: DataDimension, factor: 8 * kilo * kilo},
+	{id: GigaByte, symbol: "GB", dimension: DataDimension, factor: 8 * kilo * kilo * kilo},
+	{id: TeraByte, symbol: "TB", dimension: DataDimension, factor: 8 * kilo * kilo * kilo * kilo},
+	{id: KibiByte, symbol: "KiB", dimension: DataDimension, factor: 8 * kibi},
+	{id: MebiByte, symbol: "MiB", dimension: DataDimension, factor: 8 * kibi * kibi},
+	{id: GibiByte, symbol: "GiB", dimension: DataDimension, factor: 8 * kibi * kibi * kibi},
+	{id: TebiByte, symbol: "TiB", dimension: DataDimension, factor: 8 * kibi * kibi * kibi * kibi},
+
+	{id: Percent, symbol: "%", dimension: RatioDimension, factor: 1},
+	{id: Ratio, symbol: "ratio", dimension: RatioDimension, factor: 100},
+}
+
+// Lookup finds the unit of the catalog with the specified ID, matched case-insensitively, e.g. MilliSecond or millisecond, or symbol, matched case-sensitively, e.g. ms or MiB.
+func Lookup(unitID string) (Unit, bool) {
+	for _, u := range catalog {
+		if u.symbol == unitID {
+			return u, true
+		}
 	}
 
-	if isByteUnit(unit) {
-		// convert Bytes to Kilobyte
-		return value / 1024
+	for _, u := range catalog {
+		if strings.EqualFold(u.id, unitID) {
+			return u, true
+		}
 	}
 
-	return value
+	return Unit{}, false
+}
+
+// UnknownUnitError represents the error that a unit is not part of the catalog.
+type UnknownUnitError struct {
+	unitID string
+}
+
+func (e *UnknownUnitError) Error() string {
+	return fmt.Sprintf("unknown unit '%s'", e.unitID)
+}
+
+// IncompatibleUnitsError represents the error that a value cannot be converted between two units of different dimensions.
+type IncompatibleUnitsError struct {
+	sourceUnitID string
+	targetUnitID string
 }
 
-func canBeConverted(unit string) bool {
-	return isByteUnit(unit) || isMicroSecondUnit(unit)
+func (e *IncompatibleUnitsError) Error() string {
+	return fmt.Sprintf("cannot convert from '%s' to '%s'", e.sourceUnitID, e.targetUnitID)
 }
 
-func isByteUnit(unit string) bool {
-	return bytePattern.MatchString(unit)
+// NewIncompatibleUnitsError creates a new IncompatibleUnitsError for the specified source and target units.
+func NewIncompatibleUnitsError(sourceUnitID string, targetUnitID string) *IncompatibleUnitsError {
+	return &IncompatibleUnitsError{sourceUnitID: sourceUnitID, targetUnitID: targetUnitID}
+}
+
+// Convert converts the value from the source unit to the target unit, returning the converted value and the ID of the target unit or an error if either unit is unknown or they are incompatible.
+func Convert(value float64, sourceUnitID string, targetUnitID string) (float64, string, error) {
+	source, ok := Lookup(sourceUnitID)
+	if !ok {
+		return 0, "", &UnknownUnitError{unitID: sourceUnitID}
+	}
+
+	target, ok := Lookup(targetUnitID)
+	if !ok {
+		return 0, "", &UnknownUnitError{unitID: targetUnitID}
+	}
+
+	if !source.IsConvertibleTo(target) {
+		return 0, "", NewIncompatibleUnitsError(source.id, target.id)
+	}
+
+	// dividing or multiplying by the exact ratio of the factors avoids rounding errors, e.g. when converting from MicroSecond to MilliSecond
+	if source.factor < target.factor {
+		return value / (target.factor / source.factor), target.id, nil
+	}
+	return value * (source.factor / target.factor), target.id, nil
+}
+
+// Scale scales data based on the unit, i.e converts microseconds to milliseconds and bytes to kibibytes, and returns the scaled value together with the resulting unit.
+// Values of other units are returned unchanged together with the ID of their unit, or the specified ID if the unit is unknown, e.g. Count or PerSecond.
+func Scale(unitID string, value float64) (float64, string) {
+	u, ok := Lookup(unitID)
+	if !ok {
+		return value, unitID
+	}
+
+	switch u.id {
+	case MicroSecond:
+		scaledValue, scaledUnitID, _ := Convert(value, u.id, MilliSecond)
+		return scaledValue, scaledUnitID
+	case Byte:
+		scaledValue, scaledUnitID, _ := Convert(value, u.id, KibiByte)
+		return scaledValue, scaledUnitID
+	default:
+		return value, u.id
+	}
 }
 
-func isMicroSecondUnit(unit string) bool {
-	return microSecondPattern.MatchString(unit)
+// ScaleData scales data based on the unit, i.e converts microseconds to milliseconds and bytes to kibibytes.
+func ScaleData(unitID string, value float64) float64 {
+	scaledValue, _ := Scale(unitID, value)
+	return scaledValue
 }
@@ -68,3 +68,114 @@ func TestScaleDataWithUnit(t *testing.T) {
 		})
 	}
 }
+
+// TestScale tests that the unit of scaled values is returned, as is the original unit ID of unknown units.
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name           string
+		unit           string
+		expectedValue  float64
+		expectedUnitID string
+	}{
+		{
+			name:           "microseconds are scaled to milliseconds",
+			unit:           "microsecond",
+			expectedValue:  2,
+			expectedUnitID: MilliSecond,
+		},
+		{
+			name:           "known units are unchanged",
+			unit:           "percent",
+			expectedValue:  2000,
+			expectedUnitID: Percent,
+		},
+		{
+			name:           "count is unchanged",
+			unit:           Count,
+			expectedValue:  2000,
+			expectedUnitID: Count,
+		},
+		{
+			name:           "unknown units are unchanged",
+			unit:           "PerSecond",
+			expectedValue:  2000,
+			expectedUnitID: "PerSecond",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, unitID := Scale(tt.unit, 2000)
+
+			assert.EqualValues(t, tt.expectedValue, value)
+			assert.EqualValues(t, tt.expectedUnitID, unitID)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name         string
+		unitID       string
+		expectedID   string
+		expectedFind bool
+	}{
+		{name: "ID works", unitID: "MilliSecond", expectedID: MilliSecond, expectedFind: true},
+		{name: "lowercase ID works", unitID: "millisecond", expectedID: MilliSecond, expectedFind: true},
+		{name: "time symbol works", unitID: "ns", expectedID: NanoSecond, expectedFind: true},
+		{name: "SI data symbol works", unitID: "MB", expectedID: MegaByte, expectedFind: true},
+		{name: "IEC data symbol works", unitID: "MiB", expectedID: MebiByte, expectedFind: true},
+		{name: "bit symbol works", unitID: "Mbit", expectedID: MegaBit, expectedFind: true},
+		{name: "percent symbol works", unitID: "%", expectedID: Percent, expectedFind: true},
+		{name: "symbols are case-sensitive", unitID: "mib", expectedFind: false},
+		{name: "unknown unit is not found", unitID: "Furlong", expectedFind: false},
+		{name: "count is not part of the catalog", unitID: "Count", expectedFind: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, found := Lookup(tt.unitID)
+			assert.Equal(t, tt.expectedFind, found)
+			if tt.expectedFind {
+				assert.Equal(t, tt.expectedID, u.ID())
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          float64
+		sourceUnitID   string
+		targetUnitID   string
+		expectedValue  float64
+		expectedUnitID string
+		expectedError  string
+	}{
+		{name: "nanoseconds to milliseconds", value: 2500000, sourceUnitID: NanoSecond, targetUnitID: MilliSecond, expectedValue: 2.5, expectedUnitID: MilliSecond},
+		{name: "microseconds to seconds", value: 1500000, sourceUnitID: MicroSecond, targetUnitID: "s", expectedValue: 1.5, expectedUnitID: Second},
+		{name: "hours to minutes", value: 2, sourceUnitID: Hour, targetUnitID: Minute, expectedValue: 120, expectedUnitID: Minute},
+		{name: "bytes to SI kilobytes", value: 2000, sourceUnitID: Byte, targetUnitID: KiloByte, expectedValue: 2, expectedUnitID: KiloByte},
+		{name: "bytes to IEC kibibytes", value: 2048, sourceUnitID: Byte, targetUnitID: KibiByte, expectedValue: 2, expectedUnitID: KibiByte},
+		{name: "gibibytes to mebibytes", value: 1, sourceUnitID: GibiByte, targetUnitID: MebiByte, expectedValue: 1024, expectedUnitID: MebiByte},
+		{name: "bytes to bits", value: 2, sourceUnitID: Byte, targetUnitID: Bit, expectedValue: 16, expectedUnitID: Bit},
+		{name: "megabits to kilobytes", value: 1, sourceUnitID: MegaBit, targetUnitID: KiloByte, expectedValue: 125, expectedUnitID: KiloByte},
+		{name: "ratio to percent", value: 0.25, sourceUnitID: Ratio, targetUnitID: Percent, expectedValue: 25, expectedUnitID: Percent},
+		{name: "percent to ratio", value: 50, sourceUnitID: "%", targetUnitID: Ratio, expectedValue: 0.5, expectedUnitID: Ratio},
+		{name: "time to data fails", value: 1, sourceUnitID: Second, targetUnitID: Byte, expectedError: "cannot convert from 'Second' to 'Byte'"},
+		{name: "unknown source unit fails", value: 1, sourceUnitID: "Furlong", targetUnitID: Byte, expectedError: "unknown unit 'Furlong'"},
+		{name: "unknown target unit fails", value: 1, sourceUnitID: Byte, targetUnitID: "Furlong", expectedError: "unknown unit 'Furlong'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, unitID, err := Convert(tt.value, tt.sourceUnitID, tt.targetUnitID)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expectedValue, value, 1e-9)
+			assert.Equal(t, tt.expectedUnitID, unitID)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/audit"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// AuditPrefix is the prefix of Audit logs queries.
+const AuditPrefix = "AUDIT"
+
+const filterKey = "filter"
+
+// QueryParser will parse a v1 Audit logs query string (usually found in sli.yaml files) into a Query
+type QueryParser struct {
+	query string
+}
+
+// NewQueryParser creates a new QueryParser for the specified Audit logs query string.
+func NewQueryParser(query string) *QueryParser {
+	return &QueryParser{
+		query: strings.TrimSpace(query),
+	}
+}
+
+// Parse parses the query string into a Query or returns an error.
+func (p *QueryParser) Parse() (*audit.Query, error) {
+	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := pieces.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != AuditPrefix {
+		return nil, fmt.Errorf("Audit logs queries should start with %s", AuditPrefix)
+	}
+
+	auditQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePairs, err := common.NewSLIParser(auditQueryString, &auditQueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	query := audit.NewQuery(keyValuePairs.GetValue(filterKey))
+	return &query, nil
+}
+
+type auditQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of an Audit logs query.
+func (v *auditQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case filterKey:
+		return true
+	default:
+		return false
+	}
+}
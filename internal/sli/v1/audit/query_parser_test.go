@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQueryParser tests the QueryParser
+func TestQueryParser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedFilter       string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:           "valid",
+			inputQuery:     "AUDIT;filter=category(\"CONFIG\"),eventType(\"UPDATE\")",
+			expectedFilter: "category(\"CONFIG\"),eventType(\"UPDATE\")",
+		},
+		{
+			name:       "valid - empty",
+			inputQuery: "AUDIT;",
+		},
+		{
+			name:                 "invalid - no AUDIT prefix",
+			inputQuery:           ";filter=category(\"CONFIG\")",
+			expectError:          true,
+			expectedErrorMessage: "Audit logs queries should start with AUDIT",
+		},
+		{
+			name:                 "invalid - unknown key",
+			inputQuery:           "AUDIT;filter=category(\"CONFIG\")&sort=timestamp",
+			expectError:          true,
+			expectedErrorMessage: "unknown key",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, query) {
+					assert.EqualValues(t, tc.expectedFilter, query.GetFilter())
+				}
+			}
+		})
+	}
+}
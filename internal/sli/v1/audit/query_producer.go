@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/audit"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// QueryProducer for Audit logs queries.
+type QueryProducer struct {
+	query audit.Query
+}
+
+// NewQueryProducer creates a QueryProducer for the specified Audit logs Query.
+func NewQueryProducer(query audit.Query) QueryProducer {
+	return QueryProducer{query: query}
+}
+
+// Produce returns the Audit logs query string for a Query.
+func (p QueryProducer) Produce() string {
+	keyValues := make(map[string]string, 1)
+	if p.query.GetFilter() != "" {
+		keyValues[filterKey] = p.query.GetFilter()
+	}
+
+	return common.ProducePrefixedSLI(AuditPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
+}
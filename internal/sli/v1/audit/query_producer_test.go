@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProducer_Produce(t *testing.T) {
+	testConfigs := []struct {
+		name                     string
+		inputAuditQuery          audit.Query
+		expectedAuditQueryString string
+	}{
+		{
+			name:                     "valid with no filter",
+			inputAuditQuery:          audit.NewQuery(""),
+			expectedAuditQueryString: "AUDIT;",
+		},
+		{
+			name:                     "valid with filter",
+			inputAuditQuery:          audit.NewQuery("category(\"CONFIG\")"),
+			expectedAuditQueryString: "AUDIT;filter=category(\"CONFIG\")",
+		},
+	}
+	for _, testConfig := range testConfigs {
+		tc := testConfig
+		t.Run(tc.name, func(t *testing.T) {
+			auditQueryString := NewQueryProducer(tc.inputAuditQuery).Produce()
+			assert.Equal(t, tc.expectedAuditQueryString, auditQueryString)
+		})
+	}
+}
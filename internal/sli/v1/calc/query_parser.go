@@ -0,0 +1,66 @@
+package calc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/calc"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// CalcPrefix is the prefix of calculated SLI queries.
+const CalcPrefix = "CALC"
+
+const expressionKey = "expr"
+
+// QueryParser will parse a v1 calculated SLI query string (usually found in sli.yaml files) into a Query
+type QueryParser struct {
+	query string
+}
+
+// NewQueryParser creates a new QueryParser for the specified calculated SLI query string.
+func NewQueryParser(query string) *QueryParser {
+	return &QueryParser{
+		query: strings.TrimSpace(query),
+	}
+}
+
+// Parse parses the query string into a Query or returns an error.
+func (p *QueryParser) Parse() (*calc.Query, error) {
+	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := pieces.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != CalcPrefix {
+		return nil, fmt.Errorf("calculated SLI queries should start with %s", CalcPrefix)
+	}
+
+	calcQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePairs, err := common.NewSLIParser(calcQueryString, &calcQueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return calc.NewQuery(keyValuePairs.GetValue(expressionKey))
+}
+
+type calcQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of a calculated SLI query.
+func (v *calcQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case expressionKey:
+		return true
+	default:
+		return false
+	}
+}
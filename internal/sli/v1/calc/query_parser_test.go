@@ -0,0 +1,73 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQueryParser tests the QueryParser
+func TestQueryParser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedExpression   string
+		expectedIndicators   []string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:               "valid",
+			inputQuery:         "CALC;expr=errors / requests * 100",
+			expectedExpression: "errors / requests * 100",
+			expectedIndicators: []string{"errors", "requests"},
+		},
+		{
+			name:               "valid - braced indicator names",
+			inputQuery:         "CALC;expr={error-count} / {request-count}",
+			expectedExpression: "{error-count} / {request-count}",
+			expectedIndicators: []string{"error-count", "request-count"},
+		},
+		{
+			name:                 "invalid - no CALC prefix",
+			inputQuery:           ";expr=errors / requests",
+			expectError:          true,
+			expectedErrorMessage: "calculated SLI queries should start with CALC",
+		},
+		{
+			name:                 "invalid - missing expression",
+			inputQuery:           "CALC;",
+			expectError:          true,
+			expectedErrorMessage: "expression should not be empty",
+		},
+		{
+			name:                 "invalid - unknown key",
+			inputQuery:           "CALC;expr=errors / requests&unit=percent",
+			expectError:          true,
+			expectedErrorMessage: "unknown key",
+		},
+		{
+			name:                 "invalid - malformed expression",
+			inputQuery:           "CALC;expr=errors / (requests",
+			expectError:          true,
+			expectedErrorMessage: "missing ')'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, query) {
+					assert.EqualValues(t, tc.expectedExpression, query.GetExpression())
+					assert.EqualValues(t, tc.expectedIndicators, query.GetIndicators())
+				}
+			}
+		})
+	}
+}
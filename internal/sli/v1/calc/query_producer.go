@@ -0,0 +1,25 @@
+package calc
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/calc"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// QueryProducer for calculated SLI queries.
+type QueryProducer struct {
+	query calc.Query
+}
+
+// NewQueryProducer creates a QueryProducer for the specified calculated SLI Query.
+func NewQueryProducer(query calc.Query) QueryProducer {
+	return QueryProducer{query: query}
+}
+
+// Produce returns the calculated SLI query string for a Query.
+func (p QueryProducer) Produce() string {
+	keyValues := map[string]string{
+		expressionKey: p.query.GetExpression(),
+	}
+
+	return common.ProducePrefixedSLI(CalcPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
+}
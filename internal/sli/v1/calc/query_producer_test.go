@@ -0,0 +1,36 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/calc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProducer_Produce(t *testing.T) {
+	testConfigs := []struct {
+		name                    string
+		inputCalcQuery          calc.Query
+		expectedCalcQueryString string
+	}{
+		{
+			name:                    "valid",
+			inputCalcQuery:          newQuery(t, "errors / requests * 100"),
+			expectedCalcQueryString: "CALC;expr=errors / requests * 100",
+		},
+	}
+	for _, testConfig := range testConfigs {
+		tc := testConfig
+		t.Run(tc.name, func(t *testing.T) {
+			calcQueryString := NewQueryProducer(tc.inputCalcQuery).Produce()
+			assert.Equal(t, tc.expectedCalcQueryString, calcQueryString)
+		})
+	}
+}
+
+func newQuery(t *testing.T, expression string) calc.Query {
+	query, err := calc.NewQuery(expression)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
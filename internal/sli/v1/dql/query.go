@@ -0,0 +1,35 @@
+package dql
+
+import (
+	"errors"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dql"
+)
+
+// Query represents a v1 DQL query.
+type Query struct {
+	field string
+	query dql.Query
+}
+
+// NewQuery creates a Query from the specified record field and DQL query or returns an error.
+func NewQuery(field string, query dql.Query) (*Query, error) {
+	if field == "" {
+		return nil, errors.New("field should not be empty")
+	}
+
+	return &Query{
+		field: field,
+		query: query,
+	}, nil
+}
+
+// GetField returns the name of the record field containing the SLI value.
+func (q *Query) GetField() string {
+	return q.field
+}
+
+// GetQuery returns the DQL query.
+func (q *Query) GetQuery() dql.Query {
+	return q.query
+}
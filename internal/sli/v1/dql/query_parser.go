@@ -0,0 +1,74 @@
+package dql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dql"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// DQLPrefix is the prefix of DQL queries.
+const DQLPrefix = "DQL"
+
+const (
+	fieldKey = "field"
+	queryKey = "query"
+)
+
+// QueryParser will parse a v1 DQL query string (usually found in sli.yaml files) into a Query
+type QueryParser struct {
+	query string
+}
+
+// NewQueryParser creates a new QueryParser for the specified DQL query string.
+func NewQueryParser(query string) *QueryParser {
+	return &QueryParser{
+		query: strings.TrimSpace(query),
+	}
+}
+
+// Parse parses the query string into a Query or returns an error.
+func (p *QueryParser) Parse() (*Query, error) {
+	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := pieces.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != DQLPrefix {
+		return nil, fmt.Errorf("DQL queries should start with %s", DQLPrefix)
+	}
+
+	dqlQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePairs, err := common.NewSLIParser(dqlQueryString, &dqlQueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	innerQuery, err := dql.NewQuery(keyValuePairs.GetValue(queryKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewQuery(keyValuePairs.GetValue(fieldKey), *innerQuery)
+}
+
+type dqlQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of a DQL query.
+func (v *dqlQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case fieldKey, queryKey:
+		return true
+	default:
+		return false
+	}
+}
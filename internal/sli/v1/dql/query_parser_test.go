@@ -0,0 +1,73 @@
+package dql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQueryParser tests the QueryParser
+func TestQueryParser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedField        string
+		expectedQuery        string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:          "valid",
+			inputQuery:    "DQL;field=error_count&query=fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()",
+			expectedField: "error_count",
+			expectedQuery: "fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()",
+		},
+		{
+			name:          "valid - different order",
+			inputQuery:    "DQL;query=fetch logs | summarize c = count()&field=c",
+			expectedField: "c",
+			expectedQuery: "fetch logs | summarize c = count()",
+		},
+		{
+			name:                 "invalid - no DQL prefix",
+			inputQuery:           ";field=c&query=fetch logs | summarize c = count()",
+			expectError:          true,
+			expectedErrorMessage: "DQL queries should start with DQL",
+		},
+		{
+			name:                 "invalid - missing field",
+			inputQuery:           "DQL;query=fetch logs | summarize c = count()",
+			expectError:          true,
+			expectedErrorMessage: "field should not be empty",
+		},
+		{
+			name:                 "invalid - missing query",
+			inputQuery:           "DQL;field=c",
+			expectError:          true,
+			expectedErrorMessage: "DQL query should not be empty",
+		},
+		{
+			name:                 "invalid - unknown key",
+			inputQuery:           "DQL;field=c&query=fetch logs | summarize c = count()&timeframe=2h",
+			expectError:          true,
+			expectedErrorMessage: "unknown key",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, query) {
+					assert.EqualValues(t, tc.expectedField, query.GetField())
+					assert.EqualValues(t, tc.expectedQuery, query.GetQuery().GetQuery())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package dql
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// QueryProducer for DQL queries.
+type QueryProducer struct {
+	query Query
+}
+
+// NewQueryProducer creates a QueryProducer for the specified DQL Query.
+func NewQueryProducer(query Query) QueryProducer {
+	return QueryProducer{query: query}
+}
+
+// Produce returns the DQL query string for a Query.
+func (p QueryProducer) Produce() string {
+	keyValues := map[string]string{
+		fieldKey: p.query.GetField(),
+		queryKey: p.query.GetQuery().GetQuery(),
+	}
+
+	return common.ProducePrefixedSLI(DQLPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
+}
@@ -0,0 +1,40 @@
+package dql
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProducer_Produce(t *testing.T) {
+	testConfigs := []struct {
+		name                   string
+		inputDQLQuery          Query
+		expectedDQLQueryString string
+	}{
+		{
+			name:                   "valid",
+			inputDQLQuery:          newQuery(t, "error_count", "fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()"),
+			expectedDQLQueryString: "DQL;field=error_count&query=fetch logs | filter loglevel == \"ERROR\" | summarize error_count = count()",
+		},
+	}
+	for _, testConfig := range testConfigs {
+		tc := testConfig
+		t.Run(tc.name, func(t *testing.T) {
+			dqlQueryString := NewQueryProducer(tc.inputDQLQuery).Produce()
+			assert.Equal(t, tc.expectedDQLQueryString, dqlQueryString)
+		})
+	}
+}
+
+func newQuery(t *testing.T, field string, queryString string) Query {
+	innerQuery, err := dql.NewQuery(queryString)
+	assert.NoError(t, err)
+	assert.NotNil(t, innerQuery)
+
+	query, err := NewQuery(field, *innerQuery)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
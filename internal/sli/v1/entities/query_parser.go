@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/entities"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// EntitiesPrefix is the prefix of Monitored entities queries.
+const EntitiesPrefix = "ENTITIES"
+
+const (
+	entitySelectorKey = "entitySelector"
+	fromKey           = "from"
+)
+
+// QueryParser will parse a v1 Monitored entities query string (usually found in sli.yaml files) into a Query
+type QueryParser struct {
+	query string
+}
+
+// NewQueryParser creates a new QueryParser for the specified Monitored entities query string.
+func NewQueryParser(query string) *QueryParser {
+	return &QueryParser{
+		query: strings.TrimSpace(query),
+	}
+}
+
+// Parse parses the query string into a Query or returns an error.
+func (p *QueryParser) Parse() (*entities.Query, error) {
+	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := pieces.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != EntitiesPrefix {
+		return nil, fmt.Errorf("Monitored entities queries should start with %s", EntitiesPrefix)
+	}
+
+	entitiesQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePairs, err := common.NewSLIParser(entitiesQueryString, &entitiesQueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewQuery(keyValuePairs.GetValue(entitySelectorKey), keyValuePairs.GetValue(fromKey))
+}
+
+type entitiesQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of a Monitored entities query.
+func (v *entitiesQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case entitySelectorKey, fromKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQueryParser tests the QueryParser
+func TestQueryParser(t *testing.T) {
+	tests := []struct {
+		name                   string
+		inputQuery             string
+		expectedEntitySelector string
+		expectedFrom           string
+		expectError            bool
+		expectedErrorMessage   string
+	}{
+		{
+			name:                   "valid",
+			inputQuery:             "ENTITIES;entitySelector=type(HOST),tag(\"keptn_stage:staging\")",
+			expectedEntitySelector: "type(HOST),tag(\"keptn_stage:staging\")",
+		},
+		{
+			name:                   "valid - with from",
+			inputQuery:             "ENTITIES;entitySelector=type(PROCESS_GROUP_INSTANCE),healthState(\"UNHEALTHY\")&from=now-2h",
+			expectedEntitySelector: "type(PROCESS_GROUP_INSTANCE),healthState(\"UNHEALTHY\")",
+			expectedFrom:           "now-2h",
+		},
+		{
+			name:                 "invalid - no ENTITIES prefix",
+			inputQuery:           ";entitySelector=type(HOST)",
+			expectError:          true,
+			expectedErrorMessage: "Monitored entities queries should start with ENTITIES",
+		},
+		{
+			name:                 "invalid - missing entity selector",
+			inputQuery:           "ENTITIES;from=now-2h",
+			expectError:          true,
+			expectedErrorMessage: "entity selector should not be empty",
+		},
+		{
+			name:                 "invalid - unknown key",
+			inputQuery:           "ENTITIES;entitySelector=type(HOST)&fields=+tags",
+			expectError:          true,
+			expectedErrorMessage: "unknown key",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, query) {
+					assert.EqualValues(t, tc.expectedEntitySelector, query.GetEntitySelector())
+					assert.EqualValues(t, tc.expectedFrom, query.GetFrom())
+				}
+			}
+		})
+	}
+}
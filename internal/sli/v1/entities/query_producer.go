@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/entities"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// QueryProducer for Monitored entities queries.
+type QueryProducer struct {
+	query entities.Query
+}
+
+// NewQueryProducer creates a QueryProducer for the specified Monitored entities Query.
+func NewQueryProducer(query entities.Query) QueryProducer {
+	return QueryProducer{query: query}
+}
+
+// Produce returns the Monitored entities query string for a Query.
+func (p QueryProducer) Produce() string {
+	keyValues := map[string]string{
+		entitySelectorKey: p.query.GetEntitySelector(),
+	}
+	if p.query.GetFrom() != "" {
+		keyValues[fromKey] = p.query.GetFrom()
+	}
+
+	return common.ProducePrefixedSLI(EntitiesPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
+}
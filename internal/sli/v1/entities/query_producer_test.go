@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProducer_Produce(t *testing.T) {
+	testConfigs := []struct {
+		name                        string
+		inputEntitiesQuery          entities.Query
+		expectedEntitiesQueryString string
+	}{
+		{
+			name:                        "valid",
+			inputEntitiesQuery:          newQuery(t, "type(HOST)", ""),
+			expectedEntitiesQueryString: "ENTITIES;entitySelector=type(HOST)",
+		},
+		{
+			name:                        "valid - with from",
+			inputEntitiesQuery:          newQuery(t, "type(HOST)", "now-2h"),
+			expectedEntitiesQueryString: "ENTITIES;entitySelector=type(HOST)&from=now-2h",
+		},
+	}
+	for _, testConfig := range testConfigs {
+		tc := testConfig
+		t.Run(tc.name, func(t *testing.T) {
+			entitiesQueryString := NewQueryProducer(tc.inputEntitiesQuery).Produce()
+			assert.Equal(t, tc.expectedEntitiesQueryString, entitiesQueryString)
+		})
+	}
+}
+
+func newQuery(t *testing.T, entitySelector string, from string) entities.Query {
+	query, err := entities.NewQuery(entitySelector, from)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
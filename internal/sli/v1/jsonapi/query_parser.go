@@ -0,0 +1,69 @@
+package jsonapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/jsonapi"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// JSONPrefix is the prefix of JSON API queries.
+const JSONPrefix = "JSON"
+
+const (
+	pathKey   = "path"
+	selectKey = "select"
+)
+
+// QueryParser will parse a v1 JSON API query string (usually found in sli.yaml files) into a Query
+type QueryParser struct {
+	query string
+}
+
+// NewQueryParser creates a new QueryParser for the specified JSON API query string.
+func NewQueryParser(query string) *QueryParser {
+	return &QueryParser{
+		query: strings.TrimSpace(query),
+	}
+}
+
+// Parse parses the query string into a Query or returns an error.
+func (p *QueryParser) Parse() (*jsonapi.Query, error) {
+	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := pieces.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != JSONPrefix {
+		return nil, fmt.Errorf("JSON API queries should start with %s", JSONPrefix)
+	}
+
+	jsonQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePairs, err := common.NewSLIParser(jsonQueryString, &jsonQueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonapi.NewQuery(keyValuePairs.GetValue(pathKey), keyValuePairs.GetValue(selectKey))
+}
+
+type jsonQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of a JSON API query.
+func (v *jsonQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case pathKey, selectKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package jsonapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQueryParser tests the QueryParser
+func TestQueryParser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedPath         string
+		expectedSelector     string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:             "valid",
+			inputQuery:       "JSON;path=/api/v2/releases?releasesSelector=stage(\"staging\")&select=$.totalCount",
+			expectedPath:     "/api/v2/releases?releasesSelector=stage(\"staging\")",
+			expectedSelector: "$.totalCount",
+		},
+		{
+			name:             "valid - select with index",
+			inputQuery:       "JSON;select=$.items[0].value.retentionDays&path=/api/v2/settings/objects?schemaIds=builtin:logmonitoring.log-storage-settings",
+			expectedPath:     "/api/v2/settings/objects?schemaIds=builtin:logmonitoring.log-storage-settings",
+			expectedSelector: "$.items[0].value.retentionDays",
+		},
+		{
+			name:             "valid - multiple path parameters",
+			inputQuery:       "JSON;path=/api/v2/entities?entitySelector=type(HOST);fields=+tags&select=$.totalCount",
+			expectedPath:     "/api/v2/entities?entitySelector=type(HOST);fields=+tags",
+			expectedSelector: "$.totalCount",
+		},
+		{
+			name:                 "invalid - path parameter with invalid encoding",
+			inputQuery:           "JSON;path=/api/v2/entities?entitySelector=type(HOST)%2&select=$.totalCount",
+			expectError:          true,
+			expectedErrorMessage: "could not decode value of path parameter 'entitySelector'",
+		},
+		{
+			name:                 "invalid - no JSON prefix",
+			inputQuery:           ";path=/api/v2/releases&select=$.totalCount",
+			expectError:          true,
+			expectedErrorMessage: "JSON API queries should start with JSON",
+		},
+		{
+			name:                 "invalid - missing path",
+			inputQuery:           "JSON;select=$.totalCount",
+			expectError:          true,
+			expectedErrorMessage: "path should not be empty",
+		},
+		{
+			name:                 "invalid - path not an API path",
+			inputQuery:           "JSON;path=https://example.com/api/v2/releases&select=$.totalCount",
+			expectError:          true,
+			expectedErrorMessage: "path should start with /api/",
+		},
+		{
+			name:                 "invalid - path with parent reference",
+			inputQuery:           "JSON;path=/api/../login&select=$.totalCount",
+			expectError:          true,
+			expectedErrorMessage: "path should not contain '..'",
+		},
+		{
+			name:                 "invalid - missing select",
+			inputQuery:           "JSON;path=/api/v2/releases",
+			expectError:          true,
+			expectedErrorMessage: "selector should not be empty",
+		},
+		{
+			name:                 "invalid - invalid select",
+			inputQuery:           "JSON;path=/api/v2/releases&select=totalCount",
+			expectError:          true,
+			expectedErrorMessage: "selector should start with $",
+		},
+		{
+			name:                 "invalid - unknown key",
+			inputQuery:           "JSON;path=/api/v2/releases&select=$.totalCount&method=POST",
+			expectError:          true,
+			expectedErrorMessage: "unknown key",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, query) {
+					assert.EqualValues(t, tc.expectedPath, query.GetPath())
+					assert.EqualValues(t, tc.expectedSelector, query.GetSelector().String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package jsonapi
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/jsonapi"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// QueryProducer for JSON API queries.
+type QueryProducer struct {
+	query jsonapi.Query
+}
+
+// NewQueryProducer creates a QueryProducer for the specified JSON API Query.
+func NewQueryProducer(query jsonapi.Query) QueryProducer {
+	return QueryProducer{query: query}
+}
+
+// Produce returns the JSON API query string for a Query.
+func (p QueryProducer) Produce() string {
+	keyValues := map[string]string{
+		pathKey:   p.query.GetPath(),
+		selectKey: p.query.GetSelector().String(),
+	}
+
+	return common.ProducePrefixedSLI(JSONPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
+}
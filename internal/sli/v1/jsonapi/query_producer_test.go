@@ -0,0 +1,36 @@
+package jsonapi
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/jsonapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProducer_Produce(t *testing.T) {
+	testConfigs := []struct {
+		name                    string
+		inputJSONQuery          jsonapi.Query
+		expectedJSONQueryString string
+	}{
+		{
+			name:                    "valid",
+			inputJSONQuery:          newQuery(t, "/api/v2/releases", "$.totalCount"),
+			expectedJSONQueryString: "JSON;path=/api/v2/releases&select=$.totalCount",
+		},
+	}
+	for _, testConfig := range testConfigs {
+		tc := testConfig
+		t.Run(tc.name, func(t *testing.T) {
+			jsonQueryString := NewQueryProducer(tc.inputJSONQuery).Produce()
+			assert.Equal(t, tc.expectedJSONQueryString, jsonQueryString)
+		})
+	}
+}
+
+func newQuery(t *testing.T, path string, selector string) jsonapi.Query {
+	query, err := jsonapi.NewQuery(path, selector)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
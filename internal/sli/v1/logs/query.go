@@ -0,0 +1,53 @@
+package logs
+
+import (
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/logs"
+)
+
+const (
+	// CountAggregate is the aggregate for counting the matching log records.
+	CountAggregate = "count"
+)
+
+// Query represents a v1 Logs query.
+type Query struct {
+	aggregate string
+	query     logs.Query
+}
+
+// NewQuery creates a Query from the specified aggregate and log query or returns an error.
+// If no aggregate is specified, the log records are counted.
+func NewQuery(aggregate string, query logs.Query) (*Query, error) {
+	if aggregate == "" {
+		aggregate = CountAggregate
+	}
+
+	if !isValidAggregate(aggregate) {
+		return nil, fmt.Errorf("unknown aggregate: %s", aggregate)
+	}
+
+	return &Query{
+		aggregate: aggregate,
+		query:     query,
+	}, nil
+}
+
+// GetAggregate returns the aggregate.
+func (q *Query) GetAggregate() string {
+	return q.aggregate
+}
+
+// GetQuery returns the log query.
+func (q *Query) GetQuery() logs.Query {
+	return q.query
+}
+
+func isValidAggregate(aggregate string) bool {
+	switch aggregate {
+	case CountAggregate:
+		return true
+	}
+	return false
+}
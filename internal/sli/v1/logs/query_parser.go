@@ -0,0 +1,74 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/logs"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// LogsPrefix is the prefix of Logs queries.
+const LogsPrefix = "LOGS"
+
+const (
+	queryKey     = "query"
+	aggregateKey = "aggregate"
+)
+
+// QueryParser will parse a v1 Logs query string (usually found in sli.yaml files) into a Query
+type QueryParser struct {
+	query string
+}
+
+// NewQueryParser creates a new QueryParser for the specified Logs query string.
+func NewQueryParser(query string) *QueryParser {
+	return &QueryParser{
+		query: strings.TrimSpace(query),
+	}
+}
+
+// Parse parses the query string into a Query or returns an error.
+func (p *QueryParser) Parse() (*Query, error) {
+	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := pieces.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != LogsPrefix {
+		return nil, fmt.Errorf("Logs queries should start with %s", LogsPrefix)
+	}
+
+	logsQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePairs, err := common.NewSLIParser(logsQueryString, &logsQueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	innerQuery, err := logs.NewQuery(keyValuePairs.GetValue(queryKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewQuery(keyValuePairs.GetValue(aggregateKey), *innerQuery)
+}
+
+type logsQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of a Logs query.
+func (v *logsQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case queryKey, aggregateKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,73 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQueryParser tests the QueryParser
+func TestQueryParser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedAggregate    string
+		expectedQuery        string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:              "valid",
+			inputQuery:        "LOGS;query=loglevel=\"ERROR\" AND k8s.deployment.name=\"carts\"&aggregate=count",
+			expectedAggregate: "count",
+			expectedQuery:     "loglevel=\"ERROR\" AND k8s.deployment.name=\"carts\"",
+		},
+		{
+			name:              "valid - default aggregate",
+			inputQuery:        "LOGS;query=loglevel=\"ERROR\"",
+			expectedAggregate: "count",
+			expectedQuery:     "loglevel=\"ERROR\"",
+		},
+		{
+			name:                 "invalid - no LOGS prefix",
+			inputQuery:           ";query=loglevel=\"ERROR\"",
+			expectError:          true,
+			expectedErrorMessage: "Logs queries should start with LOGS",
+		},
+		{
+			name:                 "invalid - missing query",
+			inputQuery:           "LOGS;aggregate=count",
+			expectError:          true,
+			expectedErrorMessage: "log query should not be empty",
+		},
+		{
+			name:                 "invalid - unknown aggregate",
+			inputQuery:           "LOGS;query=loglevel=\"ERROR\"&aggregate=avg",
+			expectError:          true,
+			expectedErrorMessage: "unknown aggregate: avg",
+		},
+		{
+			name:                 "invalid - unknown key",
+			inputQuery:           "LOGS;query=loglevel=\"ERROR\"&limit=10",
+			expectError:          true,
+			expectedErrorMessage: "unknown key",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, query) {
+					assert.EqualValues(t, tc.expectedAggregate, query.GetAggregate())
+					assert.EqualValues(t, tc.expectedQuery, query.GetQuery().GetQuery())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package logs
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// QueryProducer for Logs queries.
+type QueryProducer struct {
+	query Query
+}
+
+// NewQueryProducer creates a QueryProducer for the specified Logs Query.
+func NewQueryProducer(query Query) QueryProducer {
+	return QueryProducer{query: query}
+}
+
+// Produce returns the Logs query string for a Query.
+func (p QueryProducer) Produce() string {
+	keyValues := map[string]string{
+		queryKey:     p.query.GetQuery().GetQuery(),
+		aggregateKey: p.query.GetAggregate(),
+	}
+
+	return common.ProducePrefixedSLI(LogsPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
+}
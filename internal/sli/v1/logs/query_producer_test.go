@@ -0,0 +1,45 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/logs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProducer_Produce(t *testing.T) {
+	testConfigs := []struct {
+		name                    string
+		inputLogsQuery          Query
+		expectedLogsQueryString string
+	}{
+		{
+			name:                    "valid",
+			inputLogsQuery:          newQuery(t, "count", "loglevel=\"ERROR\""),
+			expectedLogsQueryString: "LOGS;aggregate=count&query=loglevel=\"ERROR\"",
+		},
+		{
+			name:                    "valid - default aggregate",
+			inputLogsQuery:          newQuery(t, "", "loglevel=\"ERROR\""),
+			expectedLogsQueryString: "LOGS;aggregate=count&query=loglevel=\"ERROR\"",
+		},
+	}
+	for _, testConfig := range testConfigs {
+		tc := testConfig
+		t.Run(tc.name, func(t *testing.T) {
+			logsQueryString := NewQueryProducer(tc.inputLogsQuery).Produce()
+			assert.Equal(t, tc.expectedLogsQueryString, logsQueryString)
+		})
+	}
+}
+
+func newQuery(t *testing.T, aggregate string, queryString string) Query {
+	innerQuery, err := logs.NewQuery(queryString)
+	assert.NoError(t, err)
+	assert.NotNil(t, innerQuery)
+
+	query, err := NewQuery(aggregate, *innerQuery)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
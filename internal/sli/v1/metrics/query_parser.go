@@ -1,9 +1,11 @@
 package metrics
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
 )
 
@@ -12,6 +14,7 @@ const (
 	entitySelectorKey = "entitySelector"
 	resolutionKey     = "resolution"
 	mzSelectorKey     = "mzSelector"
+	targetUnitKey     = "unit"
 )
 
 // QueryParser will parse an un-encoded metrics query string (usually found in sli.yaml files) into a Query
@@ -29,10 +32,46 @@ func NewQueryParser(query string) *QueryParser {
 // Parse parses an un-encoded metrics query string (usually found in sli.yaml files) into a Query or returns an error.
 // It only supports the current Metrics API V2 format (without a '?' prefix)
 func (p *QueryParser) Parse() (*metrics.Query, error) {
+	query, _, err := p.ParseWithTargetUnit()
+	return query, err
+}
+
+// ParseWithTargetUnit parses an un-encoded metrics query string into a Query and the optional unit the result should be converted to, or returns an error.
+// The target unit is specified using the unit key and is returned as the ID of a known unit, e.g. unit=ms results in MilliSecond.
+func (p *QueryParser) ParseWithTargetUnit() (*metrics.Query, string, error) {
 	keyValuePairs, err := common.NewSLIParser(p.query, &metricsQueryKeyValidator{}).Parse()
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	targetUnitID, err := ParseTargetUnit(keyValuePairs.GetValue(targetUnitKey))
+	if err != nil {
+		return nil, "", err
+	}
+
+	query, err := NewQueryFromKeyValuePairs(*keyValuePairs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return query, targetUnitID, nil
+}
+
+// ParseTargetUnit returns the ID of the known unit specified by ID or symbol, an empty string if no unit is specified, or an error.
+func ParseTargetUnit(targetUnit string) (string, error) {
+	if targetUnit == "" {
+		return "", nil
+	}
+
+	u, ok := unit.Lookup(targetUnit)
+	if !ok {
+		return "", fmt.Errorf("invalid unit: %s", targetUnit)
 	}
+	return u.ID(), nil
+}
+
+// NewQueryFromKeyValuePairs creates a Query from the metrics query keys of the specified KeyValuePairs or returns an error.
+func NewQueryFromKeyValuePairs(keyValuePairs common.KeyValuePairs) (*metrics.Query, error) {
 	return metrics.NewQuery(keyValuePairs.GetValue(metricSelectorKey), keyValuePairs.GetValue(entitySelectorKey), keyValuePairs.GetValue(resolutionKey), keyValuePairs.GetValue(mzSelectorKey))
 }
 
@@ -40,6 +79,11 @@ type metricsQueryKeyValidator struct{}
 
 // ValidateKey returns true if the specified key is part of a metrics query.
 func (p *metricsQueryKeyValidator) ValidateKey(key string) bool {
+	return IsQueryKey(key) || key == targetUnitKey
+}
+
+// IsQueryKey returns true if the specified key is part of a metrics query.
+func IsQueryKey(key string) bool {
 	switch key {
 	case metricSelectorKey, entitySelectorKey, resolutionKey, mzSelectorKey:
 		return true
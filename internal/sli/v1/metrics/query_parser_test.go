@@ -14,6 +14,7 @@ func TestQueryParser_Parse(t *testing.T) {
 		expectedEntitySelector string
 		expectedResolution     string
 		expectedMZSelector     string
+		expectedTargetUnit     string
 		expectError            bool
 		expectedErrorMessage   string
 	}{
@@ -78,6 +79,18 @@ func TestQueryParser_Parse(t *testing.T) {
 			input:                  "metricSelector=(calc:service.$rt_csm:filter(and(eq(Dimension,\"request Actions.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave_Rest_customer-profile.\"),in(\"dt.entity.service\",entitySelector(\"type(service),requestAttribute(~\"$svc_id~\")\")))):splitBy():avg:auto:sort(value(avg,descending)))/((calc:service.$reqcnt_csm:filter(and(in(\"dt.entity.service\",entitySelector(\"type(service),requestAttribute(~\"$svc_id~\")\")),eq(Dimension,\"request Actions.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave_Rest_customer-profile.\")))):splitBy():sum:auto:sort(value(sum,descending))/(1))",
 			expectedMetricSelector: "(calc:service.$rt_csm:filter(and(eq(Dimension,\"request Actions.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave_Rest_customer-profile.\"),in(\"dt.entity.service\",entitySelector(\"type(service),requestAttribute(~\"$svc_id~\")\")))):splitBy():avg:auto:sort(value(avg,descending)))/((calc:service.$reqcnt_csm:filter(and(in(\"dt.entity.service\",entitySelector(\"type(service),requestAttribute(~\"$svc_id~\")\")),eq(Dimension,\"request Actions.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave.BO_EC11_NewCustAdd+_06_EntrDtlsAndSave_Rest_customer-profile.\")))):splitBy():sum:auto:sort(value(sum,descending))/(1))",
 		},
+		{
+			name:                   "metricSelector and unit ID",
+			input:                  "metricSelector=builtin:service.response.time:merge(\"dt.entity.service\"):avg&unit=MilliSecond",
+			expectedMetricSelector: "builtin:service.response.time:merge(\"dt.entity.service\"):avg",
+			expectedTargetUnit:     "MilliSecond",
+		},
+		{
+			name:                   "metricSelector and unit symbol",
+			input:                  "metricSelector=builtin:host.disk.avail:merge(\"dt.entity.disk\"):avg&unit=GiB",
+			expectedMetricSelector: "builtin:host.disk.avail:merge(\"dt.entity.disk\"):avg",
+			expectedTargetUnit:     "GibiByte",
+		},
 		// Error cases below:
 		{
 			// actually a tag 'my_tag:tom & jerry' would be totally fine from a Dynatrace API perspective
@@ -92,11 +105,17 @@ func TestQueryParser_Parse(t *testing.T) {
 			expectError:          true,
 			expectedErrorMessage: "unknown key",
 		},
+		{
+			name:                 "unknown unit fails",
+			input:                "metricSelector=builtin:service.response.time:merge(\"dt.entity.service\"):avg&unit=fortnight",
+			expectError:          true,
+			expectedErrorMessage: "invalid unit: fortnight",
+		},
 	}
 	for _, testConfig := range testConfigs {
 		tc := testConfig
 		t.Run(tc.name, func(t *testing.T) {
-			metricsQuery, err := NewQueryParser(tc.input).Parse()
+			metricsQuery, targetUnit, err := NewQueryParser(tc.input).ParseWithTargetUnit()
 			if tc.expectError {
 				assert.Error(t, err)
 				assert.Nil(t, metricsQuery)
@@ -108,6 +127,7 @@ func TestQueryParser_Parse(t *testing.T) {
 				assert.EqualValues(t, tc.expectedEntitySelector, metricsQuery.GetEntitySelector())
 				assert.EqualValues(t, tc.expectedResolution, metricsQuery.GetResolution())
 				assert.EqualValues(t, tc.expectedMZSelector, metricsQuery.GetMZSelector())
+				assert.EqualValues(t, tc.expectedTargetUnit, targetUnit)
 				assert.Empty(t, tc.expectedErrorMessage, "fix test setup")
 			}
 		})
@@ -17,7 +17,12 @@ func NewQueryProducer(query metrics.Query) QueryProducer {
 
 // Produce returns the unencoded metrics query string for a Query.
 func (b QueryProducer) Produce() string {
-	keyValues := make(map[string]string, 1)
+	return common.NewSLIProducer(common.NewKeyValuePairs(b.ProduceKeyValues())).Produce()
+}
+
+// ProduceKeyValues returns the keys and values of the metrics query string for a Query.
+func (b QueryProducer) ProduceKeyValues() map[string]string {
+	keyValues := make(map[string]string, 4)
 	keyValues[metricSelectorKey] = b.query.GetMetricSelector()
 
 	if b.query.GetEntitySelector() != "" {
@@ -32,5 +37,5 @@ func (b QueryProducer) Produce() string {
 		keyValues[mzSelectorKey] = b.query.GetMZSelector()
 	}
 
-	return common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce()
+	return keyValues
 }
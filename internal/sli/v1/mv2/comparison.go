@@ -0,0 +1,104 @@
+package mv2
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+)
+
+const (
+	// PreviousTimeframeCompareTo compares against the timeframe of the same length directly preceding the evaluation timeframe.
+	PreviousTimeframeCompareTo = "previousTimeframe"
+
+	// AbsoluteMode returns the difference between the values.
+	AbsoluteMode = "absolute"
+
+	// RelativeMode returns the difference between the values as a percentage of the value of the comparison timeframe.
+	RelativeMode = "relative"
+)
+
+var shiftPattern = regexp.MustCompile(`^shift\(-(\d+)([mhdw])\)$`)
+
+var shiftUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// Comparison encapsulates the comparison of a MV2 query against an earlier timeframe.
+type Comparison struct {
+	compareTo string
+	mode      string
+	shift     time.Duration
+}
+
+// NewComparison creates a Comparison from the specified compareTo and mode values or returns an error.
+// compareTo must either be previousTimeframe or a negative shift such as shift(-1d). If no mode is specified, the absolute difference is used.
+func NewComparison(compareTo string, mode string) (*Comparison, error) {
+	if mode == "" {
+		mode = AbsoluteMode
+	}
+
+	if mode != AbsoluteMode && mode != RelativeMode {
+		return nil, fmt.Errorf("invalid mode: %s", mode)
+	}
+
+	var shift time.Duration
+	if compareTo != PreviousTimeframeCompareTo {
+		matches := shiftPattern.FindStringSubmatch(compareTo)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid compareTo: %s, should be %s or a negative shift such as shift(-1d)", compareTo, PreviousTimeframeCompareTo)
+		}
+
+		amount, err := strconv.Atoi(matches[1])
+		if err != nil || amount == 0 {
+			return nil, fmt.Errorf("invalid shift: %s", compareTo)
+		}
+		shift = time.Duration(amount) * shiftUnits[matches[2]]
+	}
+
+	return &Comparison{
+		compareTo: compareTo,
+		mode:      mode,
+		shift:     shift,
+	}, nil
+}
+
+// GetCompareTo gets the compareTo value.
+func (c *Comparison) GetCompareTo() string {
+	return c.compareTo
+}
+
+// GetMode gets the mode.
+func (c *Comparison) GetMode() string {
+	return c.mode
+}
+
+// GetComparisonTimeframe returns the timeframe to compare the specified timeframe against or an error.
+func (c *Comparison) GetComparisonTimeframe(timeframe common.Timeframe) (*common.Timeframe, error) {
+	shift := c.shift
+	if c.compareTo == PreviousTimeframeCompareTo {
+		shift = timeframe.End().Sub(timeframe.Start())
+	}
+
+	return common.NewTimeframe(timeframe.Start().Add(-shift), timeframe.End().Add(-shift))
+}
+
+// Compare compares the value with the value of the comparison timeframe according to the mode or returns an error.
+func (c *Comparison) Compare(value float64, comparisonValue float64) (float64, error) {
+	if c.mode == AbsoluteMode {
+		return value - comparisonValue, nil
+	}
+
+	if comparisonValue == 0 {
+		return 0, fmt.Errorf("relative change cannot be calculated as the value of the comparison timeframe is 0")
+	}
+
+	// dividing by the magnitude keeps the sign of the change if the comparison value is negative
+	return (value - comparisonValue) / math.Abs(comparisonValue) * 100, nil
+}
@@ -0,0 +1,145 @@
+package mv2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestComparison_GetComparisonTimeframe tests that comparison timeframes are shifted correctly.
+func TestComparison_GetComparisonTimeframe(t *testing.T) {
+	timeframe, err := common.NewTimeframe(time.Date(2022, 9, 28, 10, 0, 0, 0, time.UTC), time.Date(2022, 9, 28, 10, 30, 0, 0, time.UTC))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		name          string
+		compareTo     string
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "previous timeframe",
+			compareTo:     "previousTimeframe",
+			expectedStart: time.Date(2022, 9, 28, 9, 30, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2022, 9, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "shift by minutes",
+			compareTo:     "shift(-15m)",
+			expectedStart: time.Date(2022, 9, 28, 9, 45, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2022, 9, 28, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:          "shift by hours",
+			compareTo:     "shift(-2h)",
+			expectedStart: time.Date(2022, 9, 28, 8, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2022, 9, 28, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:          "shift by days",
+			compareTo:     "shift(-1d)",
+			expectedStart: time.Date(2022, 9, 27, 10, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2022, 9, 27, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:          "shift by weeks",
+			compareTo:     "shift(-1w)",
+			expectedStart: time.Date(2022, 9, 21, 10, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2022, 9, 21, 10, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			comparison, err := NewComparison(tc.compareTo, "")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			comparisonTimeframe, err := comparison.GetComparisonTimeframe(*timeframe)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedStart, comparisonTimeframe.Start())
+				assert.Equal(t, tc.expectedEnd, comparisonTimeframe.End())
+			}
+		})
+	}
+}
+
+// TestComparison_Compare tests comparing values in absolute and relative mode.
+func TestComparison_Compare(t *testing.T) {
+	tests := []struct {
+		name                 string
+		mode                 string
+		value                float64
+		comparisonValue      float64
+		expectedResult       float64
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:            "absolute increase",
+			mode:            AbsoluteMode,
+			value:           120,
+			comparisonValue: 100,
+			expectedResult:  20,
+		},
+		{
+			name:            "absolute decrease",
+			mode:            AbsoluteMode,
+			value:           80,
+			comparisonValue: 100,
+			expectedResult:  -20,
+		},
+		{
+			name:            "relative increase",
+			mode:            RelativeMode,
+			value:           150,
+			comparisonValue: 120,
+			expectedResult:  25,
+		},
+		{
+			name:            "relative increase from negative comparison value",
+			mode:            RelativeMode,
+			value:           -50,
+			comparisonValue: -100,
+			expectedResult:  50,
+		},
+		{
+			name:            "relative decrease from negative comparison value",
+			mode:            RelativeMode,
+			value:           -150,
+			comparisonValue: -100,
+			expectedResult:  -50,
+		},
+		{
+			name:                 "relative with zero comparison value",
+			mode:                 RelativeMode,
+			value:                150,
+			comparisonValue:      0,
+			expectError:          true,
+			expectedErrorMessage: "value of the comparison timeframe is 0",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			comparison, err := NewComparison(PreviousTimeframeCompareTo, tc.mode)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			result, err := comparison.Compare(tc.value, tc.comparisonValue)
+			if tc.expectError {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expectedResult, result, 0.000001)
+		})
+	}
+}
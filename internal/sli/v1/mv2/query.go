@@ -6,18 +6,32 @@ import (
 	"regexp"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
+	sliunit "github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 )
 
 var unitPattern = regexp.MustCompile(`^(([bB]yte)|([Mm]icro[sS]econd))$`)
 
 // Query encapsulates a MV2 query-
 type Query struct {
-	unit  string
-	query metrics.Query
+	unit       string
+	targetUnit string
+	query      metrics.Query
+	comparison *Comparison
 }
 
 // NewQuery creates a Query from the specified unit and metrics query or returns an error.
 func NewQuery(unit string, query metrics.Query) (*Query, error) {
+	return NewQueryWithComparison(unit, query, nil)
+}
+
+// NewQueryWithComparison creates a Query from the specified unit, metrics query and optional comparison or returns an error.
+func NewQueryWithComparison(unit string, query metrics.Query, comparison *Comparison) (*Query, error) {
+	return NewQueryWithTargetUnitAndComparison(unit, "", query, comparison)
+}
+
+// NewQueryWithTargetUnitAndComparison creates a Query from the specified unit, optional target unit, metrics query and optional comparison or returns an error.
+// If a target unit is specified, it must be a known unit, specified by ID or symbol, that values of the unit can be converted to.
+func NewQueryWithTargetUnitAndComparison(unit string, targetUnit string, query metrics.Query, comparison *Comparison) (*Query, error) {
 	if unit == "" {
 		return nil, errors.New("unit should not be empty")
 	}
@@ -26,9 +40,19 @@ func NewQuery(unit string, query metrics.Query) (*Query, error) {
 		return nil, fmt.Errorf("invalid unit: %s", unit)
 	}
 
+	if targetUnit != "" {
+		_, targetUnitID, err := sliunit.Convert(0, unit, targetUnit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unit: %w", err)
+		}
+		targetUnit = targetUnitID
+	}
+
 	return &Query{
-		unit:  unit,
-		query: query,
+		unit:       unit,
+		targetUnit: targetUnit,
+		query:      query,
+		comparison: comparison,
 	}, nil
 }
 
@@ -37,7 +61,17 @@ func (q *Query) GetUnit() string {
 	return q.unit
 }
 
+// GetTargetUnit gets the unit values should be converted to or an empty string if the default scaling should be applied.
+func (q *Query) GetTargetUnit() string {
+	return q.targetUnit
+}
+
 // GetQuery gets the query.
 func (q *Query) GetQuery() metrics.Query {
 	return q.query
 }
+
+// GetComparison gets the comparison or nil if the query should not be compared against an earlier timeframe.
+func (q *Query) GetComparison() *Comparison {
+	return q.comparison
+}
@@ -11,6 +11,12 @@ import (
 // MV2Prefix is the prefix of MV2 queries
 const MV2Prefix = "MV2"
 
+const (
+	compareToKey = "compareTo"
+	modeKey      = "mode"
+	unitKey      = "unit"
+)
+
 // QueryParser will parse a MV2 query string (usually found in sli.yaml files) into a Query
 type QueryParser struct {
 	query string
@@ -48,10 +54,44 @@ func (p QueryParser) Parse() (*Query, error) {
 		return nil, err
 	}
 
-	query, err := v1metrics.NewQueryParser(mv2QueryString).Parse()
+	keyValuePairs, err := common.NewSLIParser(mv2QueryString, &mv2QueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := v1metrics.NewQueryFromKeyValuePairs(*keyValuePairs)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison, err := parseComparison(keyValuePairs.GetValue(compareToKey), keyValuePairs.GetValue(modeKey))
 	if err != nil {
 		return nil, err
 	}
 
-	return NewQuery(unit, *query)
+	return NewQueryWithTargetUnitAndComparison(unit, keyValuePairs.GetValue(unitKey), *query, comparison)
+}
+
+// parseComparison returns a Comparison for the specified compareTo and mode values, nil if no comparison is specified, or an error.
+func parseComparison(compareTo string, mode string) (*Comparison, error) {
+	if compareTo == "" {
+		if mode != "" {
+			return nil, fmt.Errorf("%s requires %s", modeKey, compareToKey)
+		}
+		return nil, nil
+	}
+
+	return NewComparison(compareTo, mode)
+}
+
+type mv2QueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of a MV2 query.
+func (v *mv2QueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case compareToKey, modeKey, unitKey:
+		return true
+	default:
+		return v1metrics.IsQueryKey(key)
+	}
 }
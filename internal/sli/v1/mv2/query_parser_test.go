@@ -115,3 +115,150 @@ func TestQueryParser(t *testing.T) {
 		})
 	}
 }
+
+// TestQueryParser_TargetUnit tests parsing MV2 queries with target units.
+func TestQueryParser_TargetUnit(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputMV2Query        string
+		expectedTargetUnit   string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:          "no target unit",
+			inputMV2Query: "MV2;MicroSecond;metricSelector=builtin:service.response.time",
+		},
+		{
+			name:               "target unit ID",
+			inputMV2Query:      "MV2;MicroSecond;metricSelector=builtin:service.response.time&unit=Second",
+			expectedTargetUnit: "Second",
+		},
+		{
+			name:               "target unit symbol",
+			inputMV2Query:      "MV2;Byte;metricSelector=builtin:host.disk.avail&unit=MB",
+			expectedTargetUnit: "MegaByte",
+		},
+		{
+			name:               "target unit of bits",
+			inputMV2Query:      "MV2;byte;metricSelector=builtin:host.net.nic.bytesRx&unit=kbit",
+			expectedTargetUnit: "KiloBit",
+		},
+		{
+			name:                 "unknown target unit",
+			inputMV2Query:        "MV2;MicroSecond;metricSelector=builtin:service.response.time&unit=fortnight",
+			expectError:          true,
+			expectedErrorMessage: "unknown unit 'fortnight'",
+		},
+		{
+			name:                 "incompatible target unit",
+			inputMV2Query:        "MV2;MicroSecond;metricSelector=builtin:service.response.time&unit=KiB",
+			expectError:          true,
+			expectedErrorMessage: "cannot convert from 'MicroSecond' to 'KibiByte'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputMV2Query).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			if assert.NotNil(t, query) {
+				assert.EqualValues(t, tc.expectedTargetUnit, query.GetTargetUnit())
+			}
+		})
+	}
+}
+
+// TestQueryParser_Comparison tests parsing MV2 queries with comparisons.
+func TestQueryParser_Comparison(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputMV2Query        string
+		expectedCompareTo    string
+		expectedMode         string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:          "no comparison",
+			inputMV2Query: "MV2;MicroSecond;metricSelector=builtin:service.response.time",
+		},
+		{
+			name:              "previous timeframe - default mode",
+			inputMV2Query:     "MV2;MicroSecond;metricSelector=builtin:service.response.time&compareTo=previousTimeframe",
+			expectedCompareTo: PreviousTimeframeCompareTo,
+			expectedMode:      AbsoluteMode,
+		},
+		{
+			name:              "shift - relative mode",
+			inputMV2Query:     "MV2;MicroSecond;metricSelector=builtin:service.response.time&compareTo=shift(-1d)&mode=relative",
+			expectedCompareTo: "shift(-1d)",
+			expectedMode:      RelativeMode,
+		},
+		{
+			name:                 "mode without compareTo",
+			inputMV2Query:        "MV2;MicroSecond;metricSelector=builtin:service.response.time&mode=relative",
+			expectError:          true,
+			expectedErrorMessage: "mode requires compareTo",
+		},
+		{
+			name:                 "invalid mode",
+			inputMV2Query:        "MV2;MicroSecond;metricSelector=builtin:service.response.time&compareTo=previousTimeframe&mode=ratio",
+			expectError:          true,
+			expectedErrorMessage: "invalid mode: ratio",
+		},
+		{
+			name:                 "positive shift",
+			inputMV2Query:        "MV2;MicroSecond;metricSelector=builtin:service.response.time&compareTo=shift(1d)",
+			expectError:          true,
+			expectedErrorMessage: "invalid compareTo: shift(1d)",
+		},
+		{
+			name:                 "zero shift",
+			inputMV2Query:        "MV2;MicroSecond;metricSelector=builtin:service.response.time&compareTo=shift(-0h)",
+			expectError:          true,
+			expectedErrorMessage: "invalid shift: shift(-0h)",
+		},
+		{
+			name:                 "unknown key",
+			inputMV2Query:        "MV2;MicroSecond;metricSelector=builtin:service.response.time&compare=previousTimeframe",
+			expectError:          true,
+			expectedErrorMessage: "unknown key: compare",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputMV2Query).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			if !assert.NotNil(t, query) {
+				return
+			}
+
+			comparison := query.GetComparison()
+			if tc.expectedCompareTo == "" {
+				assert.Nil(t, comparison)
+				return
+			}
+
+			if assert.NotNil(t, comparison) {
+				assert.EqualValues(t, tc.expectedCompareTo, comparison.GetCompareTo())
+				assert.EqualValues(t, tc.expectedMode, comparison.GetMode())
+			}
+		})
+	}
+}
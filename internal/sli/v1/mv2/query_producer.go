@@ -17,5 +17,19 @@ func NewQueryProducer(query Query) QueryProducer {
 
 // Produce returns the MV2 query string for a Query.
 func (p QueryProducer) Produce() string {
-	return common.ProducePrefixedSLI(MV2Prefix, p.query.unit, metrics.NewQueryProducer(p.query.GetQuery()).Produce())
+	keyValues := metrics.NewQueryProducer(p.query.GetQuery()).ProduceKeyValues()
+
+	if p.query.GetTargetUnit() != "" {
+		keyValues[unitKey] = p.query.GetTargetUnit()
+	}
+
+	comparison := p.query.GetComparison()
+	if comparison != nil {
+		keyValues[compareToKey] = comparison.GetCompareTo()
+		if comparison.GetMode() != AbsoluteMode {
+			keyValues[modeKey] = comparison.GetMode()
+		}
+	}
+
+	return common.ProducePrefixedSLI(MV2Prefix, p.query.unit, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
 }
@@ -24,6 +24,21 @@ func TestQueryProducer_Produce(t *testing.T) {
 			inputMV2Query:          newQuery(t, "MicroSecond", "builtin:service.response.client:merge(\"dt.entity.service\"):avg:names", "type(SERVICE)"),
 			expectedMV2QueryString: "MV2;MicroSecond;entitySelector=type(SERVICE)&metricSelector=builtin:service.response.client:merge(\"dt.entity.service\"):avg:names",
 		},
+		{
+			name:                   "comparison with default mode works",
+			inputMV2Query:          newQueryWithComparison(t, "MicroSecond", "builtin:service.response.time", "previousTimeframe", ""),
+			expectedMV2QueryString: "MV2;MicroSecond;compareTo=previousTimeframe&metricSelector=builtin:service.response.time",
+		},
+		{
+			name:                   "comparison with relative mode works",
+			inputMV2Query:          newQueryWithComparison(t, "MicroSecond", "builtin:service.response.time", "shift(-1w)", "relative"),
+			expectedMV2QueryString: "MV2;MicroSecond;compareTo=shift(-1w)&metricSelector=builtin:service.response.time&mode=relative",
+		},
+		{
+			name:                   "target unit works",
+			inputMV2Query:          newQueryWithTargetUnit(t, "MicroSecond", "builtin:service.response.time", "s"),
+			expectedMV2QueryString: "MV2;MicroSecond;metricSelector=builtin:service.response.time&unit=Second",
+		},
 	}
 	for _, testConfig := range testConfigs {
 		tc := testConfig
@@ -45,3 +60,28 @@ func newQuery(t *testing.T, unit string, meticSelector string, entitySelector st
 	assert.NotNil(t, query)
 	return *query
 }
+
+func newQueryWithComparison(t *testing.T, unit string, metricSelector string, compareTo string, mode string) Query {
+	metricsQuery, err := metrics.NewQuery(metricSelector, "", "", "")
+	assert.NoError(t, err)
+	assert.NotNil(t, metricsQuery)
+
+	comparison, err := NewComparison(compareTo, mode)
+	assert.NoError(t, err)
+
+	query, err := NewQueryWithComparison(unit, *metricsQuery, comparison)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
+
+func newQueryWithTargetUnit(t *testing.T, unit string, metricSelector string, targetUnit string) Query {
+	metricsQuery, err := metrics.NewQuery(metricSelector, "", "", "")
+	assert.NoError(t, err)
+	assert.NotNil(t, metricsQuery)
+
+	query, err := NewQueryWithTargetUnitAndComparison(unit, targetUnit, *metricsQuery, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
@@ -0,0 +1,67 @@
+package problemsv2
+
+import (
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/problems"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+const (
+	// CountAggregation counts the problems.
+	CountAggregation = "count"
+
+	// SumDurationMinutesAggregation sums the durations of the problems within the timeframe in minutes.
+	SumDurationMinutesAggregation = "sumDurationMinutes"
+
+	// MaxDurationMinutesAggregation returns the longest duration of a problem within the timeframe in minutes.
+	MaxDurationMinutesAggregation = "maxDurationMinutes"
+
+	// ImpactedEntitiesAggregation counts the distinct entities impacted by the problems.
+	ImpactedEntitiesAggregation = "impactedEntities"
+)
+
+// Query encapsulates a PV2 query.
+type Query struct {
+	aggregation string
+	query       problems.Query
+}
+
+// NewQuery creates a Query from the specified aggregation and problems query or returns an error.
+// If no aggregation is specified, the problems are counted.
+func NewQuery(aggregation string, query problems.Query) (*Query, error) {
+	if aggregation == "" {
+		aggregation = CountAggregation
+	}
+
+	switch aggregation {
+	case CountAggregation, SumDurationMinutesAggregation, MaxDurationMinutesAggregation, ImpactedEntitiesAggregation:
+	default:
+		return nil, fmt.Errorf("invalid aggregation: %s", aggregation)
+	}
+
+	return &Query{
+		aggregation: aggregation,
+		query:       query,
+	}, nil
+}
+
+// GetAggregation gets the aggregation.
+func (q *Query) GetAggregation() string {
+	return q.aggregation
+}
+
+// GetUnitID gets the ID of the unit of the aggregated value, i.e. Minute for durations and Count otherwise.
+func (q *Query) GetUnitID() string {
+	switch q.aggregation {
+	case SumDurationMinutesAggregation, MaxDurationMinutesAggregation:
+		return unit.Minute
+	default:
+		return unit.Count
+	}
+}
+
+// GetQuery gets the query.
+func (q *Query) GetQuery() problems.Query {
+	return q.query
+}
@@ -14,6 +14,7 @@ const ProblemsV2Prefix = "PV2"
 const (
 	problemSelectorKey = "problemSelector"
 	entitySelectorKey  = "entitySelector"
+	aggregateKey       = "aggregate"
 )
 
 // QueryParser will parse a v1 Problems v2 query string (usually found in sli.yaml files) into a Query
@@ -29,7 +30,7 @@ func NewQueryParser(query string) *QueryParser {
 }
 
 // Parse parses the query string into a Query or returns an error.
-func (p *QueryParser) Parse() (*problems.Query, error) {
+func (p *QueryParser) Parse() (*Query, error) {
 	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
 	if err != nil {
 		return nil, err
@@ -53,8 +54,7 @@ func (p *QueryParser) Parse() (*problems.Query, error) {
 		return nil, err
 	}
 
-	query := problems.NewQuery(keyValuePairs.GetValue(problemSelectorKey), keyValuePairs.GetValue(entitySelectorKey))
-	return &query, nil
+	return NewQuery(keyValuePairs.GetValue(aggregateKey), problems.NewQuery(keyValuePairs.GetValue(problemSelectorKey), keyValuePairs.GetValue(entitySelectorKey)))
 }
 
 type problemsQueryKeyValidator struct{}
@@ -62,7 +62,7 @@ type problemsQueryKeyValidator struct{}
 // ValidateKey returns true if the specified key is part of a Problems v2 query.
 func (p *problemsQueryKeyValidator) ValidateKey(key string) bool {
 	switch key {
-	case problemSelectorKey, entitySelectorKey:
+	case problemSelectorKey, entitySelectorKey, aggregateKey:
 		return true
 	default:
 		return false
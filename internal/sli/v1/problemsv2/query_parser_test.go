@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 )
 
 // TestQueryParser tests the QueryParser
@@ -13,26 +15,49 @@ func TestQueryParser(t *testing.T) {
 		inputQuery              string
 		expectedProblemSelector string
 		expectedEntitySelector  string
+		expectedAggregation     string
+		expectedUnitID          string
 	}{
 		{
 			name:                    "valid",
 			inputQuery:              "PV2;problemSelector=status(open)&entitySelector=mzId(7030365576649815430)",
 			expectedProblemSelector: "status(open)",
 			expectedEntitySelector:  "mzId(7030365576649815430)",
+			expectedAggregation:     CountAggregation,
+			expectedUnitID:          unit.Count,
 		},
 		{
-			name:       "valid - empty",
-			inputQuery: "PV2;",
+			name:                "valid - empty",
+			inputQuery:          "PV2;",
+			expectedAggregation: CountAggregation,
+			expectedUnitID:      unit.Count,
 		},
 		{
 			name:                   "valid",
 			inputQuery:             "PV2;entitySelector=mzId(7030365576649815430)",
 			expectedEntitySelector: "mzId(7030365576649815430)",
+			expectedAggregation:    CountAggregation,
+			expectedUnitID:         unit.Count,
 		},
 		{
 			name:                    "valid",
 			inputQuery:              "PV2;problemSelector=status(open)",
 			expectedProblemSelector: "status(open)",
+			expectedAggregation:     CountAggregation,
+			expectedUnitID:          unit.Count,
+		},
+		{
+			name:                    "valid with aggregation",
+			inputQuery:              "PV2;problemSelector=status(open)&aggregate=sumDurationMinutes",
+			expectedProblemSelector: "status(open)",
+			expectedAggregation:     SumDurationMinutesAggregation,
+			expectedUnitID:          unit.Minute,
+		},
+		{
+			name:                "valid with impacted entities aggregation",
+			inputQuery:          "PV2;aggregate=impactedEntities",
+			expectedAggregation: ImpactedEntitiesAggregation,
+			expectedUnitID:      unit.Count,
 		},
 	}
 	for _, tc := range tests {
@@ -41,8 +66,41 @@ func TestQueryParser(t *testing.T) {
 
 			assert.NoError(t, err)
 			if assert.NotNil(t, query) {
-				assert.EqualValues(t, tc.expectedProblemSelector, query.GetProblemSelector())
-				assert.EqualValues(t, tc.expectedEntitySelector, query.GetEntitySelector())
+				problemsQuery := query.GetQuery()
+				assert.EqualValues(t, tc.expectedProblemSelector, problemsQuery.GetProblemSelector())
+				assert.EqualValues(t, tc.expectedEntitySelector, problemsQuery.GetEntitySelector())
+				assert.EqualValues(t, tc.expectedAggregation, query.GetAggregation())
+				assert.EqualValues(t, tc.expectedUnitID, query.GetUnitID())
+			}
+		})
+	}
+}
+
+// TestQueryParser_Errors tests that the QueryParser rejects invalid queries.
+func TestQueryParser_Errors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "invalid aggregation",
+			inputQuery:           "PV2;aggregate=avgDurationMinutes",
+			expectedErrorMessage: "invalid aggregation: avgDurationMinutes",
+		},
+		{
+			name:                 "unknown key",
+			inputQuery:           "PV2;problemSelector=status(open)&aggregation=count",
+			expectedErrorMessage: "unknown key",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+
+			assert.Nil(t, query)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErrorMessage)
 			}
 		})
 	}
@@ -11,29 +11,34 @@ import (
 func TestQueryProducer_Produce(t *testing.T) {
 	testConfigs := []struct {
 		name                   string
-		inputPV2Query          problems.Query
+		inputPV2Query          Query
 		expectedPV2QueryString string
 	}{
 		{
 			name:                   "valid with no problem or entity selectors",
-			inputPV2Query:          problems.NewQuery("", ""),
+			inputPV2Query:          newTestQuery(t, "", problems.NewQuery("", "")),
 			expectedPV2QueryString: "PV2;",
 		},
 		{
 			name:                   "valid with both problem and entity selectors",
-			inputPV2Query:          problems.NewQuery("status(open)", "mzId(7030365576649815430)"),
+			inputPV2Query:          newTestQuery(t, "", problems.NewQuery("status(open)", "mzId(7030365576649815430)")),
 			expectedPV2QueryString: "PV2;entitySelector=mzId(7030365576649815430)&problemSelector=status(open)",
 		},
 		{
 			name:                   "valid with just problem selector",
-			inputPV2Query:          problems.NewQuery("status(open)", ""),
+			inputPV2Query:          newTestQuery(t, "", problems.NewQuery("status(open)", "")),
 			expectedPV2QueryString: "PV2;problemSelector=status(open)",
 		},
 		{
 			name:                   "valid with just entity selector",
-			inputPV2Query:          problems.NewQuery("", "mzId(7030365576649815430)"),
+			inputPV2Query:          newTestQuery(t, "", problems.NewQuery("", "mzId(7030365576649815430)")),
 			expectedPV2QueryString: "PV2;entitySelector=mzId(7030365576649815430)",
 		},
+		{
+			name:                   "valid with aggregation",
+			inputPV2Query:          newTestQuery(t, MaxDurationMinutesAggregation, problems.NewQuery("status(open)", "")),
+			expectedPV2QueryString: "PV2;aggregate=maxDurationMinutes&problemSelector=status(open)",
+		},
 	}
 	for _, testConfig := range testConfigs {
 		tc := testConfig
@@ -43,3 +48,9 @@ func TestQueryProducer_Produce(t *testing.T) {
 		})
 	}
 }
+
+func newTestQuery(t *testing.T, aggregation string, query problems.Query) Query {
+	q, err := NewQuery(aggregation, query)
+	assert.NoError(t, err)
+	return *q
+}
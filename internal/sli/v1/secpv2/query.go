@@ -0,0 +1,96 @@
+package secpv2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/secpv2"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
+
+const (
+	// CountAggregation counts the security problems.
+	CountAggregation = "count"
+
+	// MaxRiskScoreAggregation returns the highest Davis risk score of the security problems.
+	MaxRiskScoreAggregation = "maxRiskScore"
+
+	// SumRiskScoreAggregation sums the Davis risk scores of the security problems.
+	SumRiskScoreAggregation = "sumRiskScore"
+)
+
+// riskLevels lists the Davis risk levels in ascending order of severity.
+var riskLevels = []string{"NONE", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// Query encapsulates a SECPV2 query.
+type Query struct {
+	aggregation  string
+	minRiskLevel string
+	query        secpv2.Query
+}
+
+// NewQuery creates a Query from the specified aggregation, minimum risk level and security problems query or returns an error.
+// If no aggregation is specified, the security problems are counted. If no minimum risk level is specified, security problems of all risk levels are included.
+func NewQuery(aggregation string, minRiskLevel string, query secpv2.Query) (*Query, error) {
+	if aggregation == "" {
+		aggregation = CountAggregation
+	}
+
+	switch aggregation {
+	case CountAggregation, MaxRiskScoreAggregation, SumRiskScoreAggregation:
+	default:
+		return nil, fmt.Errorf("invalid aggregation: %s", aggregation)
+	}
+
+	minRiskLevel = strings.ToUpper(minRiskLevel)
+	if minRiskLevel != "" && getRiskLevelRank(minRiskLevel) < 0 {
+		return nil, fmt.Errorf("invalid minimum risk level: %s", minRiskLevel)
+	}
+
+	return &Query{
+		aggregation:  aggregation,
+		minRiskLevel: minRiskLevel,
+		query:        query,
+	}, nil
+}
+
+// GetAggregation gets the aggregation.
+func (q *Query) GetAggregation() string {
+	return q.aggregation
+}
+
+// GetMinRiskLevel gets the minimum risk level or an empty string if none was specified.
+func (q *Query) GetMinRiskLevel() string {
+	return q.minRiskLevel
+}
+
+// GetUnitID gets the ID of the unit of the aggregated value, i.e. Count for counts and Unspecified for risk scores.
+func (q *Query) GetUnitID() string {
+	if q.aggregation == CountAggregation {
+		return unit.Count
+	}
+	return unit.Unspecified
+}
+
+// GetQuery gets the query.
+func (q *Query) GetQuery() secpv2.Query {
+	return q.query
+}
+
+// IncludesRiskLevel returns true if security problems with the specified risk level should be included.
+func (q *Query) IncludesRiskLevel(riskLevel string) bool {
+	if q.minRiskLevel == "" {
+		return true
+	}
+
+	return getRiskLevelRank(strings.ToUpper(riskLevel)) >= getRiskLevelRank(q.minRiskLevel)
+}
+
+func getRiskLevelRank(riskLevel string) int {
+	for i, l := range riskLevels {
+		if l == riskLevel {
+			return i
+		}
+	}
+	return -1
+}
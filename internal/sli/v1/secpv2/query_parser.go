@@ -11,7 +11,11 @@ import (
 // SecurityProblemsV2Prefix is the prefix of Security Problems v2 queries.
 const SecurityProblemsV2Prefix = "SECPV2"
 
-const securityProblemSelectorKey = "securityProblemSelector"
+const (
+	securityProblemSelectorKey = "securityProblemSelector"
+	aggregateKey               = "aggregate"
+	minRiskLevelKey            = "minRiskLevel"
+)
 
 // QueryParser will parse a v1 Security Problems v2 query string (usually found in sli.yaml files) into a Query
 type QueryParser struct {
@@ -26,7 +30,7 @@ func NewQueryParser(query string) *QueryParser {
 }
 
 // Parse parses the query string into a Query or returns an error.
-func (p *QueryParser) Parse() (*secpv2.Query, error) {
+func (p *QueryParser) Parse() (*Query, error) {
 	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
 	if err != nil {
 		return nil, err
@@ -50,8 +54,7 @@ func (p *QueryParser) Parse() (*secpv2.Query, error) {
 		return nil, err
 	}
 
-	query := secpv2.NewQuery(keyValuePairs.GetValue(securityProblemSelectorKey))
-	return &query, nil
+	return NewQuery(keyValuePairs.GetValue(aggregateKey), keyValuePairs.GetValue(minRiskLevelKey), secpv2.NewQuery(keyValuePairs.GetValue(securityProblemSelectorKey)))
 }
 
 type securityProblemsQueryKeyValidator struct{}
@@ -59,7 +62,7 @@ type securityProblemsQueryKeyValidator struct{}
 // ValidateKey returns true if the specified key is part of a Security Problems v2 query.
 func (v *securityProblemsQueryKeyValidator) ValidateKey(key string) bool {
 	switch key {
-	case securityProblemSelectorKey:
+	case securityProblemSelectorKey, aggregateKey, minRiskLevelKey:
 		return true
 	default:
 		return false
@@ -3,6 +3,8 @@ package secpv2
 import (
 	"testing"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/secpv2"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,15 +14,32 @@ func TestQueryParser(t *testing.T) {
 		name                            string
 		inputQuery                      string
 		expectedSecurityProblemSelector string
+		expectedAggregation             string
+		expectedMinRiskLevel            string
 	}{
 		{
 			name:                            "valid",
 			inputQuery:                      "SECPV2;securityProblemSelector=status(open)",
 			expectedSecurityProblemSelector: "status(open)",
+			expectedAggregation:             CountAggregation,
 		},
 		{
-			name:       "valid - empty",
-			inputQuery: "SECPV2;",
+			name:                "valid - empty",
+			inputQuery:          "SECPV2;",
+			expectedAggregation: CountAggregation,
+		},
+		{
+			name:                            "valid with aggregation and minimum risk level",
+			inputQuery:                      "SECPV2;securityProblemSelector=status(open)&aggregate=maxRiskScore&minRiskLevel=HIGH",
+			expectedSecurityProblemSelector: "status(open)",
+			expectedAggregation:             MaxRiskScoreAggregation,
+			expectedMinRiskLevel:            "HIGH",
+		},
+		{
+			name:                 "valid with lower case minimum risk level",
+			inputQuery:           "SECPV2;minRiskLevel=critical",
+			expectedAggregation:  CountAggregation,
+			expectedMinRiskLevel: "CRITICAL",
 		},
 	}
 	for _, tc := range tests {
@@ -28,8 +47,72 @@ func TestQueryParser(t *testing.T) {
 			query, err := NewQueryParser(tc.inputQuery).Parse()
 			assert.NoError(t, err)
 			if assert.NotNil(t, query) {
-				assert.EqualValues(t, tc.expectedSecurityProblemSelector, query.GetSecurityProblemSelector())
+				securityProblemsQuery := query.GetQuery()
+				assert.EqualValues(t, tc.expectedSecurityProblemSelector, securityProblemsQuery.GetSecurityProblemSelector())
+				assert.EqualValues(t, tc.expectedAggregation, query.GetAggregation())
+				assert.EqualValues(t, tc.expectedMinRiskLevel, query.GetMinRiskLevel())
+			}
+		})
+	}
+}
+
+// TestQueryParser_Errors tests that the QueryParser rejects invalid queries.
+func TestQueryParser_Errors(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "invalid aggregation",
+			inputQuery:           "SECPV2;aggregate=avgRiskScore",
+			expectedErrorMessage: "invalid aggregation: avgRiskScore",
+		},
+		{
+			name:                 "invalid minimum risk level",
+			inputQuery:           "SECPV2;minRiskLevel=SEVERE",
+			expectedErrorMessage: "invalid minimum risk level: SEVERE",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+
+			assert.Nil(t, query)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErrorMessage)
 			}
 		})
 	}
 }
+
+// TestQuery_IncludesRiskLevel tests filtering security problems by risk level.
+func TestQuery_IncludesRiskLevel(t *testing.T) {
+	query, err := NewQuery("", "HIGH", secpv2.NewQuery(""))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, query.IncludesRiskLevel("CRITICAL"))
+	assert.True(t, query.IncludesRiskLevel("HIGH"))
+	assert.False(t, query.IncludesRiskLevel("MEDIUM"))
+	assert.False(t, query.IncludesRiskLevel("UNKNOWN"))
+
+	query, err = NewQuery("", "", secpv2.NewQuery(""))
+	if assert.NoError(t, err) {
+		assert.True(t, query.IncludesRiskLevel("NONE"))
+	}
+}
+
+// TestQuery_GetUnitID tests that counts and risk scores are returned with the expected units.
+func TestQuery_GetUnitID(t *testing.T) {
+	query, err := NewQuery(CountAggregation, "", secpv2.NewQuery(""))
+	if assert.NoError(t, err) {
+		assert.Equal(t, unit.Count, query.GetUnitID())
+	}
+
+	query, err = NewQuery(MaxRiskScoreAggregation, "", secpv2.NewQuery(""))
+	if assert.NoError(t, err) {
+		assert.Equal(t, unit.Unspecified, query.GetUnitID())
+	}
+}
@@ -11,19 +11,24 @@ import (
 func TestQueryProducer_Produce(t *testing.T) {
 	testConfigs := []struct {
 		name                      string
-		inputSECPV2Query          secpv2.Query
+		inputSECPV2Query          Query
 		expectedSECPV2QueryString string
 	}{
 		{
 			name:                      "valid with no security problem selectors",
-			inputSECPV2Query:          secpv2.NewQuery(""),
+			inputSECPV2Query:          newTestQuery(t, "", "", secpv2.NewQuery("")),
 			expectedSECPV2QueryString: "SECPV2;",
 		},
 		{
 			name:                      "valid with security problem selector",
-			inputSECPV2Query:          secpv2.NewQuery("status(open)"),
+			inputSECPV2Query:          newTestQuery(t, "", "", secpv2.NewQuery("status(open)")),
 			expectedSECPV2QueryString: "SECPV2;securityProblemSelector=status(open)",
 		},
+		{
+			name:                      "valid with aggregation and minimum risk level",
+			inputSECPV2Query:          newTestQuery(t, SumRiskScoreAggregation, "MEDIUM", secpv2.NewQuery("status(open)")),
+			expectedSECPV2QueryString: "SECPV2;aggregate=sumRiskScore&minRiskLevel=MEDIUM&securityProblemSelector=status(open)",
+		},
 	}
 	for _, testConfig := range testConfigs {
 		tc := testConfig
@@ -33,3 +38,9 @@ func TestQueryProducer_Produce(t *testing.T) {
 		})
 	}
 }
+
+func newTestQuery(t *testing.T, aggregation string, minRiskLevel string, query secpv2.Query) Query {
+	q, err := NewQuery(aggregation, minRiskLevel, query)
+	assert.NoError(t, err)
+	return *q
+}
@@ -1,24 +1,124 @@
 package slo
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/slo"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
+)
 
 // Query encapsulates an SLO query-
+// The SLO is either identified by its ID or resolved using its name or an SLO selector.
 type Query struct {
-	sloID string
+	sloID       string
+	sloName     string
+	sloSelector string
+	field       string
 }
 
-// NewQuery creates a Query from the specified SLO ID or returns an error.
-func NewQuery(sloID string) (*Query, error) {
+// NewQuery creates a Query from the specified SLO ID and field or returns an error.
+// If no field is specified, the evaluated percentage is used.
+func NewQuery(sloID string, field string) (*Query, error) {
 	if sloID == "" {
 		return nil, errors.New("SLO ID should not be empty")
 	}
 
+	field, err := validateField(field)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Query{
 		sloID: sloID,
+		field: field,
+	}, nil
+}
+
+// NewQueryBySLOName creates a Query for the SLO with the specified name and field or returns an error.
+// If no field is specified, the evaluated percentage is used.
+func NewQueryBySLOName(sloName string, field string) (*Query, error) {
+	if sloName == "" {
+		return nil, errors.New("SLO name should not be empty")
+	}
+
+	field, err := validateField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		sloName: sloName,
+		field:   field,
+	}, nil
+}
+
+// NewQueryBySLOSelector creates a Query for the SLO matching the specified SLO selector and field or returns an error.
+// If no field is specified, the evaluated percentage is used.
+func NewQueryBySLOSelector(sloSelector string, field string) (*Query, error) {
+	if sloSelector == "" {
+		return nil, errors.New("SLO selector should not be empty")
+	}
+
+	field, err := validateField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		sloSelector: sloSelector,
+		field:       field,
 	}, nil
 }
 
-// GetSLOID gets the SLO ID.
+// GetSLOID gets the SLO ID or an empty string if the SLO should be resolved using a selector.
 func (q *Query) GetSLOID() string {
 	return q.sloID
 }
+
+// GetSLOName gets the SLO name or an empty string if none was specified.
+func (q *Query) GetSLOName() string {
+	return q.sloName
+}
+
+// GetSLOSelector gets the SLO selector used to resolve the SLO or an empty string if the SLO is identified by its ID.
+// For queries by SLO name, a selector matching the name is returned.
+func (q *Query) GetSLOSelector() string {
+	if q.sloName != "" {
+		return "name(\"" + escapeSelectorValue(q.sloName) + "\")"
+	}
+	return q.sloSelector
+}
+
+// GetField gets the field.
+func (q *Query) GetField() string {
+	return q.field
+}
+
+// GetUnitID gets the ID of the unit of the field's value.
+// The error budget burn rate is a multiple of the allowed burn rate and thus has no unit, all other fields are percentages.
+func (q *Query) GetUnitID() string {
+	if q.field == slo.ErrorBudgetBurnRateField {
+		return unit.Unspecified
+	}
+	return unit.Percent
+}
+
+// validateField returns the field, or the evaluated percentage field if none is specified, or an error if the field is unknown.
+func validateField(field string) (string, error) {
+	if field == "" {
+		return slo.EvaluatedPercentageField, nil
+	}
+
+	if !slo.IsValidField(field) {
+		return "", fmt.Errorf("unknown field: %s", field)
+	}
+
+	return field, nil
+}
+
+// escapeSelectorValue escapes the special characters ~ and " using ~ so that the value can be used within quotes in a selector.
+func escapeSelectorValue(value string) string {
+	return strings.NewReplacer("~", "~~", "\"", "~\"").Replace(value)
+}
@@ -10,6 +10,13 @@ import (
 // SLOPrefix is the prefix of SLO queries.
 const SLOPrefix = "SLO"
 
+const (
+	idKey       = "id"
+	nameKey     = "name"
+	selectorKey = "selector"
+	fieldKey    = "field"
+)
+
 // QueryParser will parse a v1 SLO query string (usually found in sli.yaml files) into a Query
 type QueryParser struct {
 	query string
@@ -37,10 +44,59 @@ func (p *QueryParser) Parse() (*Query, error) {
 		return nil, fmt.Errorf("SLO queries should start with %s", SLOPrefix)
 	}
 
-	sloID, err := pieces.Get(1)
+	sloQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	// a query without key-value pairs consists solely of the SLO ID
+	if !strings.Contains(sloQueryString, "=") {
+		return NewQuery(sloQueryString, "")
+	}
+
+	keyValuePairs, err := common.NewSLIParser(sloQueryString, &sloQueryKeyValidator{}).Parse()
 	if err != nil {
 		return nil, err
 	}
 
-	return NewQuery(sloID)
+	return newQueryFromKeyValuePairs(*keyValuePairs)
+}
+
+// newQueryFromKeyValuePairs creates a Query identifying the SLO by either its ID, name or an SLO selector or returns an error.
+func newQueryFromKeyValuePairs(keyValuePairs common.KeyValuePairs) (*Query, error) {
+	id := keyValuePairs.GetValue(idKey)
+	name := keyValuePairs.GetValue(nameKey)
+	selector := keyValuePairs.GetValue(selectorKey)
+	field := keyValuePairs.GetValue(fieldKey)
+
+	specified := 0
+	for _, value := range []string{id, name, selector} {
+		if value != "" {
+			specified++
+		}
+	}
+	if specified > 1 {
+		return nil, fmt.Errorf("only one of %s, %s or %s may be specified", idKey, nameKey, selectorKey)
+	}
+
+	switch {
+	case name != "":
+		return NewQueryBySLOName(name, field)
+	case selector != "":
+		return NewQueryBySLOSelector(selector, field)
+	default:
+		return NewQuery(id, field)
+	}
+}
+
+type sloQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of an SLO query.
+func (v *sloQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case idKey, nameKey, selectorKey, fieldKey:
+		return true
+	default:
+		return false
+	}
 }
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/slo"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/unit"
 )
 
 // TestQueryParser tests the QueryParser
@@ -12,6 +15,9 @@ func TestQueryParser(t *testing.T) {
 		name                 string
 		inputQuery           string
 		expectedSLOID        string
+		expectedSLOName      string
+		expectedSLOSelector  string
+		expectedField        string
 		expectError          bool
 		expectedErrorMessage string
 	}{
@@ -19,6 +25,87 @@ func TestQueryParser(t *testing.T) {
 			name:          "valid",
 			inputQuery:    "SLO;524ca177-849b-3e8c-8175-42b93fbc33c5",
 			expectedSLOID: "524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedField: "evaluatedPercentage",
+		},
+		{
+			name:          "valid - key-value pairs without field",
+			inputQuery:    "SLO;id=524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedSLOID: "524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedField: "evaluatedPercentage",
+		},
+		{
+			name:          "valid - error budget",
+			inputQuery:    "SLO;id=524ca177-849b-3e8c-8175-42b93fbc33c5&field=errorBudget",
+			expectedSLOID: "524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedField: "errorBudget",
+		},
+		{
+			name:          "valid - error budget burn rate",
+			inputQuery:    "SLO;field=errorBudgetBurnRate&id=524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedSLOID: "524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedField: "errorBudgetBurnRate",
+		},
+		{
+			name:          "valid - target",
+			inputQuery:    "SLO;id=524ca177-849b-3e8c-8175-42b93fbc33c5&field=target",
+			expectedSLOID: "524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedField: "target",
+		},
+		{
+			name:          "valid - warning",
+			inputQuery:    "SLO;id=524ca177-849b-3e8c-8175-42b93fbc33c5&field=warning",
+			expectedSLOID: "524ca177-849b-3e8c-8175-42b93fbc33c5",
+			expectedField: "warning",
+		},
+		{
+			name:                "valid - name",
+			inputQuery:          "SLO;name=carts availability",
+			expectedSLOName:     "carts availability",
+			expectedSLOSelector: "name(\"carts availability\")",
+			expectedField:       "evaluatedPercentage",
+		},
+		{
+			name:                "valid - name with special characters and field",
+			inputQuery:          "SLO;name=carts \"p95\" ~ latency&field=errorBudget",
+			expectedSLOName:     "carts \"p95\" ~ latency",
+			expectedSLOSelector: "name(\"carts ~\"p95~\" ~~ latency\")",
+			expectedField:       "errorBudget",
+		},
+		{
+			name:                "valid - selector",
+			inputQuery:          "SLO;selector=text(\"carts\"),managementZone(\"sockshop\")",
+			expectedSLOSelector: "text(\"carts\"),managementZone(\"sockshop\")",
+			expectedField:       "evaluatedPercentage",
+		},
+		{
+			name:                 "invalid - ID and name",
+			inputQuery:           "SLO;id=524ca177-849b-3e8c-8175-42b93fbc33c5&name=carts availability",
+			expectError:          true,
+			expectedErrorMessage: "only one of id, name or selector may be specified",
+		},
+		{
+			name:                 "invalid - name and selector",
+			inputQuery:           "SLO;name=carts availability&selector=text(\"carts\")",
+			expectError:          true,
+			expectedErrorMessage: "only one of id, name or selector may be specified",
+		},
+		{
+			name:                 "invalid - unknown field",
+			inputQuery:           "SLO;id=524ca177-849b-3e8c-8175-42b93fbc33c5&field=status",
+			expectError:          true,
+			expectedErrorMessage: "unknown field: status",
+		},
+		{
+			name:                 "invalid - field without ID",
+			inputQuery:           "SLO;field=errorBudget",
+			expectError:          true,
+			expectedErrorMessage: "SLO ID should not be empty",
+		},
+		{
+			name:                 "invalid - unknown key",
+			inputQuery:           "SLO;id=524ca177-849b-3e8c-8175-42b93fbc33c5&timeframe=-1d",
+			expectError:          true,
+			expectedErrorMessage: "unknown key",
 		},
 		{
 			name:                 "invalid - no SLO prefix",
@@ -45,8 +132,33 @@ func TestQueryParser(t *testing.T) {
 				assert.NoError(t, err)
 				if assert.NotNil(t, query) {
 					assert.EqualValues(t, tc.expectedSLOID, query.GetSLOID())
+					assert.EqualValues(t, tc.expectedSLOName, query.GetSLOName())
+					assert.EqualValues(t, tc.expectedSLOSelector, query.GetSLOSelector())
+					assert.EqualValues(t, tc.expectedField, query.GetField())
 				}
 			}
 		})
 	}
 }
+
+// TestQuery_GetUnitID tests that all fields except the error budget burn rate are returned as percentages.
+func TestQuery_GetUnitID(t *testing.T) {
+	tests := []struct {
+		field          string
+		expectedUnitID string
+	}{
+		{field: slo.EvaluatedPercentageField, expectedUnitID: unit.Percent},
+		{field: slo.ErrorBudgetField, expectedUnitID: unit.Percent},
+		{field: slo.ErrorBudgetBurnRateField, expectedUnitID: unit.Unspecified},
+		{field: slo.TargetField, expectedUnitID: unit.Percent},
+		{field: slo.WarningField, expectedUnitID: unit.Percent},
+	}
+	for _, tc := range tests {
+		t.Run(tc.field, func(t *testing.T) {
+			query, err := NewQuery("524ca177-849b-3e8c-8175-42b93fbc33c5", tc.field)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedUnitID, query.GetUnitID())
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package slo
 
 import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/slo"
 	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
 )
 
@@ -15,6 +16,25 @@ func NewQueryProducer(query Query) QueryProducer {
 }
 
 // Produce returns SLO query string for a Query.
+// The short form consisting solely of the SLO ID is used if the evaluated percentage of an SLO identified by its ID is queried.
 func (p QueryProducer) Produce() string {
-	return common.ProducePrefixedSLI(SLOPrefix, p.query.GetSLOID())
+	if p.query.GetSLOID() != "" && p.query.GetField() == slo.EvaluatedPercentageField {
+		return common.ProducePrefixedSLI(SLOPrefix, p.query.GetSLOID())
+	}
+
+	keyValues := make(map[string]string, 2)
+	switch {
+	case p.query.GetSLOName() != "":
+		keyValues[nameKey] = p.query.GetSLOName()
+	case p.query.GetSLOID() != "":
+		keyValues[idKey] = p.query.GetSLOID()
+	default:
+		keyValues[selectorKey] = p.query.GetSLOSelector()
+	}
+
+	if p.query.GetField() != slo.EvaluatedPercentageField {
+		keyValues[fieldKey] = p.query.GetField()
+	}
+
+	return common.ProducePrefixedSLI(SLOPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
 }
@@ -14,9 +14,29 @@ func TestQueryProducer_Produce(t *testing.T) {
 	}{
 		{
 			name:                   "valid",
-			inputSLOQuery:          newQuery(t, "524ca177-849b-3e8c-8175-42b93fbc33c5"),
+			inputSLOQuery:          newQuery(t, "524ca177-849b-3e8c-8175-42b93fbc33c5", ""),
 			expectedSLOQueryString: "SLO;524ca177-849b-3e8c-8175-42b93fbc33c5",
 		},
+		{
+			name:                   "valid - evaluated percentage",
+			inputSLOQuery:          newQuery(t, "524ca177-849b-3e8c-8175-42b93fbc33c5", "evaluatedPercentage"),
+			expectedSLOQueryString: "SLO;524ca177-849b-3e8c-8175-42b93fbc33c5",
+		},
+		{
+			name:                   "valid - error budget",
+			inputSLOQuery:          newQuery(t, "524ca177-849b-3e8c-8175-42b93fbc33c5", "errorBudget"),
+			expectedSLOQueryString: "SLO;field=errorBudget&id=524ca177-849b-3e8c-8175-42b93fbc33c5",
+		},
+		{
+			name:                   "valid - name",
+			inputSLOQuery:          newQueryBySLOName(t, "carts availability", ""),
+			expectedSLOQueryString: "SLO;name=carts availability",
+		},
+		{
+			name:                   "valid - selector and field",
+			inputSLOQuery:          newQueryBySLOSelector(t, "text(\"carts\")", "target"),
+			expectedSLOQueryString: "SLO;field=target&selector=text(\"carts\")",
+		},
 	}
 	for _, testConfig := range testConfigs {
 		tc := testConfig
@@ -27,8 +47,22 @@ func TestQueryProducer_Produce(t *testing.T) {
 	}
 }
 
-func newQuery(t *testing.T, sloID string) Query {
-	query, err := NewQuery(sloID)
+func newQuery(t *testing.T, sloID string, field string) Query {
+	query, err := NewQuery(sloID, field)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
+
+func newQueryBySLOName(t *testing.T, sloName string, field string) Query {
+	query, err := NewQueryBySLOName(sloName, field)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
+
+func newQueryBySLOSelector(t *testing.T, sloSelector string, field string) Query {
+	query, err := NewQueryBySLOSelector(sloSelector, field)
 	assert.NoError(t, err)
 	assert.NotNil(t, query)
 	return *query
@@ -0,0 +1,69 @@
+package synthetic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/synthetic"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// SyntheticPrefix is the prefix of synthetic monitor queries.
+const SyntheticPrefix = "SYNTH"
+
+const (
+	monitorIDKey = "monitorId"
+	metricKey    = "metric"
+)
+
+// QueryParser will parse a v1 synthetic monitor query string (usually found in sli.yaml files) into a Query
+type QueryParser struct {
+	query string
+}
+
+// NewQueryParser creates a new QueryParser for the specified synthetic monitor query string.
+func NewQueryParser(query string) *QueryParser {
+	return &QueryParser{
+		query: strings.TrimSpace(query),
+	}
+}
+
+// Parse parses the query string into a Query or returns an error.
+func (p *QueryParser) Parse() (*synthetic.Query, error) {
+	pieces, err := common.NewSLIPrefixParser(p.query, 2).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := pieces.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != SyntheticPrefix {
+		return nil, fmt.Errorf("synthetic monitor queries should start with %s", SyntheticPrefix)
+	}
+
+	syntheticQueryString, err := pieces.Get(1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePairs, err := common.NewSLIParser(syntheticQueryString, &syntheticQueryKeyValidator{}).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return synthetic.NewQuery(keyValuePairs.GetValue(monitorIDKey), keyValuePairs.GetValue(metricKey))
+}
+
+type syntheticQueryKeyValidator struct{}
+
+// ValidateKey returns true if the specified key is part of a synthetic monitor query.
+func (v *syntheticQueryKeyValidator) ValidateKey(key string) bool {
+	switch key {
+	case monitorIDKey, metricKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,79 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQueryParser tests the QueryParser
+func TestQueryParser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputQuery           string
+		expectedMonitorID    string
+		expectedMetric       string
+		expectError          bool
+		expectedErrorMessage string
+	}{
+		{
+			name:              "valid - browser monitor availability",
+			inputQuery:        "SYNTH;monitorId=SYNTHETIC_TEST-1A2B3C4D5E6F7081&metric=availability",
+			expectedMonitorID: "SYNTHETIC_TEST-1A2B3C4D5E6F7081",
+			expectedMetric:    "availability",
+		},
+		{
+			name:              "valid - HTTP monitor duration",
+			inputQuery:        "SYNTH;metric=duration&monitorId=HTTP_CHECK-1A2B3C4D5E6F7081",
+			expectedMonitorID: "HTTP_CHECK-1A2B3C4D5E6F7081",
+			expectedMetric:    "duration",
+		},
+		{
+			name:                 "invalid - no SYNTH prefix",
+			inputQuery:           ";monitorId=SYNTHETIC_TEST-1A2B3C4D5E6F7081&metric=availability",
+			expectError:          true,
+			expectedErrorMessage: "synthetic monitor queries should start with SYNTH",
+		},
+		{
+			name:                 "invalid - missing monitor ID",
+			inputQuery:           "SYNTH;metric=availability",
+			expectError:          true,
+			expectedErrorMessage: "monitor ID should not be empty",
+		},
+		{
+			name:                 "invalid - not a monitor ID",
+			inputQuery:           "SYNTH;monitorId=SERVICE-1A2B3C4D5E6F7081&metric=availability",
+			expectError:          true,
+			expectedErrorMessage: "monitor ID should start with",
+		},
+		{
+			name:                 "invalid - missing metric",
+			inputQuery:           "SYNTH;monitorId=SYNTHETIC_TEST-1A2B3C4D5E6F7081",
+			expectError:          true,
+			expectedErrorMessage: "metric should not be empty",
+		},
+		{
+			name:                 "invalid - unknown metric",
+			inputQuery:           "SYNTH;monitorId=SYNTHETIC_TEST-1A2B3C4D5E6F7081&metric=errors",
+			expectError:          true,
+			expectedErrorMessage: "unknown metric: errors",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := NewQueryParser(tc.inputQuery).Parse()
+			if tc.expectError {
+				assert.Nil(t, query)
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				}
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, query) {
+					assert.EqualValues(t, tc.expectedMonitorID, query.GetMonitorID())
+					assert.EqualValues(t, tc.expectedMetric, query.GetMetric())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package synthetic
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/synthetic"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/v1/common"
+)
+
+// QueryProducer for synthetic monitor queries.
+type QueryProducer struct {
+	query synthetic.Query
+}
+
+// NewQueryProducer creates a QueryProducer for the specified synthetic monitor Query.
+func NewQueryProducer(query synthetic.Query) QueryProducer {
+	return QueryProducer{query: query}
+}
+
+// Produce returns the synthetic monitor query string for a Query.
+func (p QueryProducer) Produce() string {
+	keyValues := map[string]string{
+		monitorIDKey: p.query.GetMonitorID(),
+		metricKey:    p.query.GetMetric(),
+	}
+
+	return common.ProducePrefixedSLI(SyntheticPrefix, common.NewSLIProducer(common.NewKeyValuePairs(keyValues)).Produce())
+}
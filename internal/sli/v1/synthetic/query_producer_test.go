@@ -0,0 +1,36 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/synthetic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryProducer_Produce(t *testing.T) {
+	testConfigs := []struct {
+		name                         string
+		inputSyntheticQuery          synthetic.Query
+		expectedSyntheticQueryString string
+	}{
+		{
+			name:                         "valid",
+			inputSyntheticQuery:          newQuery(t, "SYNTHETIC_TEST-1A2B3C4D5E6F7081", "availability"),
+			expectedSyntheticQueryString: "SYNTH;metric=availability&monitorId=SYNTHETIC_TEST-1A2B3C4D5E6F7081",
+		},
+	}
+	for _, testConfig := range testConfigs {
+		tc := testConfig
+		t.Run(tc.name, func(t *testing.T) {
+			syntheticQueryString := NewQueryProducer(tc.inputSyntheticQuery).Produce()
+			assert.Equal(t, tc.expectedSyntheticQueryString, syntheticQueryString)
+		})
+	}
+}
+
+func newQuery(t *testing.T, monitorID string, metric string) synthetic.Query {
+	query, err := synthetic.NewQuery(monitorID, metric)
+	assert.NoError(t, err)
+	assert.NotNil(t, query)
+	return *query
+}
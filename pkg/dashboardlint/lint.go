@@ -0,0 +1,57 @@
+// Package dashboardlint lints Dynatrace dashboards used for Keptn quality gates, i.e. validates their KQG configuration without querying any data.
+package dashboardlint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/dashboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/ff"
+)
+
+// Report is the result of linting a dashboard.
+type Report = dashboard.LintReport
+
+// Problem is a problem found while linting a dashboard.
+type Problem = dashboard.LintProblem
+
+// ProblemKind is the kind of a problem found while linting a dashboard.
+type ProblemKind = dashboard.LintProblemKind
+
+const (
+	// MarkdownProblemKind indicates that the KQG configuration of a markdown tile is invalid.
+	MarkdownProblemKind = dashboard.MarkdownLintProblemKind
+
+	// TileConfigurationProblemKind indicates that a tile is configured in a way that causes its SLIs to fail or prevents them from being retrieved.
+	TileConfigurationProblemKind = dashboard.TileConfigurationLintProblemKind
+
+	// DuplicateSLINameProblemKind indicates that a tile produces an SLI whose name is also produced by another tile.
+	DuplicateSLINameProblemKind = dashboard.DuplicateSLINameLintProblemKind
+
+	// DuplicateDisplayNameProblemKind indicates that a tile produces an SLO whose display name is also used by another tile.
+	DuplicateDisplayNameProblemKind = dashboard.DuplicateDisplayNameLintProblemKind
+
+	// UnsupportedTileTypeProblemKind indicates that a tile is of a type that is not supported and is thus ignored.
+	UnsupportedTileTypeProblemKind = dashboard.UnsupportedTileTypeLintProblemKind
+)
+
+// Options are the options for linting a dashboard, corresponding to the feature flags of the dynatrace-service.
+// The zero value lints a dashboard as the dynatrace-service does by default.
+type Options struct {
+	SkipLowercaseSLINames                bool
+	SkipCheckDuplicateSLIAndDisplayNames bool
+}
+
+// Lint lints the specified dashboard JSON, e.g. as exported from Dynatrace or stored as a Keptn resource.
+// Duplicate names are not checked for SLO tiles, whose SLI names depend on the SLOs they show.
+// It returns an error if the JSON cannot be parsed.
+func Lint(ctx context.Context, dashboardJSON []byte, options Options) (*Report, error) {
+	d := &dynatrace.Dashboard{}
+	if err := json.Unmarshal(dashboardJSON, d); err != nil {
+		return nil, fmt.Errorf("could not parse dashboard: %w", err)
+	}
+
+	return dashboard.LintDashboard(ctx, d, ff.NewGetSLIFeatureFlags(options.SkipLowercaseSLINames, false, options.SkipCheckDuplicateSLIAndDisplayNames))
+}
@@ -0,0 +1,33 @@
+package dashboardlint
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	dashboardJSON, err := os.ReadFile("./testdata/dashboard_with_problems.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	report, err := Lint(context.Background(), dashboardJSON, Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "12345678-1111-4444-8888-123456789012", report.DashboardID)
+	if assert.Len(t, report.Problems, 2) {
+		assert.Equal(t, Problem{Kind: TileConfigurationProblemKind, TileIndex: 1, TileName: "Response time; sli=response_time; pass=<<100", TileType: "DATA_EXPLORER", Message: "error validating Data Explorer tile: error parsing SLO definition: invalid definition for 'pass': invalid criteria value(s): <<100"}, report.Problems[0])
+		assert.Equal(t, Problem{Kind: UnsupportedTileTypeProblemKind, TileIndex: 2, TileName: "Service health", TileType: "SERVICE_VERSATILE", Message: "tile type SERVICE_VERSATILE is not supported and will be ignored"}, report.Problems[1])
+	}
+}
+
+func TestLint_InvalidJSON(t *testing.T) {
+	report, err := Lint(context.Background(), []byte("{"), Options{})
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}